@@ -0,0 +1,78 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// chkmqdrain moves client applications off the queue manager before it is stopped, and is intended to be
+// used as a Kubernetes preStop hook
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/drain"
+	"github.com/ibm-messaging/mq-container/internal/ready"
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+	"github.com/ibm-messaging/mq-container/pkg/name"
+)
+
+// statusTimeout is the time allowed to get the status of the queue manager
+const statusTimeout = 10 * time.Second
+
+func doMain() int {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
+	defer cancel()
+
+	name, err := name.GetQueueManagerName()
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	log, err := logger.NewLogger(os.Stdout, os.Getenv("DEBUG") == "true", false, name)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	timeout, err := drain.GetTimeout()
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	statusCtx, cancelStatus := context.WithTimeout(ctx, statusTimeout)
+	defer cancelStatus()
+	status, err := ready.GetQueueManagerStatus(statusCtx, name)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	// Clients can only be connected to the active instance
+	if !status.Status().ActiveQM() {
+		log.Printf("Queue manager %v is not active, so there are no client connections to drain", name)
+		return 0
+	}
+	err = drain.Drain(ctx, name, timeout, log)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	return 0
+}
+
+func main() {
+	os.Exit(doMain())
+}
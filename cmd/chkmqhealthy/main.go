@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2017, 2024
+© Copyright IBM Corporation 2017, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -21,55 +21,26 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"os/signal"
-	"strings"
 
+	"github.com/ibm-messaging/mq-container/internal/probe"
 	"github.com/ibm-messaging/mq-container/pkg/name"
 )
 
-func queueManagerHealthy(ctx context.Context) (bool, error) {
-	name, err := name.GetQueueManagerName()
-	if err != nil {
-		return false, err
-	}
-	// Specify the queue manager name, just in case someone's created a second queue manager
-	// #nosec G204
-	cmd := exec.CommandContext(ctx, "dspmq", "-n", "-m", name)
-	// Run the command and wait for completion
-	out, err := cmd.CombinedOutput()
-	fmt.Printf("%s", out)
-	if err != nil {
-		fmt.Println(err)
-		return false, err
-	}
-	readyStrings := []string{
-		"(RUNNING)",
-		"(RUNNING AS STANDBY)",
-		"(RECOVERY GROUP LEADER)",
-		"(STARTING)",
-		"(REPLICA)",
-	}
-	for _, checkString := range readyStrings {
-		if strings.Contains(string(out), checkString) {
-			return true, nil
-		}
-	}
-	return false, nil
-}
-
 func doMain() int {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
 	defer cancel()
 
-	healthy, err := queueManagerHealthy(ctx)
+	name, err := name.GetQueueManagerName()
 	if err != nil {
-		return 2
+		fmt.Println(err)
+		return probe.CodeError
 	}
-	if !healthy {
-		return 1
+	result := probe.Healthy(ctx, probe.NewCommandSource(name))
+	if result.Message != "" {
+		fmt.Println(result.Message)
 	}
-	return 0
+	return result.Code
 }
 
 func main() {
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2017, 2024
+© Copyright IBM Corporation 2017, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -20,11 +20,10 @@ package main
 import (
 	"context"
 	"fmt"
-	"net"
 	"os"
 	"os/signal"
 
-	"github.com/ibm-messaging/mq-container/internal/ready"
+	"github.com/ibm-messaging/mq-container/internal/probe"
 	"github.com/ibm-messaging/mq-container/pkg/name"
 )
 
@@ -32,57 +31,18 @@ func doMain() int {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
 	defer cancel()
 
-	// Check if runmqserver has indicated that it's finished configuration
-	r, err := ready.Check()
-	if !r || err != nil {
-		return 1
-	}
 	name, err := name.GetQueueManagerName()
 	if err != nil {
 		fmt.Println(err)
-		return 1
-	}
-
-	// Check if the queue manager has a running listener
-	status, err := ready.Status(ctx, name)
-	if err != nil {
-		return 1
+		return probe.CodeFailed
 	}
-	switch status {
-	case ready.StatusActiveQM:
-		portOpen, err := checkPort("127.0.0.1:1414")
-		if err != nil {
-			fmt.Println(err)
-		}
-		if !portOpen {
-			return 1
-		}
-		return 0
-	case ready.StatusRecoveryQM:
-		fmt.Printf("Detected queue manager running as recovery leader")
-		return 0
-	case ready.StatusStandbyQM:
-		fmt.Printf("Detected queue manager running in standby mode")
-		return 10
-	case ready.StatusReplicaQM:
-		fmt.Printf("Detected queue manager running in replica mode")
-		return 20
-	default:
-		return 1
+	result := probe.Ready(ctx, probe.NewCommandSource(name))
+	if result.Message != "" {
+		fmt.Println(result.Message)
 	}
+	return result.Code
 }
 
 func main() {
 	os.Exit(doMain())
 }
-
-func checkPort(address string) (portOpen bool, err error) {
-	var conn net.Conn
-	conn, err = net.Dial("tcp", address)
-	if err != nil {
-		return
-	}
-	portOpen = true
-	err = conn.Close()
-	return
-}
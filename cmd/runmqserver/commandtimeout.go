@@ -0,0 +1,76 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/command"
+)
+
+// diagnosticsTimeout is the time allowed for the FDC summary when a command times out
+const diagnosticsTimeout = 30 * time.Second
+
+// logTimeoutDiagnostics logs a summary of the FDC files and the processes in the container, to help find out
+// why a command didn't complete, for example because of a hung filesystem
+func logTimeoutDiagnostics(timeoutErr *command.TimeoutError) {
+	log.Printf("Collecting diagnostics because %v", timeoutErr)
+	logFDCSummaryWithTimeout(diagnosticsTimeout)
+	processes, err := listProcesses("/proc")
+	if err != nil {
+		log.Printf("Unable to list processes: %v", err)
+		return
+	}
+	log.Printf("Processes running in the container (state D means waiting for I/O, such as on a hung filesystem):")
+	for _, p := range processes {
+		log.Printf("  PID %v, parent %v, state %v: %v", p.pid, p.ppid, p.state, p.commandLine)
+	}
+}
+
+// logFDCSummaryWithTimeout logs the output of ffstsummary, without waiting for longer than the timeout.  The
+// errors directory may be on a hung filesystem, in which case ffstsummary may not end even when killed.
+func logFDCSummaryWithTimeout(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	type result struct {
+		out []byte
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		// #nosec G204 - the command is a constant
+		cmd := exec.CommandContext(ctx, "/opt/mqm/bin/ffstsummary")
+		cmd.Dir = "/var/mqm/errors"
+		out, err := cmd.CombinedOutput()
+		done <- result{out, err}
+	}()
+	select {
+	case r := <-done:
+		switch {
+		case r.err != nil:
+			log.Printf("Unable to get FDC summary: %v", r.err)
+		case strings.TrimSpace(string(r.out)) == "":
+			log.Println("No FDC files found")
+		default:
+			log.Printf("FDC summary:\n%v", strings.TrimSpace(string(r.out)))
+		}
+	case <-ctx.Done():
+		log.Printf("Unable to get FDC summary: ffstsummary did not complete within %v", timeout)
+	}
+}
@@ -0,0 +1,258 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/command"
+	"github.com/ibm-messaging/mq-container/internal/mqscredact"
+	"github.com/ibm-messaging/mq-container/pkg/containerruntimelogger"
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+	"github.com/ibm-messaging/mq-container/pkg/mqini"
+)
+
+// diagCommandTimeout is the maximum time allowed for each command run while collecting diagnostics
+const diagCommandTimeout = 30 * time.Second
+
+// redactedValue replaces the value of sensitive environment variables and INI attributes
+const redactedValue = "********"
+
+// sensitiveName matches the names of environment variables and INI attributes which may hold secrets
+var sensitiveName = regexp.MustCompile(`(?i)(password|passwd|passphrase|pwd|secret|token|credential|stash|private|cryptohardware)`)
+
+// zeroReader is an endless source of zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// diagBundle writes files to a gzipped tar archive, recording any which can't be collected
+type diagBundle struct {
+	tw       *tar.Writer
+	problems []string
+}
+
+// addBytes adds a file with the specified contents to the bundle
+func (b *diagBundle) addBytes(name string, data []byte) {
+	err := b.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0640,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	})
+	if err == nil {
+		_, err = b.tw.Write(data)
+	}
+	if err != nil {
+		b.addProblem(name, err)
+	}
+}
+
+// addFile adds a copy of a regular file to the bundle
+func (b *diagBundle) addFile(name string, filePath string) {
+	// #nosec G304 - the files are in fixed MQ directories
+	f, err := os.Open(filePath)
+	if err != nil {
+		b.addProblem(name, err)
+		return
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		b.addProblem(name, err)
+		return
+	}
+	err = b.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0640,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime(),
+	})
+	if err == nil {
+		// Limit the copy, in case the file is still being written to, and pad it if the file was truncated
+		var n int64
+		n, err = io.Copy(b.tw, io.LimitReader(f, fi.Size()))
+		if err == nil && n < fi.Size() {
+			_, err = io.CopyN(b.tw, zeroReader{}, fi.Size()-n)
+		}
+	}
+	if err != nil {
+		b.addProblem(name, err)
+	}
+}
+
+// addDir adds all the regular files in a directory tree to the bundle, under the specified name
+func (b *diagBundle) addDir(name string, dir string) {
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			b.addProblem(p, err)
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		b.addFile(path.Join(name, filepath.ToSlash(rel)), p)
+		return nil
+	})
+	if err != nil {
+		b.addProblem(name, err)
+	}
+}
+
+// addCommand adds the combined output of a command to the bundle
+func (b *diagBundle) addCommand(name string, cmd string, args ...string) {
+	ctx, cancel := context.WithTimeout(context.Background(), diagCommandTimeout)
+	defer cancel()
+	out, rc, err := command.RunContext(ctx, cmd, args...)
+	if err != nil {
+		out = fmt.Sprintf("%v\nThe '%v' command returned with code: %v. Reason: %v\n", out, cmd, rc, err)
+	}
+	b.addBytes(name, []byte(out))
+}
+
+// addINI adds a copy of an INI file to the bundle, with any sensitive values redacted
+func (b *diagBundle) addINI(name string, filePath string) {
+	f, err := mqini.ReadFile(filePath)
+	if err != nil {
+		b.addProblem(name, err)
+		return
+	}
+	redactINI(f)
+	b.addBytes(name, f.Bytes())
+}
+
+// addMQSC adds a copy of each MQSC file matching a pattern to the bundle, with any sensitive values redacted
+func (b *diagBundle) addMQSC(name string, pattern string) {
+	files, _ := filepath.Glob(pattern)
+	for _, file := range files {
+		// #nosec G304 - the files are in fixed MQ directories
+		data, err := os.ReadFile(file)
+		if err != nil {
+			b.addProblem(file, err)
+			continue
+		}
+		redacted, err := mqscredact.Redact(string(data))
+		if err != nil {
+			b.addProblem(file, err)
+			continue
+		}
+		b.addBytes(path.Join(name, filepath.Base(file)), []byte(redacted))
+	}
+}
+
+func (b *diagBundle) addProblem(name string, err error) {
+	b.problems = append(b.problems, fmt.Sprintf("%v: %v", name, err))
+}
+
+// redactINI replaces the value of any attribute in the INI file which may hold a secret
+func redactINI(f *mqini.File) {
+	for _, s := range f.AllStanzas() {
+		s.SetMatching(sensitiveName.MatchString, redactedValue)
+	}
+}
+
+// sanitiseEnvironment returns a sorted listing of the supplied environment, in the form returned by os.Environ,
+// with the value of any variable which may hold a secret redacted
+func sanitiseEnvironment(environ []string) string {
+	lines := make([]string, 0, len(environ))
+	for _, e := range environ {
+		name, _, _ := strings.Cut(e, "=")
+		if sensitiveName.MatchString(name) {
+			e = name + "=" + redactedValue
+		}
+		lines = append(lines, e)
+	}
+	slices.Sort(lines)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// collectDiagnostics writes a gzipped tar archive of diagnostic information about the queue manager
+// and the container to the specified file
+func collectDiagnostics(file string, name string) error {
+	// #nosec G304 - the file name is supplied by the user running the command
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	b := &diagBundle{tw: tar.NewWriter(gz)}
+
+	b.addDir("errors/system", "/var/mqm/errors")
+	b.addDir("trace", "/var/mqm/trace")
+	b.addCommand("commands/dspmq.txt", "dspmq", "-o", "all", "-x")
+	b.addCommand("commands/dspmqver.txt", "dspmqver", "-a")
+	b.addINI("config/mqs.ini", "/var/mqm/mqs.ini")
+	if name != "" {
+		qm, err := mqini.GetQueueManager(name)
+		if err != nil {
+			b.addProblem(name, err)
+		} else {
+			b.addDir("errors/qmgr", mqini.GetErrorLogDirectory(qm))
+			b.addINI("config/qm.ini", filepath.Join(mqini.GetDataDirectory(qm), "qm.ini"))
+		}
+	}
+	b.addMQSC("mqsc/etc", "/etc/mqm/*.mqsc")
+	b.addMQSC("mqsc/run", "/run/*.mqsc")
+	if _, err := os.Stat("/run/termination-log"); err == nil {
+		b.addFile("termination-log", "/run/termination-log")
+	}
+
+	var runtime bytes.Buffer
+	runtimeLog, err := logger.NewLogger(&runtime, false, false, name)
+	if err == nil {
+		err = containerruntimelogger.LogContainerDetails(runtimeLog)
+	}
+	if err != nil {
+		fmt.Fprintf(&runtime, "Error getting container details: %v\n", err)
+	}
+	b.addBytes("runtime.txt", runtime.Bytes())
+	b.addBytes("environment.txt", []byte(sanitiseEnvironment(os.Environ())))
+
+	if len(b.problems) > 0 {
+		b.addBytes("collection-errors.txt", []byte(strings.Join(b.problems, "\n")+"\n"))
+	}
+	err = b.tw.Close()
+	if err != nil {
+		return err
+	}
+	err = gz.Close()
+	if err != nil {
+		return err
+	}
+	log.Printf("Diagnostic information written to %v", file)
+	return f.Close()
+}
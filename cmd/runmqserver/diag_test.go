@@ -0,0 +1,106 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ibm-messaging/mq-container/pkg/mqini"
+)
+
+func TestSanitiseEnvironment(t *testing.T) {
+	environ := []string{"MQ_QMGR_NAME=QM1", "MQ_ADMIN_PASSWORD=passw0rd", "MQ_APP_PASSWORD_SECURE=abc", "API_TOKEN=xyz", "EMPTY="}
+	expected := "API_TOKEN=********\nEMPTY=\nMQ_ADMIN_PASSWORD=********\nMQ_APP_PASSWORD_SECURE=********\nMQ_QMGR_NAME=QM1\n"
+	actual := sanitiseEnvironment(environ)
+	if actual != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, actual)
+	}
+}
+
+func TestRedactINI(t *testing.T) {
+	f := mqini.Parse([]byte("SSL:\n   SSLCryptoHardware=GSK_PKCS11=/usr/lib/pkcs11.so;token;password;SYMMETRIC_CIPHER_ON\n   KeyStashFile=/run/key.sth\n   OCSPCheckExtensions=YES\nLDAP:\n   BindPassword=secret\n"))
+	redactINI(f)
+	expected := "SSL:\n   SSLCryptoHardware=********\n   KeyStashFile=********\n   OCSPCheckExtensions=YES\nLDAP:\n   BindPassword=********\n"
+	actual := string(f.Bytes())
+	if actual != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, actual)
+	}
+}
+
+func TestRedactINIDuplicateKeys(t *testing.T) {
+	f := mqini.Parse([]byte("LDAP:\n   BindPassword=first\n   BindPassword=second\nSSL:\n   KeyPassphrase=phrase\n"))
+	redactINI(f)
+	expected := "LDAP:\n   BindPassword=********\n   BindPassword=********\nSSL:\n   KeyPassphrase=********\n"
+	actual := string(f.Bytes())
+	if actual != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, actual)
+	}
+}
+
+func TestDiagBundle(t *testing.T) {
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "sub"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "sub", "AMQ1234.0.FDC"), []byte("fdc"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "20-config.mqsc"), []byte("DEFINE AUTHINFO(A) AUTHTYPE(IDPWLDAP) LDAPPWD('secret')\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	b := &diagBundle{tw: tar.NewWriter(&buf)}
+	b.addDir("errors", dir)
+	b.addMQSC("mqsc", filepath.Join(dir, "*.mqsc"))
+	b.addFile("missing", filepath.Join(dir, "missing"))
+	err = b.tw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.problems) != 1 {
+		t.Errorf("Expected one problem for the missing file, got %v", b.problems)
+	}
+	contents := map[string]string{}
+	tr := tar.NewReader(&buf)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents[hdr.Name] = string(data)
+	}
+	if contents["errors/sub/AMQ1234.0.FDC"] != "fdc" {
+		t.Errorf("Expected FDC in bundle, got %v", contents)
+	}
+	if mqsc, ok := contents["mqsc/20-config.mqsc"]; !ok || bytes.Contains([]byte(mqsc), []byte("secret")) {
+		t.Errorf("Expected redacted MQSC in bundle, got %q", mqsc)
+	}
+}
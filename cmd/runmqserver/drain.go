@@ -0,0 +1,59 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/ibm-messaging/mq-container/internal/drain"
+	"github.com/ibm-messaging/mq-container/internal/ready"
+)
+
+// isDrainOnStopEnabled returns true if client connections should be drained before the queue manager is stopped
+func isDrainOnStopEnabled() bool {
+	drainOnStop := os.Getenv("MQ_DRAIN_ON_STOP")
+	return drainOnStop == "true" || drainOnStop == "1"
+}
+
+// drainQueueManager stops the listeners and server-connection channels of an active queue manager, and waits
+// for client connections to end, if enabled using MQ_DRAIN_ON_STOP.  The queue manager is stopped afterwards
+// even if the connections do not end in time.
+func drainQueueManager(name string) {
+	if !isDrainOnStopEnabled() {
+		return
+	}
+	timeout, err := drain.GetTimeout()
+	if err != nil {
+		log.Printf("Error processing MQ_DRAIN_TIMEOUT, the default value of %v will be used. Err: %v", drain.DefaultTimeout, err)
+		timeout = drain.DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+	defer cancel()
+	status, err := ready.GetQueueManagerStatus(ctx, name)
+	if err != nil {
+		log.Printf("Error getting status for queue manager %v, client connections will not be drained: %v", name, err)
+		return
+	}
+	if !status.Status().ActiveQM() {
+		log.Debug("Queue manager is not active, so there are no client connections to drain")
+		return
+	}
+	err = drain.Drain(context.Background(), name, timeout, log)
+	if err != nil {
+		log.Printf("Error draining client connections: %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/envvars"
+	"github.com/ibm-messaging/mq-container/internal/hooks"
+	"github.com/ibm-messaging/mq-container/internal/ready"
+	"github.com/ibm-messaging/mq-container/internal/role"
+)
+
+// hooksDir is the directory containing a sub-directory of hooks for each phase
+const hooksDir = "/etc/mqm/hooks"
+
+// defaultHookTimeout is the time allowed for each hook, if MQ_HOOKS_TIMEOUT isn't set
+const defaultHookTimeout = 60 * time.Second
+
+// hookPolicy determines what happens when a hook fails
+type hookPolicy string
+
+const (
+	hookPolicyFail hookPolicy = "fail"
+	hookPolicyWarn hookPolicy = "warn"
+)
+
+// getHookPolicyVariable returns the name of the environment variable holding the failure policy for a phase,
+// such as MQ_HOOKS_PRE_CREATE_POLICY
+func getHookPolicyVariable(phase hooks.Phase) string {
+	return "MQ_HOOKS_" + strings.ToUpper(strings.ReplaceAll(string(phase), "-", "_")) + "_POLICY"
+}
+
+// getHookPolicy returns the failure policy for a phase.  A failing hook stops the container by default
+// before the queue manager has started, but not afterwards.  A failing pre-stop hook never prevents the
+// queue manager from being stopped.
+func getHookPolicy(phase hooks.Phase) (hookPolicy, error) {
+	if phase == hooks.PreStop {
+		return hookPolicyWarn, nil
+	}
+	name := getHookPolicyVariable(phase)
+	policy := hookPolicy(strings.ToLower(strings.TrimSpace(os.Getenv(name))))
+	switch policy {
+	case "":
+		if phase == hooks.PostStart {
+			return hookPolicyWarn, nil
+		}
+		return hookPolicyFail, nil
+	case hookPolicyFail, hookPolicyWarn:
+		return policy, nil
+	}
+	return "", fmt.Errorf("Invalid value for %v: '%v'. Allowed values are 'fail' and 'warn'", name, policy)
+}
+
+// getHookTimeout returns the time allowed for each hook, from MQ_HOOKS_TIMEOUT
+func getHookTimeout() (time.Duration, error) {
+	value := strings.TrimSpace(os.Getenv("MQ_HOOKS_TIMEOUT"))
+	if value == "" {
+		return defaultHookTimeout, nil
+	}
+	seconds, err := envvars.ParsePositiveInteger(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for MQ_HOOKS_TIMEOUT: '%v'", value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// getHookConfigProblems returns a description of each problem with the hooks configuration
+func getHookConfigProblems() []string {
+	problems := []string{}
+	for _, phase := range hooks.Phases {
+		if _, err := getHookPolicy(phase); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if _, err := getHookTimeout(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	return problems
+}
+
+// getQueueManagerRole returns the role of the queue manager in this container, such as "active" or "replica"
+func getQueueManagerRole(name string) role.Role {
+	ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+	defer cancel()
+	status, err := ready.Status(ctx, name)
+	if err != nil {
+		return role.Unknown
+	}
+	return role.FromStatus(status)
+}
+
+// runHooks runs the hooks for a phase from hooksDir, with the queue manager's name and role, and any
+// additional variables, in their environment.  An error is returned if a hook fails, and the phase's
+// policy is to fail.
+func runHooks(name string, phase hooks.Phase, env ...string) error {
+	paths, err := hooks.Find(hooksDir, phase)
+	if err == nil && len(paths) == 0 {
+		return nil
+	}
+	policy, err := getHookPolicy(phase)
+	if err != nil {
+		return err
+	}
+	timeout, err := getHookTimeout()
+	if err != nil {
+		return err
+	}
+	config := hooks.Config{
+		Dir:           hooksDir,
+		Timeout:       timeout,
+		Env:           append([]string{"MQ_QMGR_NAME=" + name, "MQ_QMGR_ROLE=" + string(getQueueManagerRole(name))}, env...),
+		StopOnFailure: policy == hookPolicyFail,
+	}
+	err = hooks.Run(context.Background(), phase, config, log)
+	if err != nil && policy == hookPolicyFail {
+		return err
+	}
+	if err != nil {
+		log.Printf("Warning: one or more %v hooks failed", phase)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/hooks"
+)
+
+func TestGetHookPolicy(t *testing.T) {
+	tests := []struct {
+		phase    hooks.Phase
+		value    string
+		expected hookPolicy
+		err      bool
+	}{
+		{hooks.PreCreate, "", hookPolicyFail, false},
+		{hooks.PostCreate, "WARN", hookPolicyWarn, false},
+		{hooks.PostStart, "", hookPolicyWarn, false},
+		{hooks.PostStart, "fail", hookPolicyFail, false},
+		{hooks.PreStop, "fail", hookPolicyWarn, false},
+		{hooks.PreCreate, "ignore", "", true},
+	}
+	for _, test := range tests {
+		t.Run(string(test.phase)+"-"+test.value, func(t *testing.T) {
+			t.Setenv(getHookPolicyVariable(test.phase), test.value)
+			policy, err := getHookPolicy(test.phase)
+			if (err != nil) != test.err {
+				t.Fatalf("Expected error=%v; got %v", test.err, err)
+			}
+			if policy != test.expected {
+				t.Errorf("Expected policy %v; got %v", test.expected, policy)
+			}
+		})
+	}
+}
+
+func TestGetHookTimeout(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		err      bool
+	}{
+		{"", defaultHookTimeout, false},
+		{"120", 2 * time.Minute, false},
+		{"0", 0, true},
+		{"1m", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			t.Setenv("MQ_HOOKS_TIMEOUT", test.value)
+			timeout, err := getHookTimeout()
+			if (err != nil) != test.err {
+				t.Fatalf("Expected error=%v; got %v", test.err, err)
+			}
+			if timeout != test.expected {
+				t.Errorf("Expected %v; got %v", test.expected, timeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Severities of log messages, in increasing order
+const (
+	severityDebug = iota
+	severityInfo
+	severityWarning
+	severityError
+)
+
+// filteredLogSources are the log sources which can be filtered by severity and message ID
+var filteredLogSources = []string{"qmgr", "web", "mqsc"}
+
+// logLevelSeverities maps the 'loglevel' values used by MQ and Liberty to a severity
+var logLevelSeverities = map[string]int{
+	"FINEST":  severityDebug,
+	"FINER":   severityDebug,
+	"FINE":    severityDebug,
+	"ENTRY":   severityDebug,
+	"EXIT":    severityDebug,
+	"EVENT":   severityDebug,
+	"DEBUG":   severityDebug,
+	"INFO":    severityInfo,
+	"AUDIT":   severityInfo,
+	"WARNING": severityWarning,
+	"ERROR":   severityError,
+	"SEVERE":  severityError,
+	"FATAL":   severityError,
+}
+
+// minSeverities maps the values of the MQ_LOGGING_CONSOLE_*_MIN_SEVERITY variables to a severity
+var minSeverities = map[string]int{
+	"info":    severityInfo,
+	"warning": severityWarning,
+	"error":   severityError,
+}
+
+// messageIDPattern matches the ID of an MQ message in a line of an MQSC log
+var messageIDPattern = regexp.MustCompile(`AMQ[0-9]+[A-Z]`)
+
+// logFilter decides which log messages from one source are mirrored to the console
+type logFilter struct {
+	// minSeverity is the lowest severity mirrored, or -1 if messages are not filtered by severity
+	minSeverity int
+	// includeIDs are the IDs of messages which are mirrored regardless of their severity, or the only messages
+	// mirrored if there is no minimum severity
+	includeIDs []string
+}
+
+// logFilters holds the filter for each log source
+type logFilters map[string]logFilter
+
+// getLogFilters returns the log filter for each source, as configured by the
+// MQ_LOGGING_CONSOLE_<SOURCE>_MIN_SEVERITY and MQ_LOGGING_CONSOLE_<SOURCE>_INCLUDE_ID variables.
+// An error is returned if a minimum severity is not one of the allowed values.
+func getLogFilters() (logFilters, error) {
+	filters := logFilters{}
+	for _, source := range filteredLogSources {
+		prefix := "MQ_LOGGING_CONSOLE_" + strings.ToUpper(source)
+		f := logFilter{minSeverity: -1}
+		if value := strings.TrimSpace(os.Getenv(prefix + "_MIN_SEVERITY")); value != "" {
+			s, ok := minSeverities[strings.ToLower(value)]
+			if !ok {
+				return nil, fmt.Errorf("invalid value for %v_MIN_SEVERITY: '%v'. Allowed values are 'info', 'warning' and 'error'", prefix, value)
+			}
+			f.minSeverity = s
+		}
+		for _, id := range strings.Split(strings.ToUpper(os.Getenv(prefix+"_INCLUDE_ID")), ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				f.includeIDs = append(f.includeIDs, id)
+			}
+		}
+		filters[source] = f
+	}
+	return filters, nil
+}
+
+// getSeverity returns the severity of a message, using its log level, or the last letter of its message
+// ID if it has no recognised log level.  If neither is known, false is returned.
+func getSeverity(logLevel string, messageID string) (int, bool) {
+	if s, ok := logLevelSeverities[strings.ToUpper(logLevel)]; ok {
+		return s, true
+	}
+	if len(messageID) > 0 {
+		switch messageID[len(messageID)-1] {
+		case 'I':
+			return severityInfo, true
+		case 'W':
+			return severityWarning, true
+		case 'E', 'S':
+			return severityError, true
+		}
+	}
+	return 0, false
+}
+
+// allows returns true if a message with the specified log level and message ID should be mirrored.  If
+// included IDs are set without a minimum severity, only the messages with those IDs are mirrored.
+func (f logFilter) allows(logLevel string, messageID string) bool {
+	if f.minSeverity < 0 && len(f.includeIDs) == 0 {
+		return true
+	}
+	for _, id := range f.includeIDs {
+		if strings.EqualFold(id, messageID) {
+			return true
+		}
+	}
+	if f.minSeverity < 0 {
+		return false
+	}
+	s, ok := getSeverity(logLevel, messageID)
+	// Messages of unknown severity are always mirrored
+	return !ok || s >= f.minSeverity
+}
+
+// getJSONLogSource returns the source of a log message parsed from JSON
+func getJSONLogSource(obj map[string]interface{}) string {
+	logType, _ := obj["type"].(string)
+	switch {
+	case strings.HasPrefix(logType, "liberty_"):
+		return "web"
+	case logType == "mq_event":
+		return "event"
+	}
+	return "qmgr"
+}
+
+// allowsJSON returns true if a log message parsed from JSON should be mirrored
+func (filters logFilters) allowsJSON(obj map[string]interface{}) bool {
+	f, ok := filters[getJSONLogSource(obj)]
+	if !ok {
+		return true
+	}
+	logLevel, _ := obj["loglevel"].(string)
+	messageID, _ := obj["ibm_messageId"].(string)
+	return f.allows(logLevel, messageID)
+}
+
+// allowsMQSC returns true if a line from the MQSC log should be mirrored
+func (filters logFilters) allowsMQSC(msg string) bool {
+	f, ok := filters["mqsc"]
+	if !ok {
+		return true
+	}
+	return f.allows(determineMQSCLogLevel(msg), messageIDPattern.FindString(msg))
+}
@@ -0,0 +1,159 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLogFiltersAllowsJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		msg      string
+		expected bool
+	}{
+		{
+			name:     "NoFilter",
+			msg:      `{"type":"mq_log","loglevel":"INFO","ibm_messageId":"AMQ5026I"}`,
+			expected: true,
+		},
+		{
+			name:     "QMgrInfoFiltered",
+			env:      map[string]string{"MQ_LOGGING_CONSOLE_QMGR_MIN_SEVERITY": "warning"},
+			msg:      `{"type":"mq_log","loglevel":"INFO","ibm_messageId":"AMQ5026I"}`,
+			expected: false,
+		},
+		{
+			name:     "QMgrErrorAllowed",
+			env:      map[string]string{"MQ_LOGGING_CONSOLE_QMGR_MIN_SEVERITY": "WARNING"},
+			msg:      `{"type":"mq_log","loglevel":"ERROR","ibm_messageId":"AMQ9999E"}`,
+			expected: true,
+		},
+		{
+			name:     "QMgrIncludedID",
+			env:      map[string]string{"MQ_LOGGING_CONSOLE_QMGR_MIN_SEVERITY": "error", "MQ_LOGGING_CONSOLE_QMGR_INCLUDE_ID": "AMQ5051I, amq5026i"},
+			msg:      `{"type":"mq_log","loglevel":"INFO","ibm_messageId":"AMQ5026I"}`,
+			expected: true,
+		},
+		{
+			name:     "QMgrIncludedIDOnly",
+			env:      map[string]string{"MQ_LOGGING_CONSOLE_QMGR_INCLUDE_ID": "AMQ5051I"},
+			msg:      `{"type":"mq_log","loglevel":"INFO","ibm_messageId":"AMQ5051I"}`,
+			expected: true,
+		},
+		{
+			name:     "QMgrNotIncludedID",
+			env:      map[string]string{"MQ_LOGGING_CONSOLE_QMGR_INCLUDE_ID": "AMQ5051I"},
+			msg:      `{"type":"mq_log","loglevel":"ERROR","ibm_messageId":"AMQ9999E"}`,
+			expected: false,
+		},
+		{
+			name:     "QMgrSeverityFromMessageID",
+			env:      map[string]string{"MQ_LOGGING_CONSOLE_QMGR_MIN_SEVERITY": "warning"},
+			msg:      `{"type":"mq_log","ibm_messageId":"AMQ7234I"}`,
+			expected: false,
+		},
+		{
+			name:     "QMgrUnknownSeverity",
+			env:      map[string]string{"MQ_LOGGING_CONSOLE_QMGR_MIN_SEVERITY": "error"},
+			msg:      `{"type":"mq_log","message":"no level"}`,
+			expected: true,
+		},
+		{
+			name:     "WebFilterIgnoresQMgr",
+			env:      map[string]string{"MQ_LOGGING_CONSOLE_WEB_MIN_SEVERITY": "error"},
+			msg:      `{"type":"mq_log","loglevel":"INFO","ibm_messageId":"AMQ5026I"}`,
+			expected: true,
+		},
+		{
+			name:     "WebAuditFiltered",
+			env:      map[string]string{"MQ_LOGGING_CONSOLE_WEB_MIN_SEVERITY": "error"},
+			msg:      `{"type":"liberty_message","loglevel":"AUDIT","ibm_messageId":"CWWKF0011I"}`,
+			expected: false,
+		},
+		{
+			name:     "WebSevereAllowed",
+			env:      map[string]string{"MQ_LOGGING_CONSOLE_WEB_MIN_SEVERITY": "error"},
+			msg:      `{"type":"liberty_message","loglevel":"SEVERE","ibm_messageId":"CWWKE0701E"}`,
+			expected: true,
+		},
+		{
+			name:     "WebTraceFiltered",
+			env:      map[string]string{"MQ_LOGGING_CONSOLE_WEB_MIN_SEVERITY": "info"},
+			msg:      `{"type":"liberty_trace","loglevel":"FINEST"}`,
+			expected: false,
+		},
+		{
+			name:     "EventsNotFiltered",
+			env:      map[string]string{"MQ_LOGGING_CONSOLE_QMGR_MIN_SEVERITY": "error"},
+			msg:      `{"type":"mq_event","ibm_eventReason":"MQRC_CHANNEL_STOPPED"}`,
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			obj, err := processLogMessage(tt.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			filters, err := getLogFilters()
+			if err != nil {
+				t.Fatal(err)
+			}
+			allowed := filters.allowsJSON(obj)
+			if allowed != tt.expected {
+				t.Errorf("Expected allowsJSON() to return %v for %v; got %v", tt.expected, tt.msg, allowed)
+			}
+		})
+	}
+}
+
+func TestLogFiltersAllowsMQSC(t *testing.T) {
+	t.Setenv("MQ_LOGGING_CONSOLE_MQSC_MIN_SEVERITY", "error")
+	t.Setenv("MQ_LOGGING_CONSOLE_MQSC_INCLUDE_ID", "AMQ8006I")
+	tests := []struct {
+		msg      string
+		expected bool
+	}{
+		{"     1 : DEFINE QLOCAL(APP.QUEUE)", false},
+		{"AMQ8006I: IBM MQ queue created.", true},
+		{"AMQ8010I: IBM MQ channel created.", false},
+		{"AMQ8405E: Syntax error detected at or near end of command segment below:-", true},
+	}
+	filters, err := getLogFilters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		allowed := filters.allowsMQSC(tt.msg)
+		if allowed != tt.expected {
+			t.Errorf("Expected allowsMQSC() to return %v for %v; got %v", tt.expected, tt.msg, allowed)
+		}
+	}
+}
+
+func TestGetLogFiltersInvalidMinSeverity(t *testing.T) {
+	t.Setenv("MQ_LOGGING_CONSOLE_QMGR_MIN_SEVERITY", "warn")
+	_, err := getLogFilters()
+	if err == nil || !strings.Contains(err.Error(), "MQ_LOGGING_CONSOLE_QMGR_MIN_SEVERITY") || !strings.Contains(err.Error(), "'warning'") {
+		t.Errorf("Expected an error naming the variable and the allowed values; got %v", err)
+	}
+}
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2017, 2025
+© Copyright IBM Corporation 2017, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -31,6 +31,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/ibm-messaging/mq-container/internal/mqevent"
 	"github.com/ibm-messaging/mq-container/internal/pathutils"
 	"github.com/ibm-messaging/mq-container/pkg/logger"
 	"github.com/ibm-messaging/mq-container/pkg/mqini"
@@ -243,7 +244,7 @@ func mirrorSystemErrorLogs(ctx context.Context, wg *sync.WaitGroup, mf mirrorFun
 
 // mirrorMQSCLogs starts a goroutine to mirror the contents of the auto-config mqsc logs
 func mirrorMQSCLogs(ctx context.Context, wg *sync.WaitGroup, name string, mf mirrorFunc) (chan error, error) {
-	qm, err := mqini.GetQueueManager(name)
+	qm, err := mqini.GetQueueManagerContext(ctx, name)
 	if err != nil {
 		log.Debug(err)
 		return nil, err
@@ -256,7 +257,7 @@ func mirrorMQSCLogs(ctx context.Context, wg *sync.WaitGroup, name string, mf mir
 // mirrorQueueManagerErrorLogs starts a goroutine to mirror the contents of the MQ queue manager error logs
 func mirrorQueueManagerErrorLogs(ctx context.Context, wg *sync.WaitGroup, name string, fromStart bool, mf mirrorFunc) (chan error, error) {
 	// Always use the JSON log as the source
-	qm, err := mqini.GetQueueManager(name)
+	qm, err := mqini.GetQueueManagerContext(ctx, name)
 	if err != nil {
 		log.Debug(err)
 		return nil, err
@@ -275,6 +276,13 @@ func mirrorWebServerLogs(ctx context.Context, wg *sync.WaitGroup, name string, f
 	return mirrorLog(ctx, wg, "/var/mqm/web/installations/Installation1/servers/mqweb/logs/messages.log", fromStart, mf, true)
 }
 
+// mirrorEventQueues starts a goroutine to mirror the event messages on the queue manager's event queues
+func mirrorEventQueues(ctx context.Context, wg *sync.WaitGroup, name string, mf mirrorFunc) {
+	mqevent.Consume(ctx, wg, name, mqevent.GetEventQueues(), func(msg string) {
+		mf(msg, false)
+	}, log)
+}
+
 func getDebug() bool {
 	debug := os.Getenv("DEBUG")
 	if debug == "true" || debug == "1" {
@@ -287,6 +295,10 @@ func configureLogger(name string) (mirrorFunc, error) {
 	var err error
 	f := getLogFormat()
 	d := getDebug()
+	filters, err := getLogFilters()
+	if err != nil {
+		return nil, err
+	}
 	switch f {
 	case "json":
 		log, err = logger.NewLogger(os.Stdout, d, true, name)
@@ -306,18 +318,27 @@ func configureLogger(name string) (mirrorFunc, error) {
 				if err == nil && isQMLog && filterQMLogMessage(obj) {
 					return false
 				}
+				if err == nil && !filters.allowsJSON(obj) {
+					return false
+				}
 				if err != nil {
 					log.Printf("Failed to unmarshall JSON in log message - %v", msg)
 				} else {
 					mirrorLogWriter.Println(msg)
+					forwardLogEntry(obj)
 				}
 			} else {
 				// The log being mirrored isn't JSON. This can happen only in case of 'mqsc' logs
 				// Also if the logging source is from autocfgmqsc.LOG, then we have to construct the json string as per below logic
 				if checkLogSourceForMirroring("mqsc") && canMQSCLogBeMirroredToConsole(msg) {
+					if !filters.allowsMQSC(msg) {
+						return false
+					}
 					logLevel := determineMQSCLogLevel(strings.TrimSpace(msg))
+					timestamp := getTimeStamp()
 					mirrorLogWriter.Printf("{\"ibm_datetime\":\"%s\",\"type\":\"mqsc_log\",\"loglevel\":\"%s\",\"message\":\"%s\"}\n",
-						getTimeStamp(), logLevel, strings.TrimSpace(msg))
+						timestamp, logLevel, strings.TrimSpace(msg))
+					forwardLogEntry(mqscLogEntry(timestamp, logLevel, msg))
 				}
 			}
 			return true
@@ -341,15 +362,26 @@ func configureLogger(name string) (mirrorFunc, error) {
 				if err == nil && isQMLog && filterQMLogMessage(obj) {
 					return false
 				}
+				if err == nil && !filters.allowsJSON(obj) {
+					return false
+				}
 				if err != nil {
 					log.Printf("Failed to unmarshall JSON in log message - %v", err)
 				} else {
+					// Forward the entry before formatting, as formatting alters it
+					forwardLogEntry(obj)
 					mirrorLogWriter.Print(formatBasic(obj))
 				}
 			} else {
 				// The log being mirrored isn't JSON, so just print it. This can happen only in case of mqsc logs
 				if checkLogSourceForMirroring("mqsc") && canMQSCLogBeMirroredToConsole(msg) {
-					log.Print(strings.TrimSpace(msg))
+					if !filters.allowsMQSC(msg) {
+						return false
+					}
+					// Write in the same format as the logger, without the logger forwarding it as a runmqserver entry
+					timestamp := getTimeStamp()
+					mirrorLogWriter.Printf("%s %s\n", timestamp, strings.TrimSpace(msg))
+					forwardLogEntry(mqscLogEntry(timestamp, determineMQSCLogLevel(strings.TrimSpace(msg)), msg))
 				}
 			}
 			return true
@@ -536,7 +568,7 @@ func isLogConsoleSourceValid() bool {
 	for _, src := range logConsoleSource {
 		switch strings.TrimSpace(src) {
 		//If it is a permitted value, it is valid. Keep it as true, but dont return it. We may encounter something junk soon
-		case "qmgr", "web", "mqsc", "":
+		case "qmgr", "web", "mqsc", "event", "":
 			retValue = true
 		//If invalid entry arrives in-between/anywhere, just return false, there is no turning back
 		default:
@@ -585,6 +617,11 @@ func checkLogSourceForMirroring(source string) bool {
 			if source == "mqsc" {
 				return true
 			}
+		case "event":
+			//If value of input parameter is event and it exists in environment variable, mirror event messages
+			if source == "event" {
+				return true
+			}
 		}
 	}
 	return false
@@ -605,6 +642,16 @@ func getTimeStamp() string {
 	return t.Format(timestampFormat)
 }
 
+// mqscLogEntry returns a structured log entry for a line of the MQSC log, matching the JSON console format
+func mqscLogEntry(timestamp string, logLevel string, msg string) map[string]interface{} {
+	return map[string]interface{}{
+		"ibm_datetime": timestamp,
+		"type":         "mqsc_log",
+		"loglevel":     logLevel,
+		"message":      strings.TrimSpace(msg),
+	}
+}
+
 // determineMQSCLogLevel finds out log level based on if the message contains 'AMQxxxxE:' string or not.
 func determineMQSCLogLevel(message string) string {
 	//Match the below pattern
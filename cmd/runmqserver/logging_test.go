@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2020, 2024
+© Copyright IBM Corporation 2020, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -111,6 +111,33 @@ var mqLogSourcesTests = []struct {
 	{20, "qmgr,web,mqsc", true, true, true, true},
 }
 
+func TestLoggingConsoleSourceEvent(t *testing.T) {
+	tests := []struct {
+		logsrc       string
+		exptValid    bool
+		exptEventSrc bool
+		exptQmgrSrc  bool
+	}{
+		{"", true, false, true},
+		{"event", true, true, false},
+		{"EVENT", true, true, false},
+		{"qmgr,event", true, true, true},
+		{"qmgr,events", false, false, true},
+	}
+	for _, tt := range tests {
+		t.Setenv("MQ_LOGGING_CONSOLE_SOURCE", tt.logsrc)
+		if isValid := isLogConsoleSourceValid(); isValid != tt.exptValid {
+			t.Errorf("Expected return value from isLogConsoleSourceValid() is %v for MQ_LOGGING_CONSOLE_SOURCE='%v', got %v\n", tt.exptValid, tt.logsrc, isValid)
+		}
+		if isEventSrc := checkLogSourceForMirroring("event"); isEventSrc != tt.exptEventSrc {
+			t.Errorf("Expected return value from checkLogSourceForMirroring(\"event\") is %v for MQ_LOGGING_CONSOLE_SOURCE='%v', got %v\n", tt.exptEventSrc, tt.logsrc, isEventSrc)
+		}
+		if isQmgrSrc := checkLogSourceForMirroring("qmgr"); isQmgrSrc != tt.exptQmgrSrc {
+			t.Errorf("Expected return value from checkLogSourceForMirroring(\"qmgr\") is %v for MQ_LOGGING_CONSOLE_SOURCE='%v', got %v\n", tt.exptQmgrSrc, tt.logsrc, isQmgrSrc)
+		}
+	}
+}
+
 func TestLoggingConsoleSourceInputs(t *testing.T) {
 	for _, mqlogsrctest := range mqLogSourcesTests {
 		err := os.Setenv("MQ_LOGGING_CONSOLE_SOURCE", mqlogsrctest.logsrc)
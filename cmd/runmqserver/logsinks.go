@@ -0,0 +1,154 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	cryptotls "crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/syslog"
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+	"github.com/ibm-messaging/mq-container/pkg/syncwriter"
+)
+
+// keyDirSyslog holds the optional CA certificate, and client certificate and key, used to connect to a syslog server over TLS
+const keyDirSyslog = "/etc/mqm/syslog/pki/keys"
+
+// logSinkCloseTimeout is the maximum time spent sending queued log entries when runmqserver ends
+const logSinkCloseTimeout = 5 * time.Second
+
+// closableSink is a log sink which holds entries which must be sent before exiting
+type closableSink interface {
+	logger.Sink
+	Close(timeout time.Duration)
+}
+
+// logSinks receive a copy of each entry logged by runmqserver, and each entry mirrored to the console
+var logSinks []closableSink
+
+// configureLogSinks creates the log sinks which have been configured, and adds them to the logger
+func configureLogSinks(name string) error {
+	if address := os.Getenv("MQ_LOGGING_SYSLOG_ADDRESS"); address != "" {
+		w, err := newSyslogSink(name, address)
+		if err != nil {
+			return err
+		}
+		log.Printf("Forwarding logs to syslog server %v", address)
+		logSinks = append(logSinks, w)
+	}
+	if endpoint := os.Getenv("MQ_OTLP_ENDPOINT"); endpoint != "" && otlpSignalEnabled("logs") {
+		e, err := newOTLPLogExporter(name, endpoint)
+		if err != nil {
+			return err
+		}
+		log.Printf("Exporting logs to OpenTelemetry collector %v", endpoint)
+		logSinks = append(logSinks, e)
+	}
+	for _, sink := range logSinks {
+		log.AddSink(sink)
+	}
+	return nil
+}
+
+// forwardLogEntry sends a copy of a mirrored log entry to each log sink
+func forwardLogEntry(entry map[string]interface{}) {
+	for _, sink := range logSinks {
+		sink.Send(entry)
+	}
+}
+
+// closeLogSinks sends any queued log entries
+func closeLogSinks() {
+	for _, sink := range logSinks {
+		sink.Close(logSinkCloseTimeout)
+	}
+}
+
+// logSinkError reports a problem with a log sink directly to stderr, so that it can't be forwarded to the sink
+func logSinkError(msg string) {
+	syncwriter.For(os.Stderr).Println(msg)
+}
+
+// newSyslogSink creates a syslog writer for the server address, using the queue manager name as the APP-NAME
+func newSyslogSink(name string, address string) (*syslog.Writer, error) {
+	network, addr, err := syslog.ParseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	facility, err := syslog.Facility(os.Getenv("MQ_LOGGING_SYSLOG_FACILITY"))
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	config := syslog.Config{
+		Network:  network,
+		Address:  addr,
+		Facility: facility,
+		Hostname: hostname,
+		AppName:  name,
+		ErrorLog: logSinkError,
+	}
+	if network == "tls" {
+		config.TLSConfig, err = getClientTLSConfig(keyDirSyslog, addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return syslog.New(config)
+}
+
+// getClientTLSConfig returns the TLS configuration for connecting to a server, such as a syslog server.  The server
+// is trusted using ca.crt, if present, or the system CA certificates.  A client certificate is sent if tls.crt
+// and tls.key are present.
+func getClientTLSConfig(keyDir string, addr string) (*cryptotls.Config, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	config := &cryptotls.Config{
+		ServerName: host,
+		MinVersion: cryptotls.VersionTLS12,
+	}
+	// #nosec G304 - the file is in a fixed directory
+	caPEM, err := os.ReadFile(filepath.Join(keyDir, "ca.crt"))
+	if err == nil {
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in %v", filepath.Join(keyDir, "ca.crt"))
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	certFile := filepath.Join(keyDir, "tls.crt")
+	keyFile := filepath.Join(keyDir, "tls.key")
+	if _, err := os.Stat(keyFile); err == nil {
+		cert, err := cryptotls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate from %v: %w", keyDir, err)
+		}
+		config.Certificates = []cryptotls.Certificate{cert}
+	}
+	return config, nil
+}
@@ -0,0 +1,87 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetClientTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	config, err := getClientTLSConfig(dir, "syslog.example.com:6514")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ServerName != "syslog.example.com" {
+		t.Errorf("Expected server name syslog.example.com; got %v", config.ServerName)
+	}
+	if config.RootCAs != nil || len(config.Certificates) != 0 {
+		t.Errorf("Expected system CA certificates and no client certificate")
+	}
+}
+
+func TestGetClientTLSConfigInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+	}{
+		{"InvalidCA", "ca.crt"},
+		{"MissingClientCertificate", "tls.key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := os.WriteFile(filepath.Join(dir, tt.file), []byte("not PEM"), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = getClientTLSConfig(dir, "syslog.example.com:6514")
+			if err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+type testLogSink struct {
+	entries []map[string]interface{}
+}
+
+func (s *testLogSink) Send(entry map[string]interface{}) {
+	s.entries = append(s.entries, entry)
+}
+
+func (s *testLogSink) Close(timeout time.Duration) {}
+
+func TestForwardLogEntry(t *testing.T) {
+	sink := &testLogSink{}
+	saved := logSinks
+	logSinks = []closableSink{sink}
+	defer func() {
+		logSinks = saved
+	}()
+	forwardLogEntry(mqscLogEntry("2026-01-02T03:04:05.000Z", "ERROR", "  AMQ8405E: Syntax error  "))
+	if len(sink.entries) != 1 {
+		t.Fatalf("Expected 1 entry; got %v", len(sink.entries))
+	}
+	e := sink.entries[0]
+	if e["type"] != "mqsc_log" || e["loglevel"] != "ERROR" || e["message"] != "AMQ8405E: Syntax error" {
+		t.Errorf("Unexpected entry: %v", e)
+	}
+}
@@ -24,11 +24,14 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"sync"
 
+	"github.com/ibm-messaging/mq-container/internal/command"
 	"github.com/ibm-messaging/mq-container/internal/copy"
 	"github.com/ibm-messaging/mq-container/internal/fips"
 	"github.com/ibm-messaging/mq-container/internal/ha"
+	"github.com/ibm-messaging/mq-container/internal/hooks"
 	"github.com/ibm-messaging/mq-container/internal/metrics"
 	"github.com/ibm-messaging/mq-container/internal/ready"
 	"github.com/ibm-messaging/mq-container/internal/simpleauth"
@@ -42,6 +45,9 @@ func doMain() error {
 	var infoFlag = flag.Bool("info", false, "Display debug info, then exit")
 	var noLogRuntimeFlag = flag.Bool("nologruntime", false, "used when running this program from another program, to control log output")
 	var devFlag = flag.Bool("dev", false, "used when running this program from runmqdevserver to control how TLS is configured")
+	var validateFlag = flag.Bool("validate", false, "Validate the environment, mounts and configuration files, then exit")
+	var dryRunFlag = flag.Bool("dryrun", false, "Display the queue manager commands and generated configuration, then exit")
+	var diagFlag = flag.String("diag", "", "Write a gzipped tar archive of diagnostic information to the specified file, then exit")
 	flag.Parse()
 
 	if os.Getenv("MQ_ENABLE_SOFT_FILE_LIMIT_INCREASE") == "true" {
@@ -69,6 +75,34 @@ func doMain() error {
 		return nil
 	}
 
+	// Check whether they only want to collect diagnostics
+	if *diagFlag != "" {
+		if nameErr != nil {
+			name = ""
+		}
+		err = collectDiagnostics(*diagFlag, name)
+		if err != nil {
+			log.Error(err)
+		}
+		return err
+	}
+
+	// Check whether they only want to validate the configuration
+	if *validateFlag {
+		return runValidate(name, nameErr)
+	}
+	if *dryRunFlag {
+		if nameErr != nil {
+			log.Error(nameErr)
+			return nameErr
+		}
+		err = runDryRun(context.Background(), os.Stdout, name, *devFlag)
+		if err != nil {
+			log.Error(err)
+		}
+		return err
+	}
+
 	err = verifySingleProcess()
 	if err != nil {
 		// We don't do the normal termination here as it would create a termination file.
@@ -76,6 +110,23 @@ func doMain() error {
 		return err
 	}
 
+	err = configureLogSinks(name)
+	if err != nil {
+		logTermination(err)
+		return err
+	}
+	defer closeLogSinks()
+
+	// Report the time taken by each command, and collect diagnostics if a command doesn't complete in time
+	command.SetLogger(log)
+	command.SetTimeoutHandler(logTimeoutDiagnostics)
+	timeouts, err := command.GetTimeouts()
+	if err != nil {
+		logTermination(err)
+		return err
+	}
+	command.SetTimeouts(timeouts)
+
 	if nameErr != nil {
 		logTermination(err)
 		return err
@@ -96,8 +147,21 @@ func doMain() error {
 		return err
 	}
 	log.Printf("Using queue manager name: %v", name)
+	logEnvironmentProblems()
 
-	// Create a startup context to be used by the signalHandler to ensure the final reap of zombie processes only occurs after all startup processes are spawned
+	qmEndedPolicy, err := getEndedPolicy()
+	if err != nil {
+		logTermination(err)
+		return err
+	}
+	restartLimit, err := getRestartLimit()
+	if err != nil {
+		logTermination(err)
+		return err
+	}
+
+	// Create a startup context to be used by the signalHandler to ensure the final reap of zombie processes only occurs after all startup processes are spawned.
+	// The MQ commands run during startup also use this context, along with their own deadlines.
 	startupCtx, markStartupComplete := context.WithCancel(context.Background())
 	var startupMarkedComplete bool
 	// If the main thread returns before completing startup, cancel the startup context to unblock the signalHandler
@@ -119,6 +183,12 @@ func doMain() error {
 		}
 	}
 
+	err = runPreflightChecks(name)
+	if err != nil {
+		logTermination(err)
+		return err
+	}
+
 	err = createVolume("/mnt/mqm/data")
 	if err != nil {
 		logTermination(err)
@@ -257,21 +327,21 @@ func doMain() error {
 
 	enableTraceCrtmqdir := os.Getenv("MQ_ENABLE_TRACE_CRTMQDIR")
 	if enableTraceCrtmqdir == "true" || enableTraceCrtmqdir == "1" {
-		err = startMQTrace()
+		err = startMQTrace(startupCtx)
 		if err != nil {
 			logTermination(err)
 			return err
 		}
 	}
 
-	err = createDirStructure()
+	err = createDirStructure(startupCtx)
 	if err != nil {
 		logTermination(err)
 		return err
 	}
 
 	if enableTraceCrtmqdir == "true" || enableTraceCrtmqdir == "1" {
-		err = endMQTrace()
+		err = endMQTrace(startupCtx)
 		if err != nil {
 			logTermination(err)
 			return err
@@ -289,13 +359,13 @@ func doMain() error {
 	// Determine FIPS compliance level
 	fips.ProcessFIPSType(log)
 
-	keyLabel, defaultCmsKeystore, defaultP12Truststore, err := tls.ConfigureDefaultTLSKeystores(log)
+	keyLabel, defaultCmsKeystore, defaultP12Truststore, err := tls.ConfigureDefaultTLSKeystores(startupCtx, log)
 	if err != nil {
 		logTermination(err)
 		return err
 	}
 
-	err = tls.ConfigureTLS(keyLabel, defaultCmsKeystore, *devFlag, log)
+	err = tls.ConfigureTLS(startupCtx, keyLabel, defaultCmsKeystore, *devFlag, log)
 	if err != nil {
 		logTermination(err)
 		return err
@@ -303,7 +373,7 @@ func doMain() error {
 
 	//Validate MQ_LOG_CONSOLE_SOURCE variable
 	if !isLogConsoleSourceValid() {
-		log.Println("One or more invalid value is provided for MQ_LOGGING_CONSOLE_SOURCE. Allowed values are 'qmgr','web' and 'mqsc' in csv format")
+		log.Println("One or more invalid value is provided for MQ_LOGGING_CONSOLE_SOURCE. Allowed values are 'qmgr','web','mqsc' and 'event' in csv format")
 	}
 
 	var wg sync.WaitGroup
@@ -317,6 +387,15 @@ func doMain() error {
 		cancelMirror()
 	}()
 
+	// Serve the liveness, readiness and startup probes over HTTP, if enabled
+	if isProbeServerEnabled() {
+		err = startProbeServer(ctx, &wg, name)
+		if err != nil {
+			logTermination(err)
+			return err
+		}
+	}
+
 	//For mirroring web server logs if source variable is set
 	if checkLogSourceForMirroring("web") {
 		// Always log from the end of the web server messages.log, because the log rotation should happen as soon as the web server starts
@@ -327,14 +406,14 @@ func doMain() error {
 		}
 	}
 
-	err = postInit(name, keyLabel, defaultP12Truststore)
+	err = postInit(startupCtx, name, keyLabel, defaultP12Truststore)
 	if err != nil {
 		logTermination(err)
 		return err
 	}
 
 	if os.Getenv("MQ_NATIVE_HA") == "true" {
-		err = ha.ConfigureNativeHA(log)
+		err = ha.ConfigureNativeHA(startupCtx, log)
 		if err != nil {
 			logTermination(err)
 			return err
@@ -346,21 +425,39 @@ func doMain() error {
 
 	enableTraceCrtmqm := os.Getenv("MQ_ENABLE_TRACE_CRTMQM")
 	if enableTraceCrtmqm == "true" || enableTraceCrtmqm == "1" {
-		err = startMQTrace()
+		err = startMQTrace(startupCtx)
 		if err != nil {
 			logTermination(err)
 			return err
 		}
 	}
 
-	newQM, err := createQueueManager(name, *devFlag)
+	qmConfig, err := loadQueueManagerConfig(qmConfigFile)
+	if err != nil {
+		logTermination(err)
+		return err
+	}
+
+	err = runHooks(name, hooks.PreCreate)
+	if err != nil {
+		logTermination(err)
+		return err
+	}
+
+	newQM, err := createQueueManager(startupCtx, name, *devFlag, qmConfig)
+	if err != nil {
+		logTermination(err)
+		return err
+	}
+
+	err = runHooks(name, hooks.PostCreate, "MQ_QMGR_CREATED="+strconv.FormatBool(newQM))
 	if err != nil {
 		logTermination(err)
 		return err
 	}
 
 	if enableTraceCrtmqm == "true" || enableTraceCrtmqm == "1" {
-		err = endMQTrace()
+		err = endMQTrace(startupCtx)
 		if err != nil {
 			logTermination(err)
 			return err
@@ -392,7 +489,7 @@ func doMain() error {
 		}
 	}
 
-	err = updateCommandLevel()
+	err = updateCommandLevel(startupCtx)
 	if err != nil {
 		logTermination(err)
 		return err
@@ -400,7 +497,7 @@ func doMain() error {
 
 	enableTraceStrmqm := os.Getenv("MQ_ENABLE_TRACE_STRMQM")
 	if enableTraceStrmqm == "true" || enableTraceStrmqm == "1" {
-		err = startMQTrace()
+		err = startMQTrace(startupCtx)
 		if err != nil {
 			logTermination(err)
 			return err
@@ -417,7 +514,14 @@ func doMain() error {
 		}
 	}
 
-	err = startQueueManager(name)
+	// Merge any qm.ini overlay files into the qm.ini, if enabled
+	err = applyQMIniOverlay(name)
+	if err != nil {
+		logTermination(err)
+		return err
+	}
+
+	err = startQueueManager(startupCtx, name)
 	if err != nil {
 		logTermination(err)
 		return err
@@ -432,8 +536,37 @@ func doMain() error {
 		}
 	}
 
+	// Reload the queue manager's TLS keys and certificates when they change, if enabled
+	if isTLSReloadEnabled() {
+		err = startTLSReload(ctx, name, keyLabel, defaultCmsKeystore, *devFlag)
+		if err != nil {
+			logTermination(err)
+			return err
+		}
+	}
+
+	// Run the parts of runmqserver which need an active queue manager only while this instance is active,
+	// so that they are started on a standby or replica instance when it takes over
+	roleWatcher := newRoleWatcher(name)
+	roleWatcher.Register(runtimeMQSCSubsystem(roleWatcher, name))
+	//If enabled, mirror the messages on the queue manager's event queues
+	if checkLogSourceForMirroring("event") {
+		roleWatcher.Register(eventMirroringSubsystem(&wg, name, mf))
+	}
+	if isTLSReloadEnabled() {
+		roleWatcher.Register(tlsRefreshSubsystem(name))
+	}
+	if isWebServerEnabled() {
+		roleWatcher.Register(webServerSubsystem())
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		roleWatcher.Run(ctx)
+	}()
+
 	if enableTraceStrmqm == "true" || enableTraceStrmqm == "1" {
-		err = endMQTrace()
+		err = endMQTrace(startupCtx)
 		if err != nil {
 			logTermination(err)
 			return err
@@ -443,10 +576,24 @@ func doMain() error {
 	enableMetrics := os.Getenv("MQ_ENABLE_METRICS")
 	if enableMetrics == "true" || enableMetrics == "1" {
 		go metrics.GatherMetrics(name, log)
+		otlpExporter, err := startOTLPMetricExporter(name)
+		if err != nil {
+			logTermination(err)
+			return err
+		}
+		if otlpExporter != nil {
+			defer otlpExporter.Close(logSinkCloseTimeout)
+		}
 	} else {
 		log.Println("Metrics are disabled")
 	}
 
+	err = runHooks(name, hooks.PostStart)
+	if err != nil {
+		logTermination(err)
+		return err
+	}
+
 	// Start reaping zombies from now on.
 	// Start this here, so that we don't reap any sub-processes created
 	// by this process (e.g. for crtmqm or strmqm)
@@ -463,8 +610,18 @@ func doMain() error {
 		logTermination(err)
 		return err
 	}
-	// Wait for terminate signal
-	<-signalControl
+
+	// Watch for the queue manager ending without a stop being requested
+	supervisorErrors := superviseQueueManager(name, qmEndedPolicy, restartLimit)
+
+	// Wait for terminate signal, or for the queue manager to end unexpectedly
+	select {
+	case <-signalControl:
+	case err = <-supervisorErrors:
+		logTermination(err)
+		metrics.StopMetricsGathering(log)
+		return err
+	}
 	return nil
 }
 
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2019
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -18,79 +18,227 @@ package main
 import (
 	"bufio"
 	"context"
-	"fmt"
+	"encoding/json"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
+
+	"github.com/fsnotify/fsnotify"
 )
 
-// waitForFile waits until the specified file exists
-func waitForFile(ctx context.Context, path string) (os.FileInfo, error) {
-	var fi os.FileInfo
-	var err error
-	// Wait for file to exist
-	for {
-		select {
-		// Check to see if cancellation has been requested
-		case <-ctx.Done():
-			return os.Stat(path)
-		default:
-			fi, err = os.Stat(path)
-			if err != nil {
-				if os.IsNotExist(err) {
-					time.Sleep(500 * time.Millisecond)
-					continue
-				} else {
-					return nil, fmt.Errorf("mirror: unable to get info on file %v", path)
-				}
-			}
-			return fi, nil
+// mirrorStateFile records how far each mirrored file has been read.  It is on the data volume, so that
+// mirroring can resume from the same place after the container is restarted.
+const mirrorStateFile = "/mnt/mqm/data/runmqserver/mirror.json"
+
+// mirrorPollInterval is how often mirrored files are checked, in case a filesystem event is missed
+const mirrorPollInterval = 5 * time.Second
+
+// mirrorSaveInterval is the minimum time between writes of the state file while mirroring
+const mirrorSaveInterval = 5 * time.Second
+
+// mirrorPosition is the position in a mirrored file after the last line mirrored
+type mirrorPosition struct {
+	Inode  uint64 `json:"inode"`
+	Offset int64  `json:"offset"`
+}
+
+// mirrorState holds the position of each mirrored file, keyed by path, and persists them to a file.  The file
+// is written at most once every saveInterval while mirroring, and when mirroring stops, so after a crash the
+// lines mirrored since the last write are mirrored again.
+type mirrorState struct {
+	mu           sync.Mutex
+	path         string
+	positions    map[string]mirrorPosition
+	saveInterval time.Duration
+	lastSave     time.Time
+	// dirty is true if there are positions which haven't been written to the file
+	dirty bool
+}
+
+func newMirrorState(path string) *mirrorState {
+	return &mirrorState{path: path, saveInterval: mirrorSaveInterval}
+}
+
+// mirrorPositions is the state used for all mirrored files
+var mirrorPositions = newMirrorState(mirrorStateFile)
+
+// load reads the state file, if it hasn't already been read.  The caller must hold the lock.
+func (s *mirrorState) load() {
+	if s.positions != nil {
+		return
+	}
+	s.positions = map[string]mirrorPosition{}
+	// #nosec G304 - the state file is a defined constant
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Debugf("Unable to read mirror state file %v: %v", s.path, err)
+		}
+		return
+	}
+	err = json.Unmarshal(data, &s.positions)
+	if err != nil {
+		log.Debugf("Ignoring invalid mirror state file %v: %v", s.path, err)
+		s.positions = map[string]mirrorPosition{}
+	}
+}
+
+// get returns the saved position for the specified file
+func (s *mirrorState) get(file string) (mirrorPosition, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	pos, ok := s.positions[file]
+	return pos, ok
+}
+
+// set records the position for the specified file, and writes the state file if there are unsaved
+// positions and it hasn't been written within the save interval
+func (s *mirrorState) set(file string, pos mirrorPosition) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	if existing, ok := s.positions[file]; !ok || existing != pos {
+		s.positions[file] = pos
+		s.dirty = true
+	}
+	if s.dirty && time.Since(s.lastSave) >= s.saveInterval {
+		s.saveAndLog()
+	}
+}
+
+// flush writes the state file if there are unsaved positions
+func (s *mirrorState) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dirty {
+		s.saveAndLog()
+	}
+}
+
+// saveAndLog writes the state file, logging any error.  The caller must hold the lock.
+func (s *mirrorState) saveAndLog() {
+	s.lastSave = time.Now()
+	err := s.save()
+	if err != nil {
+		log.Debugf("Unable to write mirror state file %v: %v", s.path, err)
+		return
+	}
+	s.dirty = false
+}
+
+// save atomically writes the state file.  The caller must hold the lock.
+func (s *mirrorState) save() error {
+	data, err := json.Marshal(s.positions)
+	if err != nil {
+		return err
+	}
+	// #nosec G301
+	err = os.MkdirAll(filepath.Dir(s.path), 0770)
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	// #nosec G306 - its a read by owner/s group, and pose no harm.
+	err = os.WriteFile(tmp, data, 0660)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// getInode returns the inode number of a file
+func getInode(fi os.FileInfo) uint64 {
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		return uint64(st.Ino)
+	}
+	return 0
+}
+
+// findRotatedFile returns the path of a file in the same directory, and with the same extension, as the
+// specified file, which has the specified inode number.  This finds a log file which has been rotated.
+func findRotatedFile(path string, inode uint64) string {
+	dir := filepath.Dir(path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		p := filepath.Join(dir, entry.Name())
+		if p == path || filepath.Ext(p) != filepath.Ext(path) {
+			continue
+		}
+		fi, err := entry.Info()
+		if err == nil && fi.Mode().IsRegular() && getInode(fi) == inode {
+			return p
 		}
 	}
+	return ""
 }
 
 type mirrorFunc func(msg string, isQMLog bool) bool
 
-// mirrorAvailableMessages prints lines from the file, until no more are available
-func mirrorAvailableMessages(f *os.File, mf mirrorFunc, isQMLog bool) {
-	scanner := bufio.NewScanner(f)
+// mirrorAvailableMessages prints complete lines from the file, starting at the specified offset, until
+// no more are available, and returns the offset after the last line mirrored.  An incomplete line at
+// the end of the file is left to be mirrored once it is complete, unless final is set, for example
+// because the file has been rotated.
+func mirrorAvailableMessages(f *os.File, offset int64, mf mirrorFunc, isQMLog bool, final bool) int64 {
+	_, err := f.Seek(offset, io.SeekStart)
+	if err != nil {
+		log.Errorf("Unable to seek to offset %v in file %v: %v", offset, f.Name(), err)
+		return offset
+	}
+	r := bufio.NewReader(f)
 	count := 0
-	for scanner.Scan() {
-		t := scanner.Text()
-		if mf(t, isQMLog) {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				log.Errorf("Error reading file %v: %v", f.Name(), err)
+			} else if final && line != "" {
+				offset += int64(len(line))
+				if mf(strings.TrimRight(line, "\r"), isQMLog) {
+					count++
+				}
+			}
+			break
+		}
+		offset += int64(len(line))
+		if mf(strings.TrimRight(line, "\r\n"), isQMLog) {
 			count++
 		}
 	}
 	if count > 0 {
 		log.Debugf("Mirrored %v log entries from %v", count, f.Name())
 	}
-	err := scanner.Err()
-	if err != nil {
-		log.Errorf("Error reading file %v: %v", f.Name(), err)
-		return
-	}
+	return offset
 }
 
 // mirrorLog tails the specified file, and logs each line to stdout.
 // This is useful for usability, as the container console log can show
 // messages from the MQ error logs.
+// The position reached in the file is saved, so that if the container is restarted, mirroring resumes
+// from the same place, including finishing any part of the file which was rotated while stopped.  If
+// there is no saved position, mirroring starts from the end of an existing file, unless fromStart is set.
 func mirrorLog(ctx context.Context, wg *sync.WaitGroup, path string, fromStart bool, mf mirrorFunc, isQMLog bool) (chan error, error) {
 	errorChannel := make(chan error, 1)
-	var offset int64 = -1
-	var f *os.File
-	var err error
+	var offset int64
+	var f, previous *os.File
+	var previousOffset int64
 	var fi os.FileInfo
+	var err error
+	saved, haveSaved := mirrorPositions.get(path)
 	// Need to check if the file exists before returning, otherwise we have a
 	// race to see if the new file get created before we can test for it
-	fi, err = os.Stat(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			// File doesn't exist, so ensure we start at the beginning
-			offset = 0
-		} else {
-			return nil, err
-		}
-	} else {
+	_, err = os.Stat(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
 		// If the file exists, open it now, before we return.  This makes sure
 		// the file is open before the queue manager is created or started.
 		// Otherwise, there would be the potential for a nearly-full file to
@@ -100,99 +248,136 @@ func mirrorLog(ctx context.Context, wg *sync.WaitGroup, path string, fromStart b
 		if err != nil {
 			return nil, err
 		}
-		// File already exists, so start reading at the end
-		offset = fi.Size()
+		fi, err = f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		switch {
+		case haveSaved && saved.Inode == getInode(fi):
+			if saved.Offset <= fi.Size() {
+				log.Debugf("Resuming mirroring of %v from offset %v", path, saved.Offset)
+				offset = saved.Offset
+			}
+		case haveSaved || fromStart:
+			// The file is new since the position was saved, or we've been told to go from the start
+			offset = 0
+		default:
+			// Start reading at the end
+			offset = fi.Size()
+		}
+	}
+	if haveSaved && (f == nil || saved.Inode != getInode(fi)) {
+		// The file has been rotated since the position was saved, so finish mirroring the rotated file first
+		rotated := findRotatedFile(path, saved.Inode)
+		if rotated != "" {
+			log.Debugf("Resuming mirroring of rotated file %v from offset %v", rotated, saved.Offset)
+			// #nosec G304 - no harm, we open readonly and check error.
+			previous, err = os.OpenFile(rotated, os.O_RDONLY, 0)
+			if err != nil {
+				log.Debugf("Unable to open rotated file %v: %v", rotated, err)
+				previous = nil
+			}
+			previousOffset = saved.Offset
+		}
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		if f != nil {
+			f.Close()
+		}
+		if previous != nil {
+			previous.Close()
+		}
+		return nil, err
 	}
 	// Increment wait group counter, only if the goroutine gets started
 	wg.Add(1)
 	go func() {
 		// Notify the wait group when this goroutine ends
 		defer func() {
+			if f != nil {
+				f.Close()
+			}
+			watcher.Close()
+			mirrorPositions.flush()
 			log.Debugf("Finished monitoring %v", path)
 			wg.Done()
 		}()
-		if f == nil {
-			// File didn't exist, so need to wait for it
-			fi, err = waitForFile(ctx, path)
-			if err != nil {
-				log.Error(err)
-				errorChannel <- err
-				return
-			}
-			if fi == nil {
-				return
-			}
-			log.Debugf("File exists: %v, %v", path, fi.Size())
-			// #nosec G304 - no harm, we open readonly and check error.
-			f, err = os.OpenFile(path, os.O_RDONLY, 0)
-			if err != nil {
-				log.Error(err)
-				errorChannel <- err
-				return
-			}
-		}
-
-		fi, err = f.Stat()
-		if err != nil {
-			log.Error(err)
-			errorChannel <- err
-			return
-		}
-		// The file now exists.  If it didn't exist before we started, offset=0
-		// Always start at the beginning if we've been told to go from the start
-		if offset != 0 && !fromStart {
-			log.Debugf("Seeking offset %v in file %v", offset, path)
-			_, err = f.Seek(offset, 0)
-			if err != nil {
-				log.Errorf("Unable to return to offset %v: %v", offset, err)
-			}
+		if previous != nil {
+			mirrorAvailableMessages(previous, previousOffset, mf, isQMLog, true)
+			previous.Close()
 		}
+		// Watch the directory rather than the file, so that rotation and creation of the file are seen
+		dir := filepath.Dir(path)
+		watching := false
+		ticker := time.NewTicker(mirrorPollInterval)
+		defer ticker.Stop()
 		closing := false
 		for {
-			// If there's already data there, mirror it now.
-			mirrorAvailableMessages(f, mf, isQMLog)
-			// Wait for the new log file (after rotation)
-			newFI, err := waitForFile(ctx, path)
-			if err != nil {
-				log.Error(err)
-				errorChannel <- err
-				return
+			if !watching {
+				// The directory may not exist yet, in which case the watch is retried on the next poll
+				watching = watcher.Add(dir) == nil
 			}
-			if !os.SameFile(fi, newFI) {
-				log.Debugf("Detected log rotation in file %v", path)
-				// WARNING: There is a possible race condition here.  If *another*
-				// log rotation happens before we can open the new file, then we
-				// could skip all those messages.  This could happen with a very small
-				// MQ error log size.
-				mirrorAvailableMessages(f, mf, isQMLog)
-				err = f.Close()
-				if err != nil {
-					log.Errorf("Unable to close mirror file handle: %v", err)
-				}
-				// Re-open file
-				log.Debugf("Re-opening error log file %v", path)
+			if f == nil {
 				// #nosec G304 - no harm, we open readonly and check error.
 				f, err = os.OpenFile(path, os.O_RDONLY, 0)
+				if err == nil {
+					fi, err = f.Stat()
+				}
 				if err != nil {
+					f = nil
+					if !os.IsNotExist(err) {
+						log.Error(err)
+						errorChannel <- err
+						return
+					}
+				} else {
+					log.Debugf("File exists: %v, %v", path, fi.Size())
+					// The file didn't exist when we started, or has been created since
+					offset = 0
+				}
+			}
+			if f != nil {
+				if current, err := f.Stat(); err == nil && current.Size() < offset {
+					log.Debugf("Detected truncation of file %v", path)
+					offset = 0
+				}
+				offset = mirrorAvailableMessages(f, offset, mf, isQMLog, false)
+				newFI, err := os.Stat(path)
+				if err != nil && !os.IsNotExist(err) {
 					log.Error(err)
 					errorChannel <- err
 					return
 				}
-				fi = newFI
-				// Don't seek this time, because we know it's a new file
-				mirrorAvailableMessages(f, mf, isQMLog)
+				if err == nil && !os.SameFile(fi, newFI) {
+					log.Debugf("Detected log rotation in file %v", path)
+					// Finish the rotated file, then start on the new one
+					mirrorAvailableMessages(f, offset, mf, isQMLog, true)
+					err = f.Close()
+					if err != nil {
+						log.Errorf("Unable to close mirror file handle: %v", err)
+					}
+					// Re-open file
+					log.Debugf("Re-opening error log file %v", path)
+					f = nil
+					continue
+				}
+				mirrorPositions.set(path, mirrorPosition{Inode: getInode(fi), Offset: offset})
+			}
+			if closing {
+				log.Debugf("Shutting down mirror for %v", path)
+				return
 			}
 			select {
 			case <-ctx.Done():
 				log.Debugf("Context cancelled for mirroring %v", path)
-				if closing {
-					log.Debugf("Shutting down mirror for %v", path)
-					return
-				}
 				// Set a flag, to allow one more time through the loop
 				closing = true
-			default:
-				time.Sleep(500 * time.Millisecond)
+			case <-watcher.Events:
+			case err := <-watcher.Errors:
+				log.Debugf("Error watching %v: %v", dir, err)
+			case <-ticker.C:
 			}
 		}
 	}()
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2023
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -26,7 +27,17 @@ import (
 	"time"
 )
 
+// useTempMirrorState saves the mirror positions in a temporary directory for the duration of a test
+func useTempMirrorState(t *testing.T) {
+	saved := mirrorPositions
+	mirrorPositions = newMirrorState(filepath.Join(t.TempDir(), "mirror.json"))
+	t.Cleanup(func() {
+		mirrorPositions = saved
+	})
+}
+
 func TestMirrorLogWithoutRotation(t *testing.T) {
+	useTempMirrorState(t)
 	// Repeat the test multiple times, to help identify timing problems
 	for i := 0; i < 10; i++ {
 		t.Run(t.Name()+strconv.Itoa(i), func(t *testing.T) {
@@ -66,6 +77,7 @@ func TestMirrorLogWithoutRotation(t *testing.T) {
 }
 
 func TestMirrorLogWithRotation(t *testing.T) {
+	useTempMirrorState(t)
 	// Repeat the test multiple times, to help identify timing problems
 	for i := 0; i < 5; i++ {
 		t.Run(t.Name()+strconv.Itoa(i), func(t *testing.T) {
@@ -125,6 +137,7 @@ func TestMirrorLogWithRotation(t *testing.T) {
 }
 
 func testMirrorLogExistingFile(t *testing.T, newQM bool) int {
+	useTempMirrorState(t)
 	tmp, err := os.CreateTemp("", t.Name())
 	if err != nil {
 		t.Fatal(err)
@@ -175,6 +188,7 @@ func TestMirrorLogExistingFileButNewQueueManager(t *testing.T) {
 }
 
 func TestMirrorLogCancelWhileWaiting(t *testing.T) {
+	useTempMirrorState(t)
 	ctx, cancel := context.WithCancel(context.Background())
 	var wg sync.WaitGroup
 	defer func() {
@@ -192,3 +206,125 @@ func TestMirrorLogCancelWhileWaiting(t *testing.T) {
 	wg.Wait()
 	// No need to assert anything.  If it didn't work, the code would have hung (TODO: not ideal)
 }
+
+// runMirrorLog mirrors the file until write has been called, and returns the messages mirrored
+func runMirrorLog(t *testing.T, path string, fromStart bool, write func()) []string {
+	msgs := []string{}
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	_, err := mirrorLog(ctx, &wg, path, fromStart, func(msg string, isQMLog bool) bool {
+		msgs = append(msgs, msg)
+		return true
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write()
+	cancel()
+	wg.Wait()
+	return msgs
+}
+
+func appendToFile(t *testing.T, path string, data string) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	_, err = f.WriteString(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkMessages(t *testing.T, expected []string, actual []string) {
+	t.Helper()
+	if strings.Join(expected, ",") != strings.Join(actual, ",") {
+		t.Errorf("Expected messages %v; got %v", expected, actual)
+	}
+}
+
+// TestMirrorLogResume tests that mirroring resumes from the saved position, even if asked to start from the
+// beginning of the file
+func TestMirrorLogResume(t *testing.T) {
+	useTempMirrorState(t)
+	path := filepath.Join(t.TempDir(), "AMQERR01.json")
+	appendToFile(t, path, "A\n")
+	msgs := runMirrorLog(t, path, true, func() {
+		appendToFile(t, path, "B\n")
+	})
+	checkMessages(t, []string{"A", "B"}, msgs)
+
+	appendToFile(t, path, "C\n")
+	// Simulate a restart, by discarding the positions held in memory
+	mirrorPositions = newMirrorState(mirrorPositions.path)
+	msgs = runMirrorLog(t, path, true, func() {
+		appendToFile(t, path, "D\n")
+	})
+	checkMessages(t, []string{"C", "D"}, msgs)
+}
+
+// TestMirrorLogResumeAfterRotation tests that the remainder of a file which was rotated while mirroring was
+// stopped is mirrored, before the new file
+func TestMirrorLogResumeAfterRotation(t *testing.T) {
+	useTempMirrorState(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "AMQERR01.json")
+	msgs := runMirrorLog(t, path, true, func() {
+		appendToFile(t, path, "A\n")
+	})
+	checkMessages(t, []string{"A"}, msgs)
+
+	appendToFile(t, path, "B\n")
+	err := os.Rename(path, filepath.Join(dir, "AMQERR02.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	appendToFile(t, path, "C\n")
+	mirrorPositions = newMirrorState(mirrorPositions.path)
+	msgs = runMirrorLog(t, path, false, func() {})
+	checkMessages(t, []string{"B", "C"}, msgs)
+}
+
+// TestMirrorLogPartialLine tests that an incomplete line isn't mirrored until it is complete
+func TestMirrorLogPartialLine(t *testing.T) {
+	useTempMirrorState(t)
+	path := filepath.Join(t.TempDir(), "AMQERR01.json")
+	appendToFile(t, path, "A\n{\"message\":")
+	msgs := runMirrorLog(t, path, true, func() {})
+	checkMessages(t, []string{"A"}, msgs)
+
+	mirrorPositions = newMirrorState(mirrorPositions.path)
+	msgs = runMirrorLog(t, path, true, func() {
+		appendToFile(t, path, "\"B\"}\n")
+	})
+	checkMessages(t, []string{`{"message":"B"}`}, msgs)
+}
+
+// TestMirrorStateSaveInterval tests that the state file is written at most once in the save interval, and
+// that the latest positions are written when flushed
+func TestMirrorStateSaveInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mirror.json")
+	state := newMirrorState(path)
+	state.saveInterval = time.Hour
+	read := func() string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	state.set("AMQERR01.json", mirrorPosition{Inode: 1, Offset: 10})
+	if expected := `{"AMQERR01.json":{"inode":1,"offset":10}}`; read() != expected {
+		t.Errorf("Expected the first position to be written; got %v", read())
+	}
+	state.set("AMQERR01.json", mirrorPosition{Inode: 1, Offset: 20})
+	if expected := `{"AMQERR01.json":{"inode":1,"offset":10}}`; read() != expected {
+		t.Errorf("Expected the state file not to be written within the save interval; got %v", read())
+	}
+	state.flush()
+	if expected := `{"AMQERR01.json":{"inode":1,"offset":20}}`; read() != expected {
+		t.Errorf("Expected the latest position to be written when flushed; got %v", read())
+	}
+}
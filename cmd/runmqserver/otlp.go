@@ -0,0 +1,124 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/envvars"
+	"github.com/ibm-messaging/mq-container/internal/metrics"
+	"github.com/ibm-messaging/mq-container/internal/otlp"
+)
+
+// keyDirOTLP holds the optional CA certificate, and client certificate and key, used to connect to an OpenTelemetry collector over HTTPS
+const keyDirOTLP = "/etc/mqm/otlp/pki/keys"
+
+// defaultOTLPExportInterval is the default number of seconds between exports of metrics to an OpenTelemetry collector
+const defaultOTLPExportInterval = 60
+
+// otlpSignalEnabled returns true if the signal ("metrics" or "logs") should be exported to an OpenTelemetry collector
+func otlpSignalEnabled(signal string) bool {
+	signals, ok := os.LookupEnv("MQ_OTLP_SIGNALS")
+	if !ok {
+		return true
+	}
+	for _, s := range strings.Split(signals, ",") {
+		if strings.TrimSpace(s) == signal {
+			return true
+		}
+	}
+	return false
+}
+
+// getOTLPConfig returns the configuration for exporting to the collector at the endpoint
+func getOTLPConfig(name string, endpoint string) (otlp.Config, error) {
+	config := otlp.Config{
+		Endpoint: endpoint,
+		ErrorLog: logSinkError,
+	}
+	u, err := otlp.ParseEndpoint(endpoint)
+	if err != nil {
+		return config, err
+	}
+	config.Resource, err = otlp.BuildResource(name)
+	if err != nil {
+		return config, err
+	}
+	if u.Scheme == "https" {
+		port := u.Port()
+		if port == "" {
+			port = "443"
+		}
+		config.TLSConfig, err = getClientTLSConfig(keyDirOTLP, net.JoinHostPort(u.Hostname(), port))
+		if err != nil {
+			return config, err
+		}
+	}
+	return config, nil
+}
+
+// newOTLPLogExporter creates a log sink which exports logs to an OpenTelemetry collector
+func newOTLPLogExporter(name string, endpoint string) (*otlp.LogExporter, error) {
+	config, err := getOTLPConfig(name, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return otlp.NewLogExporter(config)
+}
+
+// startOTLPMetricExporter starts exporting the queue manager metrics to an OpenTelemetry collector, if configured.
+// A nil exporter is returned if metrics are not exported.
+func startOTLPMetricExporter(name string) (*otlp.MetricExporter, error) {
+	endpoint := os.Getenv("MQ_OTLP_ENDPOINT")
+	if endpoint == "" || !otlpSignalEnabled("metrics") {
+		return nil, nil
+	}
+	interval, err := getOTLPExportInterval()
+	if err != nil {
+		return nil, err
+	}
+	config, err := getOTLPConfig(name, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	gatherer, err := metrics.NewGatherer(name, log)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := otlp.NewMetricExporter(config, gatherer, interval)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Exporting metrics to OpenTelemetry collector %v every %v", endpoint, interval)
+	return exporter, nil
+}
+
+// getOTLPExportInterval returns the interval between exports of metrics, from MQ_OTLP_EXPORT_INTERVAL
+func getOTLPExportInterval() (time.Duration, error) {
+	value := os.Getenv("MQ_OTLP_EXPORT_INTERVAL")
+	if value == "" {
+		return defaultOTLPExportInterval * time.Second, nil
+	}
+	seconds, err := envvars.ParsePositiveInteger(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for MQ_OTLP_EXPORT_INTERVAL: '%v'", value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
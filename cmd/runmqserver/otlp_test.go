@@ -0,0 +1,73 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestOTLPSignalEnabled(t *testing.T) {
+	tests := []struct {
+		signals string
+		metrics bool
+		logs    bool
+	}{
+		{"metrics,logs", true, true},
+		{"metrics", true, false},
+		{" logs ", false, true},
+		{"", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.signals, func(t *testing.T) {
+			t.Setenv("MQ_OTLP_SIGNALS", tt.signals)
+			if otlpSignalEnabled("metrics") != tt.metrics || otlpSignalEnabled("logs") != tt.logs {
+				t.Errorf("Expected metrics=%v, logs=%v", tt.metrics, tt.logs)
+			}
+		})
+	}
+}
+
+func TestOTLPSignalEnabledDefault(t *testing.T) {
+	t.Setenv("MQ_OTLP_SIGNALS", "")
+	os.Unsetenv("MQ_OTLP_SIGNALS")
+	if !otlpSignalEnabled("metrics") || !otlpSignalEnabled("logs") {
+		t.Error("Expected all signals to be enabled by default")
+	}
+}
+
+func TestGetOTLPExportInterval(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		err      bool
+	}{
+		{"", 60 * time.Second, false},
+		{"15", 15 * time.Second, false},
+		{"0", 0, true},
+		{"1m", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("MQ_OTLP_EXPORT_INTERVAL", tt.value)
+			interval, err := getOTLPExportInterval()
+			if (err != nil) != tt.err || interval != tt.expected {
+				t.Errorf("Expected %v (error %v); got %v (%v)", tt.expected, tt.err, interval, err)
+			}
+		})
+	}
+}
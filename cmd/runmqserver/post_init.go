@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2023
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -16,17 +16,15 @@ limitations under the License.
 package main
 
 import (
-	"os"
+	"context"
 
 	"github.com/ibm-messaging/mq-container/internal/fips"
 	"github.com/ibm-messaging/mq-container/internal/tls"
 )
 
-// postInit is run after /var/mqm is set up
-func postInit(name, keyLabel string, p12Truststore tls.KeyStoreData) error {
-	enableWebServer := os.Getenv("MQ_ENABLE_EMBEDDED_WEB_SERVER")
-	if enableWebServer == "true" || enableWebServer == "1" {
-
+// postInit is run after /var/mqm is set up.  The web server is started once the queue manager is active.
+func postInit(ctx context.Context, name, keyLabel string, p12Truststore tls.KeyStoreData) error {
+	if isWebServerEnabled() {
 		// Enable FIPS for MQ Web Server if asked for.
 		if fips.IsFIPSEnabled() {
 			err := configureFIPSWebServer(p12Truststore)
@@ -36,19 +34,10 @@ func postInit(name, keyLabel string, p12Truststore tls.KeyStoreData) error {
 		}
 
 		// Configure the web server (if enabled)
-		err := configureWebServer(keyLabel, p12Truststore)
+		err := configureWebServer(ctx, keyLabel, p12Truststore)
 		if err != nil {
 			return err
 		}
-
-		// Start the web server, in the background (if installed)
-		// WARNING: No error handling or health checking available for the web server
-		go func() {
-			err = startWebServer()
-			if err != nil {
-				log.Printf("Error starting web server: %v", err)
-			}
-		}()
 	}
 	return nil
 }
@@ -0,0 +1,147 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ibm-messaging/mq-container/internal/containerruntime"
+	"github.com/ibm-messaging/mq-container/internal/pathutils"
+)
+
+// preflightMode determines what happens when a preflight check fails
+type preflightMode string
+
+const (
+	preflightModeOff  preflightMode = "off"
+	preflightModeWarn preflightMode = "warn"
+	preflightModeFail preflightMode = "fail"
+)
+
+const (
+	// logPageSize is the size of a page in the recovery log
+	logPageSize = 4096
+	// The default recovery log settings used by crtmqm
+	defaultLogFilePages      = 4096
+	defaultLogPrimaryFiles   = 3
+	defaultLogSecondaryFiles = 2
+)
+
+// getPreflightMode returns the preflight mode set in MQ_PREFLIGHT_CHECKS
+func getPreflightMode() (preflightMode, error) {
+	mode := preflightMode(strings.ToLower(strings.TrimSpace(os.Getenv("MQ_PREFLIGHT_CHECKS"))))
+	switch mode {
+	case "":
+		return preflightModeWarn, nil
+	case preflightModeOff, preflightModeWarn, preflightModeFail:
+		return mode, nil
+	}
+	return "", fmt.Errorf("Invalid value for MQ_PREFLIGHT_CHECKS: '%v'. Allowed values are 'off', 'warn' and 'fail'", mode)
+}
+
+// getRecoveryLogSize returns the number of bytes needed for the recovery log of a new queue manager
+func getRecoveryLogSize(qmConfig *queueManagerConfig) uint64 {
+	filePages := defaultLogFilePages
+	primaryFiles := defaultLogPrimaryFiles
+	secondaryFiles := defaultLogSecondaryFiles
+	if lfp, err := strconv.Atoi(os.Getenv("MQ_QMGR_LOG_FILE_PAGES")); err == nil && lfp > 0 {
+		filePages = lfp
+	}
+	if qmConfig != nil {
+		if qmConfig.Log.FilePages > 0 {
+			filePages = qmConfig.Log.FilePages
+		}
+		if qmConfig.Log.PrimaryFiles > 0 {
+			primaryFiles = qmConfig.Log.PrimaryFiles
+		}
+		if qmConfig.Log.SecondaryFiles > 0 {
+			secondaryFiles = qmConfig.Log.SecondaryFiles
+		}
+	}
+	return uint64(primaryFiles+secondaryFiles) * uint64(filePages) * logPageSize
+}
+
+// getPreflightConfig returns the resources needed by the queue manager
+func getPreflightConfig(name string, mounts map[string]string, qmConfig *queueManagerConfig) containerruntime.PreflightConfig {
+	config := containerruntime.PreflightConfig{
+		LogDir:       "/mnt/mqm/data/log",
+		WritableDirs: []string{"/mnt/mqm", "/run", "/tmp"},
+	}
+	if _, ok := mounts["/mnt/mqm-log"]; ok {
+		config.LogDir = "/mnt/mqm-log/log"
+		config.WritableDirs = append(config.WritableDirs, "/mnt/mqm-log")
+	}
+	if _, ok := mounts["/mnt/mqm-data"]; ok {
+		config.WritableDirs = append(config.WritableDirs, "/mnt/mqm-data")
+	}
+	// The recovery log of an existing queue manager has already been allocated
+	dataDir := getQueueManagerDataDir(mounts, replaceCharsInQMName(name))
+	if _, err := os.Stat(pathutils.CleanPath(dataDir, "qm.ini")); os.IsNotExist(err) {
+		config.LogSize = getRecoveryLogSize(qmConfig)
+	}
+	return config
+}
+
+// getPreflightProblems runs the preflight checks, logs any checks which were skipped, and returns a description
+// of each failed check
+func getPreflightProblems(name string) []string {
+	mounts, err := containerruntime.GetMounts()
+	if err != nil {
+		return []string{err.Error()}
+	}
+	// Problems with the configuration file are reported when it's used
+	qmConfig, _ := loadQueueManagerConfig(qmConfigFile)
+	problems := []string{}
+	for _, result := range containerruntime.RunPreflightChecks(getPreflightConfig(name, mounts, qmConfig)) {
+		switch result.Status {
+		case containerruntime.PreflightFailed:
+			problems = append(problems, fmt.Sprintf("%v: %v", result.Check, result.Detail))
+		case containerruntime.PreflightSkipped:
+			log.Printf("Preflight check skipped: %v: %v", result.Check, result.Detail)
+		default:
+			log.Debugf("Preflight check passed: %v: %v", result.Check, result.Detail)
+		}
+	}
+	return problems
+}
+
+// runPreflightChecks checks that the container provides the resources needed by the queue manager.  An error
+// is returned if any checks fail, and MQ_PREFLIGHT_CHECKS is set to "fail".
+func runPreflightChecks(name string) error {
+	mode, err := getPreflightMode()
+	if err != nil {
+		return err
+	}
+	if mode == preflightModeOff {
+		return nil
+	}
+	problems := getPreflightProblems(name)
+	if len(problems) == 0 {
+		log.Println("Preflight checks passed")
+		return nil
+	}
+	for _, problem := range problems {
+		log.Printf("Preflight check failed: %v", problem)
+	}
+	if mode == preflightModeFail {
+		return fmt.Errorf("%v preflight checks failed: %v", len(problems), strings.Join(problems, "; "))
+	}
+	log.Printf("Warning: %v preflight checks failed, the queue manager may not start or run correctly", len(problems))
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGetPreflightMode(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected preflightMode
+		err      bool
+	}{
+		{"", preflightModeWarn, false},
+		{"off", preflightModeOff, false},
+		{"FAIL", preflightModeFail, false},
+		{"error", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			t.Setenv("MQ_PREFLIGHT_CHECKS", test.value)
+			mode, err := getPreflightMode()
+			if (err != nil) != test.err {
+				t.Fatalf("Expected error=%v; got %v", test.err, err)
+			}
+			if mode != test.expected {
+				t.Errorf("Expected mode %v; got %v", test.expected, mode)
+			}
+		})
+	}
+}
+
+func TestGetRecoveryLogSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		filePages string
+		config    *queueManagerConfig
+		expected  uint64
+	}{
+		{"Default", "", nil, 5 * 4096 * 4096},
+		{"FilePagesFromEnv", "8192", nil, 5 * 8192 * 4096},
+		{"Config", "", &queueManagerConfig{Log: logConfig{FilePages: 16384, PrimaryFiles: 10, SecondaryFiles: 5}}, 15 * 16384 * 4096},
+		{"PartialConfig", "8192", &queueManagerConfig{Log: logConfig{PrimaryFiles: 4}}, 6 * 8192 * 4096},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("MQ_QMGR_LOG_FILE_PAGES", test.filePages)
+			size := getRecoveryLogSize(test.config)
+			if size != test.expected {
+				t.Errorf("Expected %v; got %v", test.expected, size)
+			}
+		})
+	}
+}
+
+func TestGetPreflightConfig(t *testing.T) {
+	mounts := map[string]string{"/mnt/mqm": "ext4", "/mnt/mqm-log": "nfs", "/mnt/mqm-data": "nfs"}
+	config := getPreflightConfig("QM1", mounts, nil)
+	if config.LogDir != "/mnt/mqm-log/log" {
+		t.Errorf("Expected log directory /mnt/mqm-log/log; got %v", config.LogDir)
+	}
+	for _, dir := range []string{"/mnt/mqm", "/mnt/mqm-log", "/mnt/mqm-data", "/run", "/tmp"} {
+		if !slices.Contains(config.WritableDirs, dir) {
+			t.Errorf("Expected %v to be checked; got %v", dir, config.WritableDirs)
+		}
+	}
+	if config.LogSize == 0 {
+		t.Error("Expected log size to be checked for a new queue manager")
+	}
+}
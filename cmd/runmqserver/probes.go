@@ -0,0 +1,106 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/envvars"
+	"github.com/ibm-messaging/mq-container/internal/probe"
+)
+
+const (
+	// defaultProbePort is the port used by the probe server if MQ_PROBE_PORT is not set
+	defaultProbePort = 9158
+	// metricsPort is the port used by the metrics server, which can't be shared with the probe server
+	metricsPort = 9157
+	// probePollInterval is the time between each check of the queue manager status by the probe server
+	probePollInterval = 5 * time.Second
+)
+
+// isProbeServerEnabled returns true if the HTTP probe server should be started
+func isProbeServerEnabled() bool {
+	enableProbeServer := os.Getenv("MQ_ENABLE_PROBE_SERVER")
+	return enableProbeServer == "true" || enableProbeServer == "1"
+}
+
+// getProbePort returns the port used by the probe server
+func getProbePort() (int, error) {
+	value := os.Getenv("MQ_PROBE_PORT")
+	if value == "" {
+		return defaultProbePort, nil
+	}
+	port, err := envvars.ParsePort(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for MQ_PROBE_PORT: '%v'", value)
+	}
+	return port, nil
+}
+
+// startProbeServer serves the liveness (/livez), readiness (/readyz) and startup (/startupz) probes over
+// HTTP, until the context is cancelled.  The probes use the same checks as chkmqhealthy, chkmqready and
+// chkmqstarted, but the status of the queue manager is polled in the background, rather than for each request.
+func startProbeServer(ctx context.Context, wg *sync.WaitGroup, name string) error {
+	port, err := getProbePort()
+	if err != nil {
+		return err
+	}
+	if port == metricsPort {
+		return fmt.Errorf("MQ_PROBE_PORT must not be the same as the metrics port (%v)", port)
+	}
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return fmt.Errorf("failed to start probe server: %w", err)
+	}
+
+	nativeHA := os.Getenv("MQ_NATIVE_HA") == "true"
+	poller := probe.NewPoller(probe.NewCommandSource(name), probePollInterval)
+	server := &http.Server{
+		Handler:           probe.NewHandler(poller, nativeHA),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		poller.Run(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err := server.Shutdown(shutdownCtx)
+		if err != nil {
+			log.Printf("Error stopping probe server: %v", err)
+		}
+	}()
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			log.Errorf("Probe server stopped: %v", err)
+		}
+	}()
+	log.Printf("Serving probes on port %v", port)
+	return nil
+}
@@ -0,0 +1,46 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"testing"
+)
+
+func TestGetProbePort(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int
+		err      bool
+	}{
+		{"", defaultProbePort, false},
+		{"8080", 8080, false},
+		{"0", 0, true},
+		{"65536", 0, true},
+		{"http", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			t.Setenv("MQ_PROBE_PORT", test.value)
+			port, err := getProbePort()
+			if (err != nil) != test.err {
+				t.Fatalf("Expected error=%v; got %v", test.err, err)
+			}
+			if port != test.expected {
+				t.Errorf("Expected port %d; got %d", test.expected, port)
+			}
+		})
+	}
+}
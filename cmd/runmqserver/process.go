@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2025
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/ibm-messaging/mq-container/internal/command"
@@ -66,3 +68,95 @@ func determineExecutable() (string, error) {
 	_, exec := filepath.Split(file)
 	return exec, nil
 }
+
+// findProcesses returns the executable of each process, other than this one, running a program from the
+// given directory, indexed by process ID
+func findProcesses(procDir string, programDir string) (map[int]string, error) {
+	entries, err := os.ReadDir(procDir)
+	if err != nil {
+		return nil, err
+	}
+	self := os.Getpid()
+	pids := map[int]string{}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || pid == self {
+			continue
+		}
+		// The process may have ended, or belong to another user
+		exe, err := os.Readlink(filepath.Join(procDir, entry.Name(), "exe"))
+		if err != nil {
+			continue
+		}
+		if strings.HasPrefix(exe, programDir) {
+			pids[pid] = exe
+		}
+	}
+	return pids, nil
+}
+
+// processInfo describes a process running in the container
+type processInfo struct {
+	pid         int
+	ppid        int
+	state       string
+	commandLine string
+}
+
+// listProcesses returns the processes in the container, in order of process ID
+func listProcesses(procDir string) ([]processInfo, error) {
+	entries, err := os.ReadDir(procDir)
+	if err != nil {
+		return nil, err
+	}
+	processes := []processInfo{}
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		// #nosec G304 - the files are in procfs
+		stat, err := os.ReadFile(filepath.Join(procDir, entry.Name(), "stat"))
+		if err != nil {
+			// The process may have ended
+			continue
+		}
+		// The command name is in parentheses, and may itself contain spaces or parentheses
+		end := strings.LastIndexByte(string(stat), ')')
+		start := strings.IndexByte(string(stat), '(')
+		if start < 0 || end < start {
+			continue
+		}
+		p := processInfo{pid: pid, commandLine: string(stat[start+1 : end])}
+		fields := strings.Fields(string(stat[end+1:]))
+		if len(fields) >= 2 {
+			p.state = fields[0]
+			p.ppid, _ = strconv.Atoi(fields[1])
+		}
+		// #nosec G304 - the files are in procfs
+		cmdline, err := os.ReadFile(filepath.Join(procDir, entry.Name(), "cmdline"))
+		if err == nil && len(cmdline) > 0 {
+			p.commandLine = redactCommandLine(strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00"))
+		}
+		processes = append(processes, p)
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].pid < processes[j].pid
+	})
+	return processes, nil
+}
+
+// redactCommandLine joins the arguments of a command, replacing passwords such as "-pw secret" with "*"
+func redactCommandLine(args []string) string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = arg
+		if i > 0 {
+			previous := strings.ToLower(args[i-1])
+			if strings.HasPrefix(previous, "-") && (strings.HasSuffix(previous, "pw") || strings.Contains(previous, "password")) {
+				redacted[i] = "*"
+			}
+		}
+	}
+	return strings.Join(redacted, " ")
+}
@@ -0,0 +1,58 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestListProcesses(t *testing.T) {
+	procDir := t.TempDir()
+	processes := map[string][]string{
+		"20": {"20 (runmqakm) D 1 20", "/opt/mqm/bin/runmqakm\x00-cert\x00-list\x00-pw\x00secret\x00"},
+		"3":  {"3 (my (odd) name) S 1 3", ""},
+		"1":  {"1 (runmqserver) S 0 1", "runmqserver\x00"},
+	}
+	for pid, files := range processes {
+		err := os.Mkdir(filepath.Join(procDir, pid), 0700)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(procDir, pid, "stat"), []byte(files[0]), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(procDir, pid, "cmdline"), []byte(files[1]), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	list, err := listProcesses(procDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []processInfo{
+		{1, 0, "S", "runmqserver"},
+		{3, 1, "S", "my (odd) name"},
+		{20, 1, "D", "/opt/mqm/bin/runmqakm -cert -list -pw *"},
+	}
+	if !slices.Equal(list, expected) {
+		t.Errorf("Expected %+v; got %+v", expected, list)
+	}
+}
@@ -0,0 +1,230 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ibm-messaging/mq-container/internal/pathutils"
+	"github.com/ibm-messaging/mq-container/pkg/mqini"
+)
+
+// qmConfigFile is the location of an optional file which defines how the queue manager is created
+const qmConfigFile = "/etc/mqm/qmgr.json"
+
+const (
+	logTypeCircular = "circular"
+	logTypeLinear   = "linear"
+)
+
+// queueManagerConfig is the definition of the queue manager, read from qmConfigFile
+type queueManagerConfig struct {
+	Log                      logConfig `json:"log"`
+	DefaultTransmissionQueue string    `json:"defaultTransmissionQueue"`
+	DeadLetterQueue          string    `json:"deadLetterQueue"`
+	Port                     int       `json:"port"`
+}
+
+// logConfig is the definition of the queue manager's recovery log.  Zero values mean the MQ default is used.
+type logConfig struct {
+	Type           string `json:"type"`
+	FilePages      int    `json:"filePages"`
+	PrimaryFiles   int    `json:"primaryFiles"`
+	SecondaryFiles int    `json:"secondaryFiles"`
+	BufferPages    int    `json:"bufferPages"`
+}
+
+var validQueueName = regexp.MustCompile(`^[A-Za-z0-9._/%]{1,48}$`)
+
+// unsupportedQMConfigExtensions are the extensions of files which might be mistaken for the queue manager
+// definition, but which are not read
+var unsupportedQMConfigExtensions = []string{".yaml", ".yml"}
+
+// loadQueueManagerConfig reads and validates the queue manager definition from the specified file.
+// If the file does not exist, nil is returned.  An error is returned if there is a YAML file alongside it,
+// as only JSON is supported.
+func loadQueueManagerConfig(path string) (*queueManagerConfig, error) {
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	for _, ext := range unsupportedQMConfigExtensions {
+		if _, err := os.Stat(base + ext); err == nil {
+			return nil, fmt.Errorf("Unsupported queue manager configuration %v: only JSON is supported, so use %v instead", base+ext, path)
+		}
+	}
+	// #nosec G304 - path is a defined constant
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to read queue manager configuration %v: %v", path, err)
+	}
+	config := &queueManagerConfig{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(config)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse queue manager configuration %v: %v", path, err)
+	}
+	config.Log.Type = strings.ToLower(strings.TrimSpace(config.Log.Type))
+	problems := config.validate()
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("Invalid queue manager configuration %v: %v", path, strings.Join(problems, "; "))
+	}
+	return config, nil
+}
+
+// validate returns a description of each problem with the queue manager definition
+func (c *queueManagerConfig) validate() []string {
+	problems := []string{}
+	switch c.Log.Type {
+	case "", logTypeCircular, logTypeLinear:
+	default:
+		problems = append(problems, fmt.Sprintf("log.type must be '%v' or '%v', not '%v'", logTypeCircular, logTypeLinear, c.Log.Type))
+	}
+	if c.Log.FilePages != 0 && (c.Log.FilePages < 64 || c.Log.FilePages > 65535) {
+		problems = append(problems, fmt.Sprintf("log.filePages must be between 64 and 65535, not %v", c.Log.FilePages))
+	}
+	if c.Log.PrimaryFiles != 0 && (c.Log.PrimaryFiles < 2 || c.Log.PrimaryFiles > 510) {
+		problems = append(problems, fmt.Sprintf("log.primaryFiles must be between 2 and 510, not %v", c.Log.PrimaryFiles))
+	}
+	if c.Log.SecondaryFiles != 0 && (c.Log.SecondaryFiles < 1 || c.Log.SecondaryFiles > 509) {
+		problems = append(problems, fmt.Sprintf("log.secondaryFiles must be between 1 and 509, not %v", c.Log.SecondaryFiles))
+	}
+	if c.Log.PrimaryFiles+c.Log.SecondaryFiles > 511 {
+		problems = append(problems, fmt.Sprintf("the total of log.primaryFiles and log.secondaryFiles must not exceed 511, not %v", c.Log.PrimaryFiles+c.Log.SecondaryFiles))
+	}
+	if c.Log.BufferPages != 0 && (c.Log.BufferPages < 18 || c.Log.BufferPages > 4096) {
+		problems = append(problems, fmt.Sprintf("log.bufferPages must be 0, or between 18 and 4096, not %v", c.Log.BufferPages))
+	}
+	if c.DefaultTransmissionQueue != "" && !validQueueName.MatchString(c.DefaultTransmissionQueue) {
+		problems = append(problems, fmt.Sprintf("defaultTransmissionQueue is not a valid queue name: '%v'", c.DefaultTransmissionQueue))
+	}
+	if c.DeadLetterQueue != "" && !validQueueName.MatchString(c.DeadLetterQueue) {
+		problems = append(problems, fmt.Sprintf("deadLetterQueue is not a valid queue name: '%v'", c.DeadLetterQueue))
+	}
+	if c.Port != 0 && (c.Port < 1 || c.Port > 65535) {
+		problems = append(problems, fmt.Sprintf("port must be between 1 and 65535, not %v", c.Port))
+	}
+	if lfp := os.Getenv("MQ_QMGR_LOG_FILE_PAGES"); lfp != "" && c.Log.FilePages != 0 && lfp != strconv.Itoa(c.Log.FilePages) {
+		problems = append(problems, fmt.Sprintf("log.filePages (%v) conflicts with MQ_QMGR_LOG_FILE_PAGES (%v)", c.Log.FilePages, lfp))
+	}
+	return problems
+}
+
+// getCreateArgs returns the 'crtmqm' arguments for the settings in the queue manager definition
+func (c *queueManagerConfig) getCreateArgs() []string {
+	args := []string{}
+	switch c.Log.Type {
+	case logTypeCircular:
+		args = append(args, "-lc")
+	case logTypeLinear:
+		args = append(args, "-ll")
+	}
+	if c.Log.FilePages != 0 {
+		args = append(args, "-lf", strconv.Itoa(c.Log.FilePages))
+	}
+	if c.Log.PrimaryFiles != 0 {
+		args = append(args, "-lp", strconv.Itoa(c.Log.PrimaryFiles))
+	}
+	if c.Log.SecondaryFiles != 0 {
+		args = append(args, "-ls", strconv.Itoa(c.Log.SecondaryFiles))
+	}
+	if c.DefaultTransmissionQueue != "" {
+		args = append(args, "-d", c.DefaultTransmissionQueue)
+	}
+	if c.DeadLetterQueue != "" {
+		args = append(args, "-u", c.DeadLetterQueue)
+	}
+	return args
+}
+
+// getLogStanzaValues returns the settings in the queue manager definition which can be changed in the
+// 'Log' stanza of the qm.ini after the queue manager has been created
+func (c *queueManagerConfig) getLogStanzaValues() map[string]string {
+	values := map[string]string{}
+	if c.Log.PrimaryFiles != 0 {
+		values["LogPrimaryFiles"] = strconv.Itoa(c.Log.PrimaryFiles)
+	}
+	if c.Log.SecondaryFiles != 0 {
+		values["LogSecondaryFiles"] = strconv.Itoa(c.Log.SecondaryFiles)
+	}
+	if c.Log.BufferPages != 0 {
+		values["LogBufferPages"] = strconv.Itoa(c.Log.BufferPages)
+	}
+	return values
+}
+
+// getImmutableDifferences returns a description of each setting in the queue manager definition which
+// differs from the existing qm.ini, and which cannot be altered after the queue manager has been created
+func (c *queueManagerConfig) getImmutableDifferences(qmIni *mqini.File) []string {
+	differences := []string{}
+	if c.Log.Type != "" {
+		existing, _ := qmIni.Get("Log", "LogType")
+		if !strings.EqualFold(existing, c.Log.Type) {
+			differences = append(differences, fmt.Sprintf("log.type is '%v', but LogType in the qm.ini is '%v'", c.Log.Type, existing))
+		}
+	}
+	if c.Log.FilePages != 0 {
+		existing, _ := qmIni.Get("Log", "LogFilePages")
+		if existing != strconv.Itoa(c.Log.FilePages) {
+			differences = append(differences, fmt.Sprintf("log.filePages is %v, but LogFilePages in the qm.ini is %v", c.Log.FilePages, existing))
+		}
+	}
+	return differences
+}
+
+// applyQueueManagerConfig updates the 'Log' stanza of the qm.ini with the settings in the queue manager
+// definition which can be changed after the queue manager has been created.  The qm.ini is only written if a
+// value differs, and never by the standby instance of a multi-instance queue manager.
+func applyQueueManagerConfig(name string, dataDir string, config *queueManagerConfig) error {
+	values := config.getLogStanzaValues()
+	if len(values) == 0 {
+		return nil
+	}
+	qmIni, err := readQMIni(dataDir)
+	if err != nil {
+		return err
+	}
+	changed := false
+	for _, k := range slices.Sorted(maps.Keys(values)) {
+		if qmIni.Set("Log", k, values[k]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	standby, err := isMultiInstanceStandby(name)
+	if err != nil {
+		return err
+	}
+	if standby {
+		log.Printf("Not updating the 'Log' stanza of the qm.ini from the queue manager configuration, as queue manager %v is active on another instance", name)
+		return nil
+	}
+	log.Println("Updating the 'Log' stanza of the qm.ini from the queue manager configuration")
+	// #nosec G306 - its a read by owner/s group, and pose no harm.
+	return qmIni.WriteFile(pathutils.CleanPath(dataDir, "qm.ini"), 0660)
+}
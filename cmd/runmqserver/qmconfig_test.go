@@ -0,0 +1,208 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ibm-messaging/mq-container/pkg/mqini"
+)
+
+func TestLoadQueueManagerConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+		err      string
+	}{
+		{
+			name:     "Linear",
+			content:  `{"log": {"type": "Linear", "filePages": 8192, "primaryFiles": 10, "secondaryFiles": 5, "bufferPages": 512}, "deadLetterQueue": "DEV.DEAD.LETTER.QUEUE", "defaultTransmissionQueue": "XMITQ", "port": 1415}`,
+			expected: []string{"-ll", "-lf", "8192", "-lp", "10", "-ls", "5", "-d", "XMITQ", "-u", "DEV.DEAD.LETTER.QUEUE"},
+		},
+		{
+			name:     "Empty",
+			content:  `{}`,
+			expected: []string{},
+		},
+		{
+			name:    "UnknownField",
+			content: `{"log": {"kind": "linear"}}`,
+			err:     "unknown field",
+		},
+		{
+			name:    "InvalidLogType",
+			content: `{"log": {"type": "replicated"}}`,
+			err:     "log.type",
+		},
+		{
+			name:    "InvalidValues",
+			content: `{"log": {"filePages": 10, "primaryFiles": 400, "secondaryFiles": 200, "bufferPages": 5}, "deadLetterQueue": "DEAD LETTER", "port": 70000}`,
+			err:     "log.filePages must be between 64 and 65535, not 10; the total of log.primaryFiles and log.secondaryFiles must not exceed 511, not 600; log.bufferPages must be 0, or between 18 and 4096, not 5; deadLetterQueue is not a valid queue name: 'DEAD LETTER'; port must be between 1 and 65535, not 70000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "qmgr.json")
+			err := os.WriteFile(path, []byte(tt.content), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config, err := loadQueueManagerConfig(path)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Expected error containing '%v', got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			args := config.getCreateArgs()
+			if !reflect.DeepEqual(args, tt.expected) {
+				t.Errorf("Expected crtmqm arguments %v, got %v", tt.expected, args)
+			}
+		})
+	}
+}
+
+func TestLoadQueueManagerConfigMissing(t *testing.T) {
+	config, err := loadQueueManagerConfig(filepath.Join(t.TempDir(), "qmgr.json"))
+	if err != nil || config != nil {
+		t.Errorf("Expected no configuration and no error, got %v and %v", config, err)
+	}
+}
+
+func TestLoadQueueManagerConfigYAML(t *testing.T) {
+	for _, name := range []string{"qmgr.yaml", "qmgr.yml"} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := os.WriteFile(filepath.Join(dir, name), []byte("log:\n  type: linear\n"), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = loadQueueManagerConfig(filepath.Join(dir, "qmgr.json"))
+			if err == nil || !strings.Contains(err.Error(), name) {
+				t.Errorf("Expected an error naming %v, got %v", name, err)
+			}
+		})
+	}
+}
+
+func TestLoadQueueManagerConfigLogFilePagesConflict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "qmgr.json")
+	err := os.WriteFile(path, []byte(`{"log": {"filePages": 8192}}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MQ_QMGR_LOG_FILE_PAGES", "4096")
+	_, err = loadQueueManagerConfig(path)
+	if err == nil {
+		t.Fatal("Expected an error when log.filePages conflicts with MQ_QMGR_LOG_FILE_PAGES")
+	}
+	t.Setenv("MQ_QMGR_LOG_FILE_PAGES", "8192")
+	_, err = loadQueueManagerConfig(path)
+	if err != nil {
+		t.Errorf("Unexpected error when log.filePages matches MQ_QMGR_LOG_FILE_PAGES: %v", err)
+	}
+}
+
+func TestGetImmutableDifferences(t *testing.T) {
+	qmIni, err := mqini.ReadFile("./test-files/testvalidateLogFilePages_1.ini")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &queueManagerConfig{Log: logConfig{Type: logTypeLinear, FilePages: 1235}}
+	differences := config.getImmutableDifferences(qmIni)
+	if len(differences) != 1 || !strings.Contains(differences[0], "log.type") {
+		t.Errorf("Expected a difference in log.type only, got %v", differences)
+	}
+	config = &queueManagerConfig{Log: logConfig{FilePages: 4096, PrimaryFiles: 10}}
+	differences = config.getImmutableDifferences(qmIni)
+	if len(differences) != 1 || !strings.Contains(differences[0], "log.filePages") {
+		t.Errorf("Expected a difference in log.filePages only, got %v", differences)
+	}
+}
+
+func TestApplyQueueManagerConfig(t *testing.T) {
+	ini := "ExitPath:\n   ExitsDefaultPath=/mnt/mqm/data/exits\nLog:\n   LogPrimaryFiles=3\n   LogFilePages=1235\nTuningParameters:\n   Foo=Bar\n"
+	expected := "ExitPath:\n   ExitsDefaultPath=/mnt/mqm/data/exits\nLog:\n   LogPrimaryFiles=10\n   LogFilePages=1235\n   LogBufferPages=512\n   LogSecondaryFiles=5\nTuningParameters:\n   Foo=Bar\n"
+	dataDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dataDir, "qm.ini"), []byte(ini), 0660)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &queueManagerConfig{Log: logConfig{PrimaryFiles: 10, SecondaryFiles: 5, BufferPages: 512}}
+	err = applyQueueManagerConfig("qm1", dataDir, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := os.ReadFile(filepath.Join(dataDir, "qm.ini"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(actual) != expected {
+		t.Errorf("Expected:\n%v\nGot:\n%v", expected, string(actual))
+	}
+}
+
+func TestApplyQueueManagerConfigUnchanged(t *testing.T) {
+	tests := []struct {
+		name          string
+		multiInstance string
+		elsewhere     bool
+		config        *queueManagerConfig
+	}{
+		{"SameValues", "false", false, &queueManagerConfig{Log: logConfig{PrimaryFiles: 3}}},
+		{"Standby", "true", true, &queueManagerConfig{Log: logConfig{PrimaryFiles: 10}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("MQ_MULTI_INSTANCE", test.multiInstance)
+			previous := runningElsewhere
+			runningElsewhere = func(name string) (bool, error) { return test.elsewhere, nil }
+			t.Cleanup(func() { runningElsewhere = previous })
+
+			ini := "Log:\n   LogPrimaryFiles=3\n"
+			dataDir := t.TempDir()
+			path := filepath.Join(dataDir, "qm.ini")
+			err := os.WriteFile(path, []byte(ini), 0660)
+			if err != nil {
+				t.Fatal(err)
+			}
+			before, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = applyQueueManagerConfig("qm1", dataDir, test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The qm.ini is replaced atomically when written, so any write would change the file
+			after, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !os.SameFile(before, after) {
+				t.Error("Expected qm.ini not to be written")
+			}
+		})
+	}
+}
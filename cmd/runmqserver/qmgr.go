@@ -19,7 +19,7 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -30,13 +30,14 @@ import (
 	"github.com/ibm-messaging/mq-container/internal/mqversion"
 	"github.com/ibm-messaging/mq-container/internal/pathutils"
 	"github.com/ibm-messaging/mq-container/internal/ready"
+	"github.com/ibm-messaging/mq-container/pkg/mqini"
 )
 
 // createDirStructure creates the default MQ directory structure under /var/mqm
-func createDirStructure() error {
+func createDirStructure(ctx context.Context) error {
 	// log file diagnostics before and after crtmqdir if DEBUG=true
 	logDiagnostics()
-	out, rc, err := command.Run("/opt/mqm/bin/crtmqdir", "-f", "-a")
+	out, rc, err := command.RunContext(ctx, "/opt/mqm/bin/crtmqdir", "-f", "-a")
 	if err != nil {
 		if rc == 10 {
 			// Ignore warnings about 'mqwebuser.xml' being a symlink
@@ -55,7 +56,7 @@ func createDirStructure() error {
 
 // createQueueManager creates a queue manager, if it doesn't already exist.
 // It returns true if one was created (or a standby was created), or false if one already existed
-func createQueueManager(name string, devMode bool) (bool, error) {
+func createQueueManager(ctx context.Context, name string, devMode bool, qmConfig *queueManagerConfig) (bool, error) {
 	log.Printf("Creating queue manager %v", name)
 
 	mounts, err := containerruntime.GetMounts()
@@ -68,21 +69,36 @@ func createQueueManager(name string, devMode bool) (bool, error) {
 
 	// Run 'dspmqinf' to check if 'mqs.ini' configuration file exists
 	// If command succeeds, the queue manager (or standby queue manager) has already been created
-	_, _, err = command.Run("dspmqinf", name)
+	_, _, err = command.RunContext(ctx, "dspmqinf", name)
 	if err == nil {
 		log.Printf("Detected existing queue manager %v", name)
 		// Check if MQ_QMGR_LOG_FILE_PAGES matches the value set in qm.ini
 		lfp := os.Getenv("MQ_QMGR_LOG_FILE_PAGES")
 		if lfp != "" {
-			qmIniBytes, err := readQMIni(dataDir)
+			qmIni, err := readQMIni(dataDir)
 			if err != nil {
 				log.Printf("Error reading qm.ini : %v", err)
 				return false, err
 			}
-			if !validateLogFilePageSetting(qmIniBytes, lfp) {
+			if !validateLogFilePageSetting(qmIni, lfp) {
 				log.Println("Warning: the value of MQ_QMGR_LOG_FILE_PAGES does not match the value of 'LogFilePages' in the qm.ini. This setting cannot be altered after Queue Manager creation.")
 			}
 		}
+		if qmConfig != nil {
+			qmIni, err := readQMIni(dataDir)
+			if err != nil {
+				log.Printf("Error reading qm.ini : %v", err)
+				return false, err
+			}
+			for _, difference := range qmConfig.getImmutableDifferences(qmIni) {
+				log.Printf("Warning: in %v, %v. This setting cannot be altered after Queue Manager creation.", qmConfigFile, difference)
+			}
+			err = applyQueueManagerConfig(name, dataDir, qmConfig)
+			if err != nil {
+				log.Printf("Error updating qm.ini : %v", err)
+				return false, err
+			}
+		}
 		return false, nil
 	}
 
@@ -91,16 +107,23 @@ func createQueueManager(name string, devMode bool) (bool, error) {
 	_, err = os.Stat(pathutils.CleanPath(dataDir, "qm.ini"))
 	if err != nil {
 		// If 'qm.ini' is not found - run 'crtmqm' to create a new queue manager
-		args := getCreateQueueManagerArgs(mounts, name, devMode)
-		out, rc, err := command.Run("crtmqm", args...)
+		args := getCreateQueueManagerArgs(mounts, name, devMode, qmConfig)
+		out, rc, err := command.RunContext(ctx, "crtmqm", args...)
 		if err != nil {
 			log.Printf("Error creating queue manager: the 'crtmqm' command returned with code: %v. Reason: %v", rc, string(out))
 			return false, err
 		}
+		if qmConfig != nil {
+			err = applyQueueManagerConfig(name, dataDir, qmConfig)
+			if err != nil {
+				log.Printf("Error updating qm.ini : %v", err)
+				return false, err
+			}
+		}
 	} else {
 		// If 'qm.ini' is found - run 'addmqinf' to create a standby queue manager with existing configuration
 		args := getCreateStandbyQueueManagerArgs(name)
-		out, rc, err := command.Run("addmqinf", args...)
+		out, rc, err := command.RunContext(ctx, "addmqinf", args...)
 		if err != nil {
 			log.Printf("Error creating standby queue manager: the 'addmqinf' command returned with code: %v. Reason: %v",
 				rc, string(out))
@@ -113,30 +136,23 @@ func createQueueManager(name string, devMode bool) (bool, error) {
 	return true, nil
 }
 
-// readQMIni reads the qm.ini file and returns it as a byte array
-// This function is specific to comply with the nosec.
-func readQMIni(dataDir string) ([]byte, error) {
-	qmgrDir := pathutils.CleanPath(dataDir, "qm.ini")
-	// #nosec G304 - qmgrDir filepath is derived from dspmqinf
-	iniFileBytes, err := os.ReadFile(qmgrDir)
-	if err != nil {
-		return nil, err
-	}
-	return iniFileBytes, err
+// readQMIni reads and parses the qm.ini file
+func readQMIni(dataDir string) (*mqini.File, error) {
+	// qm.ini filepath is derived from dspmqinf
+	return mqini.ReadFile(pathutils.CleanPath(dataDir, "qm.ini"))
 }
 
 // validateLogFilePageSetting validates if the specified logFilePage number is equal to the existing value in the qm.ini
-func validateLogFilePageSetting(iniFileBytes []byte, logFilePages string) bool {
-	lfpString := "LogFilePages=" + logFilePages
-	qminiConfigStr := string(iniFileBytes)
-	return strings.Contains(qminiConfigStr, lfpString)
+func validateLogFilePageSetting(qmIni *mqini.File, logFilePages string) bool {
+	lfp, ok := qmIni.Get("Log", "LogFilePages")
+	return ok && lfp == logFilePages
 }
 
-func updateCommandLevel() error {
+func updateCommandLevel(ctx context.Context) error {
 	level, ok := os.LookupEnv("MQ_CMDLEVEL")
 	if ok && level != "" {
 		log.Printf("Setting CMDLEVEL to %v", level)
-		out, rc, err := command.Run("strmqm", "-e", "CMDLEVEL="+level)
+		out, rc, err := command.RunContext(ctx, "strmqm", "-e", "CMDLEVEL="+level)
 		if err != nil {
 			log.Printf("Error setting CMDLEVEL for queue manager: the 'strmqm' command returned with code: %v. Reason: %v",
 				rc, string(out))
@@ -146,7 +162,7 @@ func updateCommandLevel() error {
 	return nil
 }
 
-func startQueueManager(name string) error {
+func startQueueManager(ctx context.Context, name string) error {
 	if os.Getenv("MQ_ENABLE_SOFT_FILE_LIMIT_INCREASE") == "true" {
 		// Set the soft limit again before starting MQ, just in case it has been altered.
 		err := setNoFileSoftLimitEqualToHardLimit()
@@ -156,7 +172,7 @@ func startQueueManager(name string) error {
 	}
 
 	log.Println("Starting queue manager")
-	out, rc, err := command.Run("strmqm", "-x", name)
+	out, rc, err := command.RunContext(ctx, "strmqm", "-x", name)
 	if err != nil {
 		// 30=standby queue manager started, which is fine
 		// 94=native HA replica started, which is fine
@@ -174,46 +190,9 @@ func startQueueManager(name string) error {
 	return nil
 }
 
-func stopQueueManager(name string) error {
-	log.Println("Stopping queue manager")
-	qmGracePeriod, err := strconv.Atoi(os.Getenv("MQ_GRACE_PERIOD"))
-	if err != nil {
-		log.Printf("Error processing MQ_GRACE_PERIOD, the default value for QM Grace Period will be used. Err: %v", err)
-		qmGracePeriod = 30
-	}
-	status, err := ready.Status(context.Background(), name)
-	if err != nil {
-		log.Printf("Error getting status for queue manager %v. The 'dspmq' command returned reason: %v",
-			name, err.Error())
-
-		return err
-	}
-	qmGracePeriodStr := strconv.Itoa(qmGracePeriod)
-	isStandby := status.StandbyQM()
-	args := []string{"-w", "-r", "-tp", qmGracePeriodStr, name}
-	if os.Getenv("MQ_MULTI_INSTANCE") == "true" {
-		if isStandby {
-			args = []string{"-x", name}
-		} else {
-			args = []string{"-s", "-w", "-tp", qmGracePeriodStr, name}
-		}
-	}
-	out, rc, err := command.Run("endmqm", args...)
-	if err != nil {
-		log.Printf("Error stopping queue manager: the 'endmqm' command returned with code: %v.  Reason: %v", rc, string(out))
-		return err
-	}
-	if isStandby {
-		log.Printf("Stopped standby queue manager")
-	} else {
-		log.Println("Stopped queue manager")
-	}
-	return nil
-}
-
-func startMQTrace() error {
+func startMQTrace(ctx context.Context) error {
 	log.Println("Starting MQ trace")
-	out, rc, err := command.Run("strmqtrc")
+	out, rc, err := command.RunContext(ctx, "strmqtrc")
 	if err != nil {
 		log.Printf("Error starting MQ trace: the 'strmqtrc' command returned with code: %v.  Reason: %v", rc, string(out))
 		return err
@@ -222,9 +201,9 @@ func startMQTrace() error {
 	return nil
 }
 
-func endMQTrace() error {
+func endMQTrace(ctx context.Context) error {
 	log.Println("Ending MQ Trace")
-	out, rc, err := command.Run("endmqtrc")
+	out, rc, err := command.RunContext(ctx, "endmqtrc")
 	if err != nil {
 		log.Printf("Error ending MQ trace: the 'endmqtrc' command returned with code: %v.  Reason: %v", rc, string(out))
 		return err
@@ -242,16 +221,14 @@ func formatMQSCOutput(out string) string {
 }
 
 func isStandbyQueueManager(name string) (bool, error) {
-	out, rc, err := command.Run("dspmq", "-n", "-m", name)
+	ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+	defer cancel()
+	status, err := ready.GetQueueManagerStatus(ctx, name)
 	if err != nil {
-		log.Printf("Error while getting status for queue manager %v: the 'dspmq' command returned with code: %v.  Reason: %v",
-			name, rc, string(out))
+		log.Printf("Error while getting status for queue manager %v: %v", name, err)
 		return false, err
 	}
-	if strings.Contains(string(out), "(RUNNING AS STANDBY)") {
-		return true, nil
-	}
-	return false, nil
+	return status.Status().StandbyQM(), nil
 }
 
 func getQueueManagerDataDir(mounts map[string]string, name string) string {
@@ -262,7 +239,7 @@ func getQueueManagerDataDir(mounts map[string]string, name string) string {
 	return dataDir
 }
 
-func getCreateQueueManagerArgs(mounts map[string]string, name string, devMode bool) []string {
+func getCreateQueueManagerArgs(mounts map[string]string, name string, devMode bool, qmConfig *queueManagerConfig) []string {
 
 	mqversionBase := "9.2.1.0"
 
@@ -278,7 +255,11 @@ func getCreateQueueManagerArgs(mounts map[string]string, name string, devMode bo
 	}
 
 	//build args
-	args := []string{"-ii", "/etc/mqm/", "-ic", "/etc/mqm/", "-q", "-p", "1414"}
+	port := "1414"
+	if qmConfig != nil && qmConfig.Port != 0 {
+		port = strconv.Itoa(qmConfig.Port)
+	}
+	args := []string{"-ii", "/etc/mqm/", "-ic", "/etc/mqm/", "-q", "-p", port}
 
 	if os.Getenv("MQ_NATIVE_HA") == "true" {
 		args = append(args, "-lr", os.Getenv("HOSTNAME"))
@@ -300,6 +281,16 @@ func getCreateQueueManagerArgs(mounts map[string]string, name string, devMode bo
 			args = append(args, "-lf", os.Getenv("MQ_QMGR_LOG_FILE_PAGES"))
 		}
 	}
+	if qmConfig != nil {
+		configArgs := qmConfig.getCreateArgs()
+		// LogFilePages may also have been set from MQ_QMGR_LOG_FILE_PAGES, in which case the values are the same
+		if slices.Contains(args, "-lf") {
+			if i := slices.Index(configArgs, "-lf"); i >= 0 {
+				configArgs = slices.Delete(configArgs, i, i+2)
+			}
+		}
+		args = append(args, configArgs...)
+	}
 	args = append(args, name)
 	return args
 }
@@ -338,23 +329,13 @@ func updateQMini(qmname string) error {
 		return err
 	}
 	dataDir := getQueueManagerDataDir(mounts, replaceCharsInQMName(qmname))
-	qmgrDir := pathutils.CleanPath(dataDir, "qm.ini")
-	//read the initial version.
-	// #nosec G304 - qmgrDir filepath is derived from dspmqinf
-	iniFileBytes, err := os.ReadFile(qmgrDir)
+	qmIni, err := readQMIni(dataDir)
 	if err != nil {
 		return err
 	}
-	qminiConfigStr := string(iniFileBytes)
-	if strings.Contains(qminiConfigStr, "ServiceComponent:") {
-		var re = regexp.MustCompile(`(?m)^.*ServiceComponent.*$\s^.*Service.*$\s^.*Name.*$\s^.*Module.*$\s^.*ComponentDataSize.*$`)
-		curFile := re.ReplaceAllString(qminiConfigStr, "")
-		// #nosec G304 G306 - qmgrDir filepath is derived from dspmqinf and
-		// its a read by owner/s group, and pose no harm.
-		err := os.WriteFile(qmgrDir, []byte(curFile), 0660)
-		if err != nil {
-			return err
-		}
+	if qmIni.RemoveStanzas("ServiceComponent", nil) > 0 {
+		// #nosec G306 - its a read by owner/s group, and pose no harm.
+		return qmIni.WriteFile(pathutils.CleanPath(dataDir, "qm.ini"), 0660)
 	}
 	return nil
 }
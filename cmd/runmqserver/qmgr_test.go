@@ -16,9 +16,11 @@ limitations under the License.
 package main
 
 import (
-	"os"	
+	"os"
 	"strings"
 	"testing"
+
+	"github.com/ibm-messaging/mq-container/pkg/mqini"
 )
 
 func Test_validateLogFilePageSetting(t *testing.T) {
@@ -78,7 +80,7 @@ func Test_validateLogFilePageSetting(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			validate := validateLogFilePageSetting(iniFileBytes, tt.args.logFilePagesValue)
+			validate := validateLogFilePageSetting(mqini.Parse(iniFileBytes), tt.args.logFilePagesValue)
 			if validate != tt.args.isValid {
 				t.Fatalf("Expected ini file validation output to be %v got %v", tt.args.isValid, validate)
 			}
@@ -0,0 +1,204 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	containerruntime "github.com/ibm-messaging/mq-container/internal/containerruntime"
+	"github.com/ibm-messaging/mq-container/internal/pathutils"
+	"github.com/ibm-messaging/mq-container/internal/ready"
+	"github.com/ibm-messaging/mq-container/pkg/mqini"
+)
+
+// qmIniOverlayDir is the directory holding INI files which are merged into the qm.ini on every start
+const qmIniOverlayDir = "/etc/mqm/qm.ini.d"
+
+// immutableQMIniAttributes lists the qm.ini attributes, by stanza, which cannot be altered after
+// the queue manager has been created
+var immutableQMIniAttributes = map[string][]string{
+	"Log": {"LogType", "LogFilePages", "LogPath"},
+}
+
+// isQMIniOverlayEnabled returns true if the qm.ini overlay has been enabled
+func isQMIniOverlayEnabled() bool {
+	enable := os.Getenv("MQ_ENABLE_QMINI_OVERLAY")
+	return enable == "true" || enable == "1"
+}
+
+// isImmutableQMIniAttribute returns true if the attribute cannot be altered after queue manager creation
+func isImmutableQMIniAttribute(stanza string, key string) bool {
+	for s, keys := range immutableQMIniAttributes {
+		if !strings.EqualFold(s, stanza) {
+			continue
+		}
+		for _, k := range keys {
+			if strings.EqualFold(k, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// describeStanza returns a description of a stanza for logging, including its name attribute if it has one
+func describeStanza(s *mqini.Stanza) string {
+	if name, ok := s.Get("Name"); ok {
+		return fmt.Sprintf("%v (Name=%v)", s.Name, name)
+	}
+	return s.Name
+}
+
+// findQMIniStanza returns the stanza in the qm.ini which an overlay stanza should be merged into, or nil.
+// Stanzas which can be repeated, such as 'ServiceComponent' or 'ApiExitLocal', are matched using their
+// 'Name' attribute; other stanzas are matched by stanza name alone.
+func findQMIniStanza(qmIni *mqini.File, overlay *mqini.Stanza) *mqini.Stanza {
+	name, hasName := overlay.Get("Name")
+	if !hasName {
+		return qmIni.Stanza(overlay.Name)
+	}
+	for _, s := range qmIni.Stanzas(overlay.Name) {
+		if existing, ok := s.Get("Name"); ok && existing == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// mergeQMIni merges the attributes in an overlay into the qm.ini, and returns a description of each change
+// made.  An error is returned if the overlay would change an attribute which cannot be altered after the
+// queue manager has been created.
+func mergeQMIni(qmIni *mqini.File, overlay *mqini.File) ([]string, error) {
+	changes := []string{}
+	for _, o := range overlay.AllStanzas() {
+		target := findQMIniStanza(qmIni, o)
+		for _, attr := range o.Attributes() {
+			var existing string
+			if target != nil {
+				existing, _ = target.Get(attr.Key)
+			}
+			if isImmutableQMIniAttribute(o.Name, attr.Key) && !strings.EqualFold(existing, attr.Value) {
+				return nil, fmt.Errorf("%v: %v cannot be altered after queue manager creation", o.Name, attr.Key)
+			}
+		}
+		if target == nil {
+			target = qmIni.AddStanza(o.Name)
+			changes = append(changes, fmt.Sprintf("added stanza %v", describeStanza(o)))
+		}
+		for _, attr := range o.Attributes() {
+			if isImmutableQMIniAttribute(o.Name, attr.Key) {
+				// Already checked to be equal to the existing value
+				continue
+			}
+			existing, found := target.Get(attr.Key)
+			if !target.Set(attr.Key, attr.Value) {
+				continue
+			}
+			if found {
+				changes = append(changes, fmt.Sprintf("%v: changed %v from '%v' to '%v'", describeStanza(o), attr.Key, existing, attr.Value))
+			} else {
+				changes = append(changes, fmt.Sprintf("%v: added %v=%v", describeStanza(o), attr.Key, attr.Value))
+			}
+		}
+	}
+	return changes, nil
+}
+
+// runningElsewhere is the function used to check whether a multi-instance queue manager is active on another
+// instance, which can be replaced in tests
+var runningElsewhere = isRunningElsewhere
+
+// isRunningElsewhere returns true if the queue manager is active on another instance of a multi-instance
+// queue manager, so that the instance in this container will start as the standby
+func isRunningElsewhere(name string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+	defer cancel()
+	status, err := ready.GetQueueManagerStatus(ctx, name)
+	if err != nil {
+		return false, fmt.Errorf("unable to determine whether queue manager %v is active on another instance: %w", name, err)
+	}
+	return status.RunningElsewhere(), nil
+}
+
+// isMultiInstanceStandby returns true if this is an instance of a multi-instance queue manager which is going
+// to start as the standby.  The qm.ini is shared by the instances, so it must be left unchanged by the standby.
+func isMultiInstanceStandby(name string) (bool, error) {
+	if os.Getenv("MQ_MULTI_INSTANCE") != "true" {
+		return false, nil
+	}
+	return runningElsewhere(name)
+}
+
+// applyQMIniOverlay merges any INI files in the overlay directory into the qm.ini of an existing queue manager.
+// For a multi-instance queue manager, the files are only merged by the instance which starts as the active
+// instance.
+func applyQMIniOverlay(name string) error {
+	if !isQMIniOverlayEnabled() {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(qmIniOverlayDir, "*.ini"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	standby, err := isMultiInstanceStandby(name)
+	if err != nil {
+		return err
+	}
+	if standby {
+		log.Printf("Not merging %v into the qm.ini, as queue manager %v is active on another instance", qmIniOverlayDir, name)
+		return nil
+	}
+	mounts, err := containerruntime.GetMounts()
+	if err != nil {
+		log.Printf("Error getting mounts for queue manager")
+		return err
+	}
+	dataDir := getQueueManagerDataDir(mounts, replaceCharsInQMName(name))
+	qmIni, err := readQMIni(dataDir)
+	if err != nil {
+		log.Printf("Error reading qm.ini : %v", err)
+		return err
+	}
+	changed := false
+	for _, file := range files {
+		overlay, err := mqini.ReadFile(file)
+		if err != nil {
+			log.Printf("Error reading %v : %v", file, err)
+			return err
+		}
+		changes, err := mergeQMIni(qmIni, overlay)
+		if err != nil {
+			return fmt.Errorf("refusing to apply %v to the qm.ini: %w", file, err)
+		}
+		for _, change := range changes {
+			log.Printf("Updating qm.ini from %v: %v", file, change)
+		}
+		changed = changed || len(changes) > 0
+	}
+	if !changed {
+		log.Debugf("No changes to qm.ini from %v", qmIniOverlayDir)
+		return nil
+	}
+	// #nosec G306 - its a read by owner/s group, and pose no harm.
+	return qmIni.WriteFile(pathutils.CleanPath(dataDir, "qm.ini"), 0660)
+}
@@ -0,0 +1,105 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ibm-messaging/mq-container/pkg/mqini"
+)
+
+const testOverlayQMIni = `Log:
+   LogPrimaryFiles=3
+   LogFilePages=4096
+   LogType=CIRCULAR
+ApiExitLocal:
+   Sequence=100
+   Function=EntryPoint
+   Module=/opt/exits/exit1
+   Name=Exit1
+Channels:
+   MaxChannels=100
+`
+
+func TestMergeQMIni(t *testing.T) {
+	tests := []struct {
+		name     string
+		overlay  string
+		changes  []string
+		expected string
+	}{
+		{
+			name:     "NoChange",
+			overlay:  "Log:\n   LogType=circular\n   LogFilePages=4096\nChannels:\n   MaxChannels=100\n",
+			changes:  []string{},
+			expected: testOverlayQMIni,
+		},
+		{
+			name:    "Tuning",
+			overlay: "Channels:\n   MaxChannels=200\n   MaxActiveChannels=150\nTuningParameters:\n   DefaultQBufferSize=1048576\nApiExitLocal:\n   Name=Exit1\n   Sequence=50\n",
+			changes: []string{
+				"Channels: changed MaxChannels from '100' to '200'",
+				"Channels: added MaxActiveChannels=150",
+				"added stanza TuningParameters",
+				"TuningParameters: added DefaultQBufferSize=1048576",
+				"ApiExitLocal (Name=Exit1): changed Sequence from '100' to '50'",
+			},
+			expected: "Log:\n   LogPrimaryFiles=3\n   LogFilePages=4096\n   LogType=CIRCULAR\nApiExitLocal:\n   Sequence=50\n   Function=EntryPoint\n   Module=/opt/exits/exit1\n   Name=Exit1\nChannels:\n   MaxChannels=200\n   MaxActiveChannels=150\nTuningParameters:\n   DefaultQBufferSize=1048576\n",
+		},
+		{
+			name:    "RepeatedStanza",
+			overlay: "ApiExitLocal:\n   Name=Exit2\n   Module=/opt/exits/exit2\n",
+			changes: []string{
+				"added stanza ApiExitLocal (Name=Exit2)",
+				"ApiExitLocal (Name=Exit2): added Name=Exit2",
+				"ApiExitLocal (Name=Exit2): added Module=/opt/exits/exit2",
+			},
+			expected: testOverlayQMIni + "ApiExitLocal:\n   Name=Exit2\n   Module=/opt/exits/exit2\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qmIni := mqini.Parse([]byte(testOverlayQMIni))
+			changes, err := mergeQMIni(qmIni, mqini.Parse([]byte(tt.overlay)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(changes, tt.changes) {
+				t.Errorf("Expected changes %q, got %q", tt.changes, changes)
+			}
+			actual := string(qmIni.Bytes())
+			if actual != tt.expected {
+				t.Errorf("Expected:\n%v\nGot:\n%v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMergeQMIniImmutable(t *testing.T) {
+	overlays := []string{
+		"Log:\n   LogFilePages=8192\n",
+		"log:\n   logtype=LINEAR\n",
+		"Log:\n   LogPath=/mnt/other/\n",
+	}
+	for _, overlay := range overlays {
+		qmIni := mqini.Parse([]byte(testOverlayQMIni))
+		_, err := mergeQMIni(qmIni, mqini.Parse([]byte(overlay)))
+		if err == nil {
+			t.Errorf("Expected an error merging %q", overlay)
+		}
+	}
+}
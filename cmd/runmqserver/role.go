@@ -0,0 +1,156 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/command"
+	"github.com/ibm-messaging/mq-container/internal/ready"
+	"github.com/ibm-messaging/mq-container/internal/role"
+)
+
+// rolePollInterval is the time between checks of the role of the queue manager in this container
+const rolePollInterval = 5 * time.Second
+
+// runtimeMQSCFiles matches the MQSC files which are applied by the queue manager each time it is started
+const runtimeMQSCFiles = "/etc/mqm/*.mqsc"
+
+// newRoleWatcher creates a watcher for the role of the queue manager in this container.  Once a stop has
+// been requested, the role is reported as unknown, so that nothing is started or stopped while the queue
+// manager ends.
+func newRoleWatcher(name string) *role.Watcher {
+	status := func(ctx context.Context) (role.Role, error) {
+		if stopRequested.Load() {
+			return role.Unknown, nil
+		}
+		ctx, cancel := context.WithTimeout(ctx, statusTimeout)
+		defer cancel()
+		status, err := ready.Status(ctx, name)
+		if err != nil {
+			return role.Unknown, err
+		}
+		return role.FromStatus(status), nil
+	}
+	return role.NewWatcher(status, rolePollInterval, log)
+}
+
+// eventMirroringSubsystem mirrors the messages on the queue manager's event queues while it is active
+func eventMirroringSubsystem(wg *sync.WaitGroup, name string, mf mirrorFunc) role.Subsystem {
+	var cancel context.CancelFunc
+	return role.Subsystem{
+		Name: "event queue mirroring",
+		Start: func(ctx context.Context) error {
+			var eventCtx context.Context
+			eventCtx, cancel = context.WithCancel(ctx)
+			mirrorEventQueues(eventCtx, wg, name, mf)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			cancel()
+			return nil
+		},
+	}
+}
+
+// tlsRefreshSubsystem applies any TLS configuration which was reloaded while the queue manager wasn't active
+func tlsRefreshSubsystem(name string) role.Subsystem {
+	return role.Subsystem{
+		Name: "pending TLS configuration",
+		Start: func(ctx context.Context) error {
+			if !tlsRefreshPending.Swap(false) {
+				return nil
+			}
+			err := refreshQueueManagerTLS(ctx, name)
+			if err != nil {
+				tlsRefreshPending.Store(true)
+			}
+			return err
+		},
+	}
+}
+
+// runtimeMQSCSubsystem applies the runtime MQSC files when a standby or replica instance takes over as the
+// active instance.  The files are applied by the queue manager itself when it is started, so they aren't
+// applied again when the instance in this container was started as the active instance.
+func runtimeMQSCSubsystem(watcher *role.Watcher, name string) role.Subsystem {
+	var pending atomic.Bool
+	watcher.OnTransition(func(t role.Transition) {
+		if t.Activated() && (t.From == role.Standby || t.From == role.Replica) {
+			pending.Store(true)
+		}
+	})
+	return role.Subsystem{
+		Name: "runtime MQSC",
+		Start: func(ctx context.Context) error {
+			if !pending.Load() {
+				return nil
+			}
+			err := applyRuntimeMQSC(ctx, name)
+			if err != nil {
+				return err
+			}
+			pending.Store(false)
+			return nil
+		},
+	}
+}
+
+// applyRuntimeMQSC runs each of the runtime MQSC files against the queue manager, in lexical order
+func applyRuntimeMQSC(ctx context.Context, name string) error {
+	files, err := filepath.Glob(runtimeMQSCFiles)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		// #nosec G304 - the files are in a fixed directory
+		mqsc, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		out, rc, err := command.RunWithInput(ctx, string(mqsc), "runmqsc", name)
+		// A return code of 10 means that some commands failed, which is reported but doesn't stop the other files
+		// from being applied, in the same way as when the queue manager is started
+		if err != nil && rc != 10 {
+			return fmt.Errorf("unable to apply %v: the 'runmqsc' command returned with code: %v. Reason: %v", file, rc, formatMQSCOutput(out))
+		}
+		if err != nil {
+			log.Printf("Warning: some commands in %v failed:\n\t%v", file, formatMQSCOutput(out))
+			continue
+		}
+		log.Printf("Applied %v", file)
+	}
+	return nil
+}
+
+// webServerSubsystem runs the web server while the queue manager is active.  The web server is started in
+// the background, as it can take some time to start.
+func webServerSubsystem() role.Subsystem {
+	return role.Subsystem{
+		Name: "web server",
+		Start: func(ctx context.Context) error {
+			return startWebServer()
+		},
+		Stop:       stopWebServer,
+		Background: true,
+	}
+}
@@ -0,0 +1,196 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/command"
+	"github.com/ibm-messaging/mq-container/internal/envvars"
+	"github.com/ibm-messaging/mq-container/internal/ready"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// defaultGracePeriod is the number of seconds used if MQ_GRACE_PERIOD isn't set
+	defaultGracePeriod = 30
+	// statusTimeout is the time allowed for dspmq to report the status of the queue manager between stages
+	statusTimeout = 5 * time.Second
+	// mqProgramDir is the directory containing the queue manager programs ended by the forced cleanup
+	mqProgramDir = "/opt/mqm/bin/"
+)
+
+// shutdownStage is one of the endmqm commands run when stopping the queue manager
+type shutdownStage struct {
+	name    string
+	args    []string
+	timeout time.Duration
+}
+
+// getGracePeriod returns the time allowed for the queue manager to end, from MQ_GRACE_PERIOD
+func getGracePeriod() time.Duration {
+	value := os.Getenv("MQ_GRACE_PERIOD")
+	if value == "" {
+		return defaultGracePeriod * time.Second
+	}
+	qmGracePeriod, err := envvars.ParsePositiveInteger(value)
+	if err != nil {
+		log.Printf("Error processing MQ_GRACE_PERIOD, the default value for QM Grace Period will be used. Err: %v", err)
+		qmGracePeriod = defaultGracePeriod
+	}
+	return time.Duration(qmGracePeriod) * time.Second
+}
+
+// getStageTimeout returns the timeout set in the named environment variable, or the given percentage
+// of the grace period if it isn't set
+func getStageTimeout(envVar string, grace time.Duration, percent int64) time.Duration {
+	value := os.Getenv(envVar)
+	if value != "" {
+		seconds, err := envvars.ParsePositiveInteger(value)
+		if err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+		log.Printf("Error processing %v, a timeout derived from MQ_GRACE_PERIOD will be used. Invalid value: '%v'", envVar, value)
+	}
+	timeout := grace * time.Duration(percent) / 100
+	if timeout < time.Second {
+		timeout = time.Second
+	}
+	return timeout
+}
+
+// getShutdownStages returns the endmqm commands to run, in order, to stop the queue manager.  A controlled
+// shutdown is escalated to an immediate and then a preemptive shutdown, if the queue manager hasn't ended
+// before the timeout for each stage.  By default, these take half, a quarter and 15% of the grace period, to
+// leave time for a forced cleanup of any remaining processes before the container is killed.  The controlled
+// shutdown also passes the grace period to endmqm using -tp, so that the queue manager escalates the shutdown
+// itself if runmqserver ends before the later stages are run.
+func getShutdownStages(name string, grace time.Duration, standby bool) []shutdownStage {
+	quiesce := getStageTimeout("MQ_SHUTDOWN_QUIESCE_TIMEOUT", grace, 50)
+	immediate := getStageTimeout("MQ_SHUTDOWN_IMMEDIATE_TIMEOUT", grace, 25)
+	preemptive := getStageTimeout("MQ_SHUTDOWN_PREEMPTIVE_TIMEOUT", grace, 15)
+	if total := quiesce + immediate + preemptive; total > grace {
+		log.Printf("Warning: the shutdown stages can take %v, which is longer than the grace period of %v", total, grace)
+	}
+	gracePeriodSeconds := strconv.Itoa(int(grace / time.Second))
+	if os.Getenv("MQ_MULTI_INSTANCE") == "true" {
+		if standby {
+			return []shutdownStage{{"standby", []string{"-x", name}, quiesce}}
+		}
+		// Switch over to the standby instance, unless the queue manager has to be ended preemptively
+		return []shutdownStage{
+			{"controlled", []string{"-s", "-w", "-tp", gracePeriodSeconds, name}, quiesce},
+			{"immediate", []string{"-s", "-i", "-w", name}, immediate},
+			{"preemptive", []string{"-p", name}, preemptive},
+		}
+	}
+	return []shutdownStage{
+		{"controlled", []string{"-w", "-r", "-tp", gracePeriodSeconds, name}, quiesce},
+		{"immediate", []string{"-i", "-w", "-r", name}, immediate},
+		{"preemptive", []string{"-p", name}, preemptive},
+	}
+}
+
+// isQueueManagerEnded returns true if the queue manager is no longer running in this container
+func isQueueManagerEnded(name string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+	defer cancel()
+	status, err := ready.GetQueueManagerStatus(ctx, name)
+	if err != nil {
+		log.Debugf("Unable to get status for queue manager %v: %v", name, err)
+		return false
+	}
+	return !status.Running()
+}
+
+// runShutdownStage runs the endmqm command for one stage, and returns true if the queue manager has ended
+func runShutdownStage(name string, stage shutdownStage) bool {
+	log.Printf("Ending queue manager with %v shutdown, waiting up to %v", stage.name, stage.timeout)
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), stage.timeout)
+	defer cancel()
+	out, rc, err := command.RunContext(ctx, "endmqm", stage.args...)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		log.Printf("The %v shutdown did not complete within %v", stage.name, stage.timeout)
+	case err != nil:
+		log.Printf("Error during %v shutdown: the 'endmqm' command returned with code: %v after %v.  Reason: %v", stage.name, rc, elapsed, out)
+	default:
+		log.Printf("The %v shutdown completed in %v", stage.name, elapsed)
+	}
+	return isQueueManagerEnded(name)
+}
+
+// stopQueueManager stops the queue manager, escalating the shutdown through each stage in turn, and
+// finally killing any remaining queue manager processes
+func stopQueueManager(name string) error {
+	log.Println("Stopping queue manager")
+	start := time.Now()
+	grace := getGracePeriod()
+	ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+	defer cancel()
+	status, err := ready.GetQueueManagerStatus(ctx, name)
+	if err != nil {
+		log.Printf("Error getting status for queue manager %v. The 'dspmq' command returned reason: %v",
+			name, err.Error())
+
+		return err
+	}
+	isStandby := status.Status().StandbyQM()
+	for _, stage := range getShutdownStages(name, grace, isStandby) {
+		if runShutdownStage(name, stage) {
+			if isStandby {
+				log.Printf("Stopped standby queue manager in %v", time.Since(start).Round(time.Millisecond))
+			} else {
+				log.Printf("Stopped queue manager in %v", time.Since(start).Round(time.Millisecond))
+			}
+			return nil
+		}
+	}
+	killed := killMQProcesses("/proc")
+	if killed == 0 {
+		err := fmt.Errorf("queue manager %v did not end within %v, and no queue manager processes were found", name, time.Since(start).Round(time.Millisecond))
+		log.Printf("Error stopping queue manager: %v", err)
+		return err
+	}
+	log.Printf("Forced the queue manager to stop after %v, by killing %v remaining processes", time.Since(start).Round(time.Millisecond), killed)
+	return nil
+}
+
+// killMQProcesses sends SIGKILL to any processes running queue manager programs, and returns the number of processes killed
+func killMQProcesses(procDir string) int {
+	pids, err := findProcesses(procDir, mqProgramDir)
+	if err != nil {
+		log.Printf("Error finding queue manager processes: %v", err)
+		return 0
+	}
+	killed := 0
+	for pid, exe := range pids {
+		err := unix.Kill(pid, unix.SIGKILL)
+		if err != nil {
+			log.Printf("Error killing process %v (%v): %v", pid, exe, err)
+			continue
+		}
+		log.Printf("Killed process %v (%v)", pid, exe)
+		killed++
+	}
+	return killed
+}
@@ -0,0 +1,142 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGetShutdownStages(t *testing.T) {
+	tests := []struct {
+		name          string
+		grace         time.Duration
+		quiesce       string
+		multiInstance string
+		standby       bool
+		expected      []shutdownStage
+	}{
+		{"Default", 30 * time.Second, "", "", false, []shutdownStage{
+			{"controlled", []string{"-w", "-r", "-tp", "30", "QM1"}, 15 * time.Second},
+			{"immediate", []string{"-i", "-w", "-r", "QM1"}, 7500 * time.Millisecond},
+			{"preemptive", []string{"-p", "QM1"}, 4500 * time.Millisecond},
+		}},
+		{"ShortGracePeriod", 2 * time.Second, "", "", false, []shutdownStage{
+			{"controlled", []string{"-w", "-r", "-tp", "2", "QM1"}, time.Second},
+			{"immediate", []string{"-i", "-w", "-r", "QM1"}, time.Second},
+			{"preemptive", []string{"-p", "QM1"}, time.Second},
+		}},
+		{"QuiesceTimeout", 30 * time.Second, "20", "", false, []shutdownStage{
+			{"controlled", []string{"-w", "-r", "-tp", "30", "QM1"}, 20 * time.Second},
+			{"immediate", []string{"-i", "-w", "-r", "QM1"}, 7500 * time.Millisecond},
+			{"preemptive", []string{"-p", "QM1"}, 4500 * time.Millisecond},
+		}},
+		{"InvalidQuiesceTimeout", 30 * time.Second, "-1", "", false, []shutdownStage{
+			{"controlled", []string{"-w", "-r", "-tp", "30", "QM1"}, 15 * time.Second},
+			{"immediate", []string{"-i", "-w", "-r", "QM1"}, 7500 * time.Millisecond},
+			{"preemptive", []string{"-p", "QM1"}, 4500 * time.Millisecond},
+		}},
+		{"MultiInstanceActive", 30 * time.Second, "", "true", false, []shutdownStage{
+			{"controlled", []string{"-s", "-w", "-tp", "30", "QM1"}, 15 * time.Second},
+			{"immediate", []string{"-s", "-i", "-w", "QM1"}, 7500 * time.Millisecond},
+			{"preemptive", []string{"-p", "QM1"}, 4500 * time.Millisecond},
+		}},
+		{"MultiInstanceStandby", 30 * time.Second, "", "true", true, []shutdownStage{
+			{"standby", []string{"-x", "QM1"}, 15 * time.Second},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("MQ_SHUTDOWN_QUIESCE_TIMEOUT", test.quiesce)
+			t.Setenv("MQ_SHUTDOWN_IMMEDIATE_TIMEOUT", "")
+			t.Setenv("MQ_SHUTDOWN_PREEMPTIVE_TIMEOUT", "")
+			t.Setenv("MQ_MULTI_INSTANCE", test.multiInstance)
+			stages := getShutdownStages("QM1", test.grace, test.standby)
+			if len(stages) != len(test.expected) {
+				t.Fatalf("Expected %v stages; got %+v", len(test.expected), stages)
+			}
+			for i, stage := range stages {
+				e := test.expected[i]
+				if stage.name != e.name || !slices.Equal(stage.args, e.args) || stage.timeout != e.timeout {
+					t.Errorf("Expected stage %v to be %+v; got %+v", i, e, stage)
+				}
+			}
+		})
+	}
+}
+
+func TestFindProcesses(t *testing.T) {
+	procDir := t.TempDir()
+	processes := map[string]string{
+		"100":                     "/opt/mqm/bin/amqzxma0",
+		"101":                     "/opt/mqm/bin/runmqlsr",
+		"102":                     "/usr/bin/bash",
+		strconv.Itoa(os.Getpid()): "/opt/mqm/bin/runmqserver",
+		"self":                    "/opt/mqm/bin/amqzxma0",
+	}
+	for pid, exe := range processes {
+		err := os.Mkdir(filepath.Join(procDir, pid), 0700)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.Symlink(exe, filepath.Join(procDir, pid, "exe"))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A process without a readable executable
+	err := os.Mkdir(filepath.Join(procDir, "103"), 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pids, err := findProcesses(procDir, mqProgramDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[int]string{100: "/opt/mqm/bin/amqzxma0", 101: "/opt/mqm/bin/runmqlsr"}
+	if len(pids) != len(expected) {
+		t.Fatalf("Expected %v; got %v", expected, pids)
+	}
+	for pid, exe := range expected {
+		if pids[pid] != exe {
+			t.Errorf("Expected process %v to be %v; got %v", pid, exe, pids[pid])
+		}
+	}
+}
+
+func TestGetGracePeriod(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"45", 45 * time.Second},
+		{"0", defaultGracePeriod * time.Second},
+		{"-5", defaultGracePeriod * time.Second},
+		{"abc", defaultGracePeriod * time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			t.Setenv("MQ_GRACE_PERIOD", test.value)
+			if grace := getGracePeriod(); grace != test.expected {
+				t.Errorf("Expected %v; got %v", test.expected, grace)
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2017, 2024
+© Copyright IBM Corporation 2017, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -21,6 +21,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/ibm-messaging/mq-container/internal/hooks"
 	"github.com/ibm-messaging/mq-container/internal/metrics"
 	"golang.org/x/sys/unix"
 )
@@ -66,6 +67,7 @@ func signalHandler(qmgr string, startupCtx context.Context) chan int {
 				log.Printf("Signal received: %v", sig)
 				signal.Stop(stopSignals)
 				stopTriggered = true
+				stopRequested.Store(true)
 
 				// If a stop signal is received during the startup process continue processing control signals until the main thread marks startup as complete
 				// Don't close the control channel until the main thread has been allowed to finish spawning processes and marks startup as complete
@@ -83,8 +85,10 @@ func signalHandler(qmgr string, startupCtx context.Context) chan int {
 				}
 				metrics.StopMetricsGathering(log)
 
-				// Shutdown queue manager in separate goroutine to allow reaping to continue in parallel
+				// Drain and shutdown queue manager in separate goroutine to allow reaping to continue in parallel
 				go func() {
+					_ = runHooks(qmgr, hooks.PreStop)
+					drainQueueManager(qmgr)
 					_ = stopQueueManager(qmgr)
 					shutdownComplete()
 				}()
@@ -0,0 +1,154 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/envvars"
+	"github.com/ibm-messaging/mq-container/internal/ready"
+)
+
+// endedPolicy is the action taken when the queue manager ends without runmqserver having stopped it
+type endedPolicy string
+
+const (
+	endedPolicyNone    endedPolicy = "none"
+	endedPolicyExit    endedPolicy = "exit"
+	endedPolicyRestart endedPolicy = "restart"
+)
+
+const (
+	supervisorPollInterval = 5 * time.Second
+	restartInitialBackoff  = 5 * time.Second
+	restartMaxBackoff      = 60 * time.Second
+	// restartResetPeriod is how long a restarted queue manager must stay running before the restart count is reset
+	restartResetPeriod  = 10 * time.Minute
+	defaultRestartLimit = 3
+)
+
+// stopRequested is set once runmqserver has started to stop the queue manager, so that the
+// supervisor does not treat the end of the queue manager as unexpected
+var stopRequested atomic.Bool
+
+// getEndedPolicy returns the policy set in MQ_QMGR_ENDED_POLICY, defaulting to 'none'
+func getEndedPolicy() (endedPolicy, error) {
+	policy := endedPolicy(strings.ToLower(strings.TrimSpace(os.Getenv("MQ_QMGR_ENDED_POLICY"))))
+	switch policy {
+	case "":
+		return endedPolicyNone, nil
+	case endedPolicyNone, endedPolicyExit, endedPolicyRestart:
+		return policy, nil
+	}
+	return "", fmt.Errorf("Invalid value for MQ_QMGR_ENDED_POLICY: '%v'. Allowed values are 'none', 'exit' and 'restart'", policy)
+}
+
+// getRestartLimit returns the maximum number of consecutive restarts set in MQ_QMGR_RESTART_LIMIT.
+// A value of zero means there is no limit.
+func getRestartLimit() (int, error) {
+	limit := strings.TrimSpace(os.Getenv("MQ_QMGR_RESTART_LIMIT"))
+	if limit == "" {
+		return defaultRestartLimit, nil
+	}
+	n, err := envvars.ParseInteger(limit)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid value for MQ_QMGR_RESTART_LIMIT: '%v'. The value must be a non-negative integer", limit)
+	}
+	return n, nil
+}
+
+// restartBackoff returns the time to wait before making the specified restart attempt, starting at 1
+func restartBackoff(attempt int) time.Duration {
+	backoff := restartInitialBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= restartMaxBackoff {
+			return restartMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// superviseQueueManager periodically checks the status of the queue manager, and applies the
+// supplied policy if the queue manager ends without a stop having been requested.  An error is
+// sent on the returned channel if runmqserver should exit as a result.
+func superviseQueueManager(name string, policy endedPolicy, restartLimit int) chan error {
+	errorChannel := make(chan error, 1)
+	go func() {
+		ticker := time.NewTicker(supervisorPollInterval)
+		defer ticker.Stop()
+		ended := false
+		restarts := 0
+		var lastRestart time.Time
+		for range ticker.C {
+			if stopRequested.Load() {
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+			status, err := ready.Status(ctx, name)
+			cancel()
+			if err != nil {
+				log.Debugf("Unable to determine status of queue manager %v: %v", name, err)
+				continue
+			}
+			if !status.EndedQM() {
+				ended = false
+				if restarts > 0 && time.Since(lastRestart) > restartResetPeriod {
+					log.Debugf("Queue manager %v has been running for %v since it was restarted, resetting restart count", name, restartResetPeriod)
+					restarts = 0
+				}
+				continue
+			}
+			// The queue manager may have ended because a stop was requested since the status was checked
+			if stopRequested.Load() {
+				return
+			}
+			switch policy {
+			case endedPolicyExit:
+				errorChannel <- fmt.Errorf("Queue manager %v ended unexpectedly", name)
+				return
+			case endedPolicyRestart:
+				restarts++
+				if restartLimit > 0 && restarts > restartLimit {
+					errorChannel <- fmt.Errorf("Queue manager %v ended unexpectedly, and was not restarted because the restart limit of %v has been reached", name, restartLimit)
+					return
+				}
+				backoff := restartBackoff(restarts)
+				log.Printf("Queue manager %v ended unexpectedly. Restarting in %v (attempt %v)", name, backoff, restarts)
+				time.Sleep(backoff)
+				if stopRequested.Load() {
+					return
+				}
+				err = startQueueManager(context.Background(), name)
+				if err != nil {
+					log.Printf("Error restarting queue manager %v: %v", name, err)
+				}
+				lastRestart = time.Now()
+			default:
+				if !ended {
+					log.Printf("Queue manager %v ended unexpectedly", name)
+				}
+			}
+			ended = true
+		}
+	}()
+	return errorChannel
+}
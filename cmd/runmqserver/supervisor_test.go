@@ -0,0 +1,94 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetEndedPolicy(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected endedPolicy
+		err      bool
+	}{
+		{"", endedPolicyNone, false},
+		{"none", endedPolicyNone, false},
+		{"exit", endedPolicyExit, false},
+		{"RESTART", endedPolicyRestart, false},
+		{" restart ", endedPolicyRestart, false},
+		{"reboot", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("MQ_QMGR_ENDED_POLICY", tt.value)
+			policy, err := getEndedPolicy()
+			if (err != nil) != tt.err {
+				t.Fatalf("Expected error=%v, got %v", tt.err, err)
+			}
+			if policy != tt.expected {
+				t.Errorf("Expected policy %v, got %v", tt.expected, policy)
+			}
+		})
+	}
+}
+
+func TestGetRestartLimit(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int
+		err      bool
+	}{
+		{"", defaultRestartLimit, false},
+		{"0", 0, false},
+		{"10", 10, false},
+		{"-1", 0, true},
+		{"ten", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("MQ_QMGR_RESTART_LIMIT", tt.value)
+			limit, err := getRestartLimit()
+			if (err != nil) != tt.err {
+				t.Fatalf("Expected error=%v, got %v", tt.err, err)
+			}
+			if limit != tt.expected {
+				t.Errorf("Expected limit %v, got %v", tt.expected, limit)
+			}
+		})
+	}
+}
+
+func TestRestartBackoff(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{1, 5 * time.Second},
+		{2, 10 * time.Second},
+		{3, 20 * time.Second},
+		{4, 40 * time.Second},
+		{5, 60 * time.Second},
+		{20, 60 * time.Second},
+	}
+	for _, tt := range tests {
+		backoff := restartBackoff(tt.attempt)
+		if backoff != tt.expected {
+			t.Errorf("Expected backoff of %v for attempt %v, got %v", tt.expected, tt.attempt, backoff)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+
+	"github.com/ibm-messaging/mq-container/internal/command"
+	"github.com/ibm-messaging/mq-container/internal/ready"
+	"github.com/ibm-messaging/mq-container/internal/tls"
+)
+
+// tlsMQSCFile is the MQSC file which configures TLS for the queue manager, generated by tls.ConfigureTLS
+const tlsMQSCFile = "/run/15-tls.mqsc"
+
+// tlsRefreshPending is set when TLS configuration is reloaded while the queue manager isn't active, so that
+// it can be applied when the queue manager becomes active
+var tlsRefreshPending atomic.Bool
+
+// isTLSReloadEnabled returns true if the queue manager's TLS keys and certificates should be reloaded when they change
+func isTLSReloadEnabled() bool {
+	enableTLSReload := os.Getenv("MQ_ENABLE_TLS_RELOAD")
+	return enableTLSReload == "true" || enableTLSReload == "1"
+}
+
+// startTLSReload watches the keys and certificates supplied in /etc/mqm/pki, and when they change,
+// rebuilds the queue manager's keystores and refreshes the queue manager's TLS configuration.
+// The password of the existing keystores is reused, so that the web server configuration remains valid.
+func startTLSReload(ctx context.Context, name string, keyLabel string, cmsKeystore tls.KeyStoreData, devMode bool) error {
+	currentLabel := keyLabel
+	password := cmsKeystore.Password
+	return tls.WatchDefaultTLSDirectories(ctx, func() {
+		log.Println("Change detected in TLS keys or certificates, reloading keystores")
+		newLabel, newKeystore, _, err := tls.ReloadDefaultTLSKeystores(ctx, password, log)
+		if err != nil {
+			log.Printf("Error reloading TLS keystores, the existing keystores remain in use: %v", err)
+			return
+		}
+		err = tls.ConfigureTLS(ctx, newLabel, newKeystore, devMode, log)
+		if err != nil {
+			log.Printf("Error configuring TLS for the queue manager: %v", err)
+			return
+		}
+		if newLabel != currentLabel {
+			log.Printf("Queue manager certificate label changed from '%v' to '%v'", currentLabel, newLabel)
+			currentLabel = newLabel
+		}
+		_ = refreshQueueManagerTLS(ctx, name)
+	}, log)
+}
+
+// refreshQueueManagerTLS applies the generated TLS configuration to a running queue manager, which sets
+// the certificate label and refreshes the cached copy of the keystore.  If the queue manager is not active,
+// the configuration is applied when it next becomes active.
+func refreshQueueManagerTLS(ctx context.Context, name string) error {
+	status, err := ready.Status(ctx, name)
+	if err != nil {
+		log.Printf("Error getting status for queue manager %v: %v", name, err)
+		return err
+	}
+	if !status.ActiveQM() {
+		log.Println("Queue manager is not active, TLS configuration will be applied when it next becomes active")
+		tlsRefreshPending.Store(true)
+		return nil
+	}
+	// #nosec G304 - tlsMQSCFile is a defined constant
+	mqsc, err := os.ReadFile(tlsMQSCFile)
+	if err != nil {
+		log.Printf("Error reading %v: %v", tlsMQSCFile, err)
+		return err
+	}
+	out, rc, err := command.RunWithInput(ctx, string(mqsc), "runmqsc", name)
+	if err != nil {
+		log.Printf("Error refreshing TLS configuration: the 'runmqsc' command returned with code: %v. Reason: %v", rc, formatMQSCOutput(out))
+		return err
+	}
+	log.Println("Refreshed queue manager TLS configuration")
+	return nil
+}
@@ -0,0 +1,198 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ibm-messaging/mq-container/internal/command"
+	"github.com/ibm-messaging/mq-container/internal/containerruntime"
+	"github.com/ibm-messaging/mq-container/internal/drain"
+	"github.com/ibm-messaging/mq-container/internal/envvars"
+	"github.com/ibm-messaging/mq-container/internal/fips"
+	"github.com/ibm-messaging/mq-container/internal/ha"
+	"github.com/ibm-messaging/mq-container/internal/otlp"
+	"github.com/ibm-messaging/mq-container/internal/syslog"
+	"github.com/ibm-messaging/mq-container/internal/tls"
+	"github.com/ibm-messaging/mq-container/pkg/mqini"
+	"golang.org/x/sys/unix"
+)
+
+// logEnvironmentProblems logs any invalid or unknown environment variables as warnings.  Deprecated
+// variables are not included, as they are reported when they are used.
+func logEnvironmentProblems() {
+	for _, p := range envvars.Validate(os.Environ()) {
+		if v, ok := envvars.Lookup(p.Name); ok && p.Warning && v.Deprecated != "" {
+			continue
+		}
+		log.Printf("Warning: environment variable %v", p)
+	}
+}
+
+// checkMounts returns a description of each problem with the volumes mounted into the container
+func checkMounts(mounts map[string]string) []string {
+	problems := []string{}
+	for mountPoint, fsType := range mounts {
+		if !containerruntime.SupportedFilesystem(fsType) {
+			problems = append(problems, fmt.Sprintf("%v uses unsupported filesystem type: %v", mountPoint, fsType))
+		}
+		if unix.Access(mountPoint, unix.W_OK) != nil {
+			problems = append(problems, fmt.Sprintf("%v is not writable", mountPoint))
+		}
+	}
+	if os.Getenv("MQ_MULTI_INSTANCE") == "true" {
+		if os.Getenv("MQ_NATIVE_HA") == "true" {
+			problems = append(problems, "MQ_MULTI_INSTANCE and MQ_NATIVE_HA cannot both be enabled")
+		}
+		for _, mountPoint := range []string{"/mnt/mqm", "/mnt/mqm-log", "/mnt/mqm-data"} {
+			fsType, ok := mounts[mountPoint]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("Missing required mount '%v' for a multi-instance queue manager", mountPoint))
+			} else if !containerruntime.ValidMultiInstanceFilesystem(fsType) {
+				problems = append(problems, fmt.Sprintf("%v uses filesystem type '%v' which is invalid for a multi-instance queue manager", mountPoint, fsType))
+			}
+		}
+	}
+	return problems
+}
+
+// checkConfigFiles returns a description of each problem with the configuration files in /etc/mqm
+func checkConfigFiles() []string {
+	problems := []string{}
+	_, err := loadQueueManagerConfig(qmConfigFile)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	overlays, _ := filepath.Glob(filepath.Join(qmIniOverlayDir, "*.ini"))
+	for _, file := range overlays {
+		overlay, err := mqini.ReadFile(file)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if len(overlay.AllStanzas()) == 0 {
+			problems = append(problems, fmt.Sprintf("%v does not contain any stanzas", file))
+		}
+	}
+	mqscFiles, _ := filepath.Glob("/etc/mqm/*.mqsc")
+	for _, file := range mqscFiles {
+		// #nosec G304 - the files are in a fixed directory
+		f, err := os.Open(file)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		f.Close()
+	}
+	return problems
+}
+
+// runValidate checks the environment, mounts and configuration files, logs any problems found,
+// and returns an error if any of them would prevent the queue manager from starting
+func runValidate(name string, nameErr error) error {
+	errs := []string{}
+	if nameErr != nil {
+		errs = append(errs, nameErr.Error())
+	}
+	for _, p := range envvars.Validate(os.Environ()) {
+		if p.Warning {
+			log.Printf("Warning: environment variable %v", p)
+		} else {
+			errs = append(errs, "environment variable "+p.String())
+		}
+	}
+	if address := os.Getenv("MQ_LOGGING_SYSLOG_ADDRESS"); address != "" {
+		if _, _, err := syslog.ParseAddress(address); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if endpoint := os.Getenv("MQ_OTLP_ENDPOINT"); endpoint != "" {
+		if _, err := otlp.ParseEndpoint(endpoint); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if isProbeServerEnabled() {
+		if _, err := getProbePort(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if _, err := drain.GetTimeout(); err != nil {
+		errs = append(errs, err.Error())
+	}
+	errs = append(errs, getHookConfigProblems()...)
+	if _, err := command.GetTimeouts(); err != nil {
+		errs = append(errs, strings.Split(err.Error(), "\n")...)
+	}
+	mounts, err := containerruntime.GetMounts()
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	errs = append(errs, checkMounts(mounts)...)
+	// Failed preflight checks are only errors if they would prevent the queue manager from starting
+	mode, err := getPreflightMode()
+	if err != nil {
+		errs = append(errs, err.Error())
+	} else if mode != preflightModeOff && nameErr == nil {
+		for _, problem := range getPreflightProblems(name) {
+			if mode == preflightModeFail {
+				errs = append(errs, "preflight check failed: "+problem)
+			} else {
+				log.Printf("Warning: preflight check failed: %v", problem)
+			}
+		}
+	}
+	errs = append(errs, checkConfigFiles()...)
+	for _, e := range errs {
+		log.Printf("Error: %v", e)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Validation failed with %v errors", len(errs))
+	}
+	log.Println("Validation succeeded")
+	return nil
+}
+
+// runDryRun writes the arguments which would be passed to crtmqm and strmqm, and the TLS and native HA
+// configuration which would be generated, to w.  Keystores are built in temporary directories, so the files
+// in use by a running queue manager are left unchanged.
+func runDryRun(ctx context.Context, w io.Writer, name string, devMode bool) error {
+	fips.ProcessFIPSType(log)
+	mounts, err := containerruntime.GetMounts()
+	if err != nil {
+		return err
+	}
+	qmConfig, err := loadQueueManagerConfig(qmConfigFile)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "crtmqm %v\n", strings.Join(getCreateQueueManagerArgs(mounts, name, devMode, qmConfig), " "))
+	if level := os.Getenv("MQ_CMDLEVEL"); level != "" {
+		fmt.Fprintf(w, "strmqm -e CMDLEVEL=%v\n", level)
+	}
+	fmt.Fprintf(w, "strmqm -x %v\n", name)
+
+	fmt.Fprintln(w, "* /run/15-tls.mqsc")
+	err = tls.RenderDefaultTLS(ctx, w, log)
+	if err != nil {
+		return err
+	}
+	return ha.RenderNativeHA(ctx, w, log)
+}
@@ -0,0 +1,138 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/command"
+	"github.com/ibm-messaging/mq-container/internal/drain"
+	"github.com/ibm-messaging/mq-container/internal/envvars"
+)
+
+func TestCheckMounts(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing")
+	tests := []struct {
+		name          string
+		multiInstance string
+		mounts        map[string]string
+		expected      []string
+	}{
+		{
+			name:     "Valid",
+			mounts:   map[string]string{dir: "ext4"},
+			expected: []string{},
+		},
+		{
+			name:     "Invalid",
+			mounts:   map[string]string{missing: "tmpfs"},
+			expected: []string{missing + " uses unsupported filesystem type: tmpfs", missing + " is not writable"},
+		},
+		{
+			name:          "MultiInstance",
+			multiInstance: "true",
+			mounts:        map[string]string{},
+			expected: []string{
+				"Missing required mount '/mnt/mqm' for a multi-instance queue manager",
+				"Missing required mount '/mnt/mqm-log' for a multi-instance queue manager",
+				"Missing required mount '/mnt/mqm-data' for a multi-instance queue manager",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MQ_MULTI_INSTANCE", tt.multiInstance)
+			t.Setenv("MQ_NATIVE_HA", "")
+			problems := checkMounts(tt.mounts)
+			if !reflect.DeepEqual(problems, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, problems)
+			}
+		})
+	}
+}
+
+// secondsReader adapts a reader which falls back to a default for invalid values, so that it returns an error
+// when the value of the variable isn't used
+func secondsReader(name string, read func() time.Duration) func() (any, error) {
+	return func() (any, error) {
+		timeout := read()
+		if os.Getenv(name) == "" {
+			return timeout, nil
+		}
+		seconds, err := strconv.Atoi(os.Getenv(name))
+		if err != nil || time.Duration(seconds)*time.Second != timeout {
+			return timeout, errors.New("value not used")
+		}
+		return timeout, nil
+	}
+}
+
+// TestRegisteredVariablesMatchReaders checks that the registered default, and values either side of the
+// boundary, are accepted or rejected in the same way by -validate and by the code which reads the variable
+func TestRegisteredVariablesMatchReaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		read   func() (any, error)
+		values []string
+	}{
+		{"MQ_GRACE_PERIOD", secondsReader("MQ_GRACE_PERIOD", getGracePeriod), []string{"0", "1", "-1"}},
+		{"MQ_SHUTDOWN_QUIESCE_TIMEOUT", secondsReader("MQ_SHUTDOWN_QUIESCE_TIMEOUT", func() time.Duration {
+			return getStageTimeout("MQ_SHUTDOWN_QUIESCE_TIMEOUT", 10*time.Minute, 50)
+		}), []string{"0", "1"}},
+		{"MQ_DRAIN_TIMEOUT", func() (any, error) { return drain.GetTimeout() }, []string{"0", "1"}},
+		{"MQ_HOOKS_TIMEOUT", func() (any, error) { return getHookTimeout() }, []string{"0", "1"}},
+		{"MQ_OTLP_EXPORT_INTERVAL", func() (any, error) { return getOTLPExportInterval() }, []string{"0", "1"}},
+		{"MQ_PROBE_PORT", func() (any, error) { return getProbePort() }, []string{"0", "1", "65535", "65536"}},
+		{"MQ_QMGR_RESTART_LIMIT", func() (any, error) { return getRestartLimit() }, []string{"-1", "0"}},
+		{"MQ_COMMAND_TIMEOUT", func() (any, error) { return command.GetTimeouts() }, []string{"-1", "0"}},
+		{"MQ_COMMAND_TIMEOUTS", func() (any, error) { return command.GetTimeouts() }, []string{"crtmqm", "=600", "crtmqm=-1", "crtmqm=0"}},
+	}
+	for _, test := range tests {
+		v, ok := envvars.Lookup(test.name)
+		if !ok {
+			t.Fatalf("%v is not registered", test.name)
+		}
+		t.Setenv(test.name, "")
+		unset, err := test.read()
+		if err != nil {
+			t.Fatalf("%v: unexpected error when not set: %v", test.name, err)
+		}
+		if v.Default != "" {
+			t.Setenv(test.name, v.Default)
+			actual, err := test.read()
+			if err != nil {
+				t.Errorf("%v: registered default '%v' is rejected: %v", test.name, v.Default, err)
+			} else if !reflect.DeepEqual(actual, unset) {
+				t.Errorf("%v: registered default '%v' gives %v, but the value used when not set is %v", test.name, v.Default, actual, unset)
+			}
+		}
+		for _, value := range test.values {
+			t.Setenv(test.name, value)
+			_, readErr := test.read()
+			checkErr := v.Check(value)
+			if (readErr == nil) != (checkErr == nil) {
+				t.Errorf("%v: value '%v' gives error %v when read, but %v when validated", test.name, value, readErr, checkErr)
+			}
+		}
+	}
+}
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2024
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -16,17 +16,25 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/ibm-messaging/mq-container/internal/command"
 	"github.com/ibm-messaging/mq-container/internal/copy"
 	"github.com/ibm-messaging/mq-container/internal/mqtemplate"
 	"github.com/ibm-messaging/mq-container/internal/tls"
 )
 
+// isWebServerEnabled returns true if the embedded web server should be run
+func isWebServerEnabled() bool {
+	enableWebServer := os.Getenv("MQ_ENABLE_EMBEDDED_WEB_SERVER")
+	return enableWebServer == "true" || enableWebServer == "1"
+}
+
 func startWebServer() error {
 	_, err := os.Stat("/opt/mqm/bin/strmqweb")
 	if err != nil && os.IsNotExist(err) {
@@ -50,7 +58,23 @@ func startWebServer() error {
 	return nil
 }
 
-func configureWebServer(keyLabel string, p12Truststore tls.KeyStoreData) error {
+// stopWebServer stops the web server, if it is installed
+func stopWebServer(ctx context.Context) error {
+	_, err := os.Stat("/opt/mqm/bin/endmqweb")
+	if err != nil && os.IsNotExist(err) {
+		return nil
+	}
+	log.Println("Stopping web server")
+	out, rc, err := command.RunContext(ctx, "endmqweb")
+	if err != nil {
+		log.Printf("Error %v stopping web server: %v", rc, out)
+		return err
+	}
+	log.Println("Stopped web server")
+	return nil
+}
+
+func configureWebServer(ctx context.Context, keyLabel string, p12Truststore tls.KeyStoreData) error {
 
 	webKeystore := ""
 	// Copy server.xml file to ensure that we have the latest expected contents - this file is only populated on QM creation
@@ -62,7 +86,7 @@ func configureWebServer(keyLabel string, p12Truststore tls.KeyStoreData) error {
 
 	// Configure the Web Keystore
 	if keyLabel != "" || os.Getenv("MQ_GENERATE_CERTIFICATE_HOSTNAME") != "" {
-		webKeystore, err = tls.ConfigureWebKeystore(p12Truststore, keyLabel)
+		webKeystore, err = tls.ConfigureWebKeystore(ctx, p12Truststore, keyLabel)
 		if err != nil {
 			return err
 		}
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2017, 2022
+© Copyright IBM Corporation 2017, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -18,11 +18,152 @@ limitations under the License.
 package command
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+)
+
+// DefaultTimeout is the deadline for commands which don't have their own, if MQ_COMMAND_TIMEOUT isn't set
+const DefaultTimeout = 5 * time.Minute
+
+// slowCommandThreshold is the time after which a command is reported as slow
+const slowCommandThreshold = 10 * time.Second
+
+// killWaitDelay is the time allowed for a command to end, and its output to be closed, after it is killed
+const killWaitDelay = 5 * time.Second
+
+// defaultTimeouts are the deadlines for commands which can legitimately take longer than DefaultTimeout,
+// such as while formatting or replaying the recovery log
+var defaultTimeouts = map[string]time.Duration{
+	"crtmqm": 30 * time.Minute,
+	"strmqm": 30 * time.Minute,
+}
+
+var (
+	mutex          sync.Mutex
+	log            *logger.Logger
+	timeoutHandler func(*TimeoutError)
+	timeouts       Timeouts
 )
 
+// SetLogger sets the logger used to report the time taken by each command.  Nothing is reported if
+// this isn't set.
+func SetLogger(l *logger.Logger) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	log = l
+}
+
+// SetTimeoutHandler sets a function to call when a command is killed because it has exceeded its
+// deadline, before the error is returned.  This can be used to collect diagnostics.
+func SetTimeoutHandler(handler func(*TimeoutError)) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	timeoutHandler = handler
+}
+
+// SetTimeouts sets the deadline for each command, which applies when the caller's context has no deadline
+// of its own.  Commands have no deadline if this isn't set.
+func SetTimeouts(t Timeouts) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	timeouts = t
+}
+
+// TimeoutError is returned when a command is killed because it has exceeded its deadline
+type TimeoutError struct {
+	Command string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("the '%v' command did not complete within %v", e.Command, e.Timeout)
+}
+
+// Timeouts holds the deadline for each command.  A deadline of zero means the command can run
+// for as long as it needs.
+type Timeouts struct {
+	Default  time.Duration
+	Commands map[string]time.Duration
+}
+
+// For returns the deadline for the named command, which may be a path
+func (t Timeouts) For(name string) time.Duration {
+	if timeout, ok := t.Commands[filepath.Base(name)]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// parseSeconds parses a non-negative number of seconds
+func parseSeconds(value string) (time.Duration, error) {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("'%v' is not a non-negative integer", value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// GetTimeouts returns the deadline for each command, from MQ_COMMAND_TIMEOUT and MQ_COMMAND_TIMEOUTS.
+// If either of these is invalid, an error is returned along with the deadlines from the valid settings.
+func GetTimeouts() (Timeouts, error) {
+	timeouts := Timeouts{Default: DefaultTimeout, Commands: map[string]time.Duration{}}
+	for name, timeout := range defaultTimeouts {
+		timeouts.Commands[name] = timeout
+	}
+	errs := []error{}
+	if value := strings.TrimSpace(os.Getenv("MQ_COMMAND_TIMEOUT")); value != "" {
+		timeout, err := parseSeconds(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid value for MQ_COMMAND_TIMEOUT: %v", err))
+		} else {
+			timeouts.Default = timeout
+		}
+	}
+	commands, err := ParseTimeouts(os.Getenv("MQ_COMMAND_TIMEOUTS"))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid value for MQ_COMMAND_TIMEOUTS: %w", err))
+	}
+	maps.Copy(timeouts.Commands, commands)
+	return timeouts, errors.Join(errs...)
+}
+
+// ParseTimeouts parses a comma-separated list of command names and numbers of seconds, such as
+// 'crtmqm=600,strmqm=600', as set in MQ_COMMAND_TIMEOUTS.  If any item is invalid, an error is returned
+// along with the deadlines from the valid items.
+func ParseTimeouts(value string) (map[string]time.Duration, error) {
+	commands := map[string]time.Duration{}
+	invalid := []string{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, found := strings.Cut(item, "=")
+		timeout, err := parseSeconds(value)
+		if !found || strings.TrimSpace(name) == "" || err != nil {
+			invalid = append(invalid, item)
+			continue
+		}
+		commands[strings.TrimSpace(name)] = timeout
+	}
+	if len(invalid) > 0 {
+		return commands, fmt.Errorf("each item must be a command name and a number of seconds, such as 'crtmqm=600', not '%v'", strings.Join(invalid, "', '"))
+	}
+	return commands, nil
+}
+
 // Run runs an OS command.  On Linux it waits for the command to
 // complete and returns the exit status (return code).
 // Do not use this function to run shell built-ins (like "cd"), because
@@ -31,14 +172,100 @@ func Run(name string, arg ...string) (string, int, error) {
 	return RunContext(context.Background(), name, arg...)
 }
 
+// RunContext runs an OS command, which is killed if the context is done.  If the context has no deadline,
+// the command is also killed if the deadline set for it using SetTimeouts is exceeded.
 func RunContext(ctx context.Context, name string, arg ...string) (string, int, error) {
+	return run(ctx, "", nil, name, arg...)
+}
+
+// RunWithInput runs an OS command, supplying the specified input on standard input.
+// It waits for the command to complete and returns the exit status (return code).
+func RunWithInput(ctx context.Context, input string, name string, arg ...string) (string, int, error) {
+	return run(ctx, input, nil, name, arg...)
+}
+
+// RunWithEnv runs an OS command, with the specified variables added to its environment.
+// It waits for the command to complete and returns the exit status (return code).
+func RunWithEnv(ctx context.Context, env []string, name string, arg ...string) (string, int, error) {
+	return run(ctx, "", env, name, arg...)
+}
+
+func run(ctx context.Context, input string, env []string, name string, arg ...string) (string, int, error) {
+	mutex.Lock()
+	timeout := timeouts.For(name)
+	mutex.Unlock()
+	commandCtx := ctx
+	if _, ok := ctx.Deadline(); ok {
+		// The caller's deadline takes the place of the command's own
+		timeout = 0
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		commandCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 	// Run the command and wait for completion
 	// #nosec G204
-	cmd := exec.CommandContext(ctx, name, arg...)
-	out, err := cmd.CombinedOutput()
-	rc := cmd.ProcessState.ExitCode()
+	cmd := exec.CommandContext(commandCtx, name, arg...)
+	if input != "" {
+		cmd.Stdin = strings.NewReader(input)
+	}
+	if env != nil {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.WaitDelay = killWaitDelay
+	start := time.Now()
+	out, rc, err := wait(commandCtx, cmd, &output)
+	elapsed := time.Since(start)
+	mutex.Lock()
+	l := log
+	handler := timeoutHandler
+	mutex.Unlock()
+	if l != nil {
+		if elapsed > slowCommandThreshold {
+			l.Printf("The '%v' command took %v, with exit code %v", filepath.Base(name), elapsed.Round(time.Millisecond), rc)
+		} else {
+			l.Debugf("The '%v' command took %v, with exit code %v", filepath.Base(name), elapsed.Round(time.Millisecond), rc)
+		}
+	}
+	// Only the command's own deadline is treated as a timeout, as the caller expects its context to end
+	if ctx.Err() == nil && commandCtx.Err() == context.DeadlineExceeded {
+		timeoutErr := &TimeoutError{Command: filepath.Base(name), Timeout: timeout}
+		if handler != nil {
+			handler(timeoutErr)
+		}
+		return out, rc, timeoutErr
+	}
+	if err != nil {
+		return out, rc, fmt.Errorf("%v: %v", cmd.Path, err)
+	}
+	return out, rc, nil
+}
+
+// wait starts the command and waits for it to end, returning its output and exit code.  If the command
+// doesn't end soon after being killed, it may be stuck in the kernel, such as on a hung filesystem, so
+// it is abandoned.
+func wait(ctx context.Context, cmd *exec.Cmd, output *bytes.Buffer) (string, int, error) {
+	err := cmd.Start()
 	if err != nil {
-		return string(out), rc, fmt.Errorf("%v: %v", cmd.Path, err)
+		return "", -1, err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		select {
+		case err = <-done:
+		case <-time.After(killWaitDelay + time.Second):
+			// The output and process state are still owned by the goroutine waiting for the command
+			return "", -1, ctx.Err()
+		}
 	}
-	return string(out), rc, nil
+	return output.String(), cmd.ProcessState.ExitCode(), err
 }
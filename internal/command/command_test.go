@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2017
+© Copyright IBM Corporation 2017, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -16,8 +16,11 @@ limitations under the License.
 package command
 
 import (
+	"context"
+	"errors"
 	"runtime"
 	"testing"
+	"time"
 )
 
 var commandTests = []struct {
@@ -45,3 +48,85 @@ func TestRun(t *testing.T) {
 		}
 	}
 }
+
+func TestRunWithInput(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Skipping tests for package which only works on Linux")
+	}
+	out, rc, err := RunWithInput(context.Background(), "hello\n", "cat")
+	if err != nil || rc != 0 {
+		t.Fatalf("RunWithInput(cat) - expected success, got rc=%v, err=%v", rc, err)
+	}
+	if out != "hello\n" {
+		t.Errorf("RunWithInput(cat) - expected output %q, got %q", "hello\n", out)
+	}
+}
+
+func TestGetTimeouts(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeout  string
+		timeouts string
+		command  string
+		expected time.Duration
+		err      bool
+	}{
+		{"Default", "", "", "dspmqinf", DefaultTimeout, false},
+		{"BuiltIn", "", "", "/opt/mqm/bin/crtmqm", 30 * time.Minute, false},
+		{"DefaultFromEnv", "60", "", "dspmqinf", time.Minute, false},
+		{"CommandFromEnv", "60", "crtmqm=600, strmqm=0", "strmqm", 0, false},
+		{"InvalidDefault", "1m", "crtmqm=600", "crtmqm", 10 * time.Minute, true},
+		{"InvalidCommand", "", "crtmqm", "crtmqm", 30 * time.Minute, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("MQ_COMMAND_TIMEOUT", test.timeout)
+			t.Setenv("MQ_COMMAND_TIMEOUTS", test.timeouts)
+			timeouts, err := GetTimeouts()
+			if (err != nil) != test.err {
+				t.Fatalf("Expected error=%v; got %v", test.err, err)
+			}
+			if timeout := timeouts.For(test.command); timeout != test.expected {
+				t.Errorf("Expected %v; got %v", test.expected, timeout)
+			}
+		})
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("Skipping tests for package which only works on Linux")
+	}
+	SetTimeouts(Timeouts{Commands: map[string]time.Duration{"sleep": time.Second}})
+	defer SetTimeouts(Timeouts{})
+	var handled *TimeoutError
+	SetTimeoutHandler(func(err *TimeoutError) {
+		handled = err
+	})
+	defer SetTimeoutHandler(nil)
+	_, _, err := Run("sleep", "10")
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.Command != "sleep" || timeoutErr.Timeout != time.Second {
+		t.Fatalf("Expected timeout error for sleep; got %v", err)
+	}
+	if handled != timeoutErr {
+		t.Errorf("Expected timeout handler to be called with %v; got %v", timeoutErr, handled)
+	}
+
+	// The end of the caller's context is not a timeout of the command
+	handled = nil
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, _, err = RunContext(ctx, "sleep", "10")
+	if err == nil || errors.As(err, &timeoutErr) || handled != nil {
+		t.Errorf("Expected error which is not a timeout error; got %v", err)
+	}
+
+	// The caller's deadline takes the place of the command's own
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, _, err = RunContext(ctx, "sleep", "1.5")
+	if err != nil {
+		t.Errorf("Expected sleep to complete within the caller's deadline; got %v", err)
+	}
+}
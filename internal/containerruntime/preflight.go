@@ -0,0 +1,165 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package containerruntime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// PreflightStatus is the outcome of a preflight check
+type PreflightStatus string
+
+const (
+	PreflightPassed  PreflightStatus = "passed"
+	PreflightFailed  PreflightStatus = "failed"
+	PreflightSkipped PreflightStatus = "skipped"
+)
+
+// PreflightResult is the result of a single preflight check
+type PreflightResult struct {
+	// Check is the name of the check, such as "kernel.shmmni"
+	Check string
+	// Status is the outcome of the check
+	Status PreflightStatus
+	// Detail describes what was found, and for a failed check, how to fix it
+	Detail string
+}
+
+// PreflightConfig describes the resources needed by the queue manager
+type PreflightConfig struct {
+	// LogDir is the directory where the recovery log will be created
+	LogDir string
+	// LogSize is the number of bytes needed for the recovery log, or zero if the log already exists
+	LogSize uint64
+	// WritableDirs are the directories which the queue manager needs to write to
+	WritableDirs []string
+}
+
+const (
+	sharedMemoryDir = "/dev/shm"
+	kernelParamDir  = "/proc/sys/kernel"
+
+	// minSharedMemorySize is the smallest /dev/shm which the queue manager can run with
+	minSharedMemorySize = 64 * 1024 * 1024
+	// minOpenFiles and minProcesses are the minimum resource limits for the user running the queue manager
+	minOpenFiles = 10240
+	minProcesses = 4096
+)
+
+// kernelParam is a kernel parameter, with the minimum values required by MQ
+type kernelParam struct {
+	name    string
+	fields  []string
+	minimum []uint64
+}
+
+// kernelParams are the kernel parameters checked, with the minimum values documented for MQ on Linux.
+// The kernel.sem parameter contains four values.
+var kernelParams = []kernelParam{
+	{"shmmni", []string{"shmmni"}, []uint64{4096}},
+	{"shmmax", []string{"shmmax"}, []uint64{268435456}},
+	{"shmall", []string{"shmall"}, []uint64{2097152}},
+	{"sem", []string{"SEMMSL", "SEMMNS", "SEMOPM", "SEMMNI"}, []uint64{32, 4096, 32, 128}},
+}
+
+// RunPreflightChecks checks that the container provides the resources needed by the queue manager.  These
+// are the requirements which cause confusing failures later if they are not met, such as AMQ6119 when
+// shared memory can't be allocated, or AMQ7017 when the recovery log can't be written.
+func RunPreflightChecks(config PreflightConfig) []PreflightResult {
+	results := []PreflightResult{checkSharedMemory(sharedMemoryDir, minSharedMemorySize)}
+	results = append(results, checkKernelParams(kernelParamDir)...)
+	results = append(results, checkResourceLimits()...)
+	if config.LogSize > 0 {
+		results = append(results, checkFreeSpace(config.LogDir, config.LogSize))
+	}
+	for _, dir := range config.WritableDirs {
+		results = append(results, checkWritable(dir))
+	}
+	return results
+}
+
+// checkKernelParams checks the kernel parameters in the specified directory.  Containers share the
+// kernel parameters of the host, or of their IPC namespace, so they can only be changed by the container runtime.
+func checkKernelParams(dir string) []PreflightResult {
+	results := []PreflightResult{}
+	for _, param := range kernelParams {
+		check := "kernel." + param.name
+		value, err := readProc(filepath.Join(dir, param.name))
+		if err != nil {
+			results = append(results, PreflightResult{check, PreflightSkipped, fmt.Sprintf("Unable to read %v: %v", check, err)})
+			continue
+		}
+		values := strings.Fields(value)
+		if len(values) != len(param.fields) {
+			results = append(results, PreflightResult{check, PreflightSkipped, fmt.Sprintf("Unexpected value for %v: %v", check, value)})
+			continue
+		}
+		problems := []string{}
+		for i, field := range param.fields {
+			n, err := strconv.ParseUint(values[i], 10, 64)
+			if err != nil || n < param.minimum[i] {
+				problems = append(problems, fmt.Sprintf("%v is %v, but at least %v is required", field, values[i], param.minimum[i]))
+			}
+		}
+		if len(problems) > 0 {
+			results = append(results, PreflightResult{check, PreflightFailed, strings.Join(problems, "; ") + ". Set the kernel parameter using the container runtime, for example with --sysctl"})
+		} else {
+			results = append(results, PreflightResult{check, PreflightPassed, value})
+		}
+	}
+	return results
+}
+
+// checkLimit checks a resource limit against the minimum required
+func checkLimit(check string, soft uint64, hard uint64, minimum uint64, remedy string) PreflightResult {
+	detail := fmt.Sprintf("soft limit %v, hard limit %v", formatLimit(soft), formatLimit(hard))
+	if soft < minimum {
+		return PreflightResult{check, PreflightFailed, fmt.Sprintf("%v, but at least %v is required. %v", detail, minimum, remedy)}
+	}
+	return PreflightResult{check, PreflightPassed, detail}
+}
+
+// formatLimit formats a resource limit, which can be unlimited
+func formatLimit(limit uint64) string {
+	if limit == ^uint64(0) {
+		return "unlimited"
+	}
+	return strconv.FormatUint(limit, 10)
+}
+
+// formatBytes formats a number of bytes in MiB
+func formatBytes(bytes uint64) string {
+	return fmt.Sprintf("%v MiB", bytes/(1024*1024))
+}
+
+// existingDir returns the directory, or its closest parent which exists.  This is used to check the
+// filesystem which will contain a directory which has not been created yet.
+func existingDir(dir string) string {
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
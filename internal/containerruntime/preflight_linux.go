@@ -0,0 +1,94 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package containerruntime
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// checkSharedMemory checks the size of the shared memory filesystem
+func checkSharedMemory(dir string, minimum uint64) PreflightResult {
+	check := dir + " size"
+	statfs := &unix.Statfs_t{}
+	err := unix.Statfs(dir, statfs)
+	if err != nil {
+		return PreflightResult{check, PreflightFailed, fmt.Sprintf("Unable to check %v: %v", dir, err)}
+	}
+	// Use type conversions, as the types of the fields vary by architecture
+	size := uint64(statfs.Blocks) * uint64(statfs.Bsize)
+	if size < minimum {
+		return PreflightResult{check, PreflightFailed, fmt.Sprintf("%v, but at least %v is required. Increase the size using the container runtime, for example with --shm-size", formatBytes(size), formatBytes(minimum))}
+	}
+	return PreflightResult{check, PreflightPassed, formatBytes(size)}
+}
+
+// checkResourceLimits checks the limits on open files and processes
+func checkResourceLimits() []PreflightResult {
+	results := []PreflightResult{}
+	limits := []struct {
+		check    string
+		resource int
+		minimum  uint64
+		remedy   string
+	}{
+		{"nofile", unix.RLIMIT_NOFILE, minOpenFiles, "Increase the limit using the container runtime, for example with --ulimit nofile=10240:10240"},
+		{"nproc", unix.RLIMIT_NPROC, minProcesses, "Increase the limit using the container runtime, for example with --ulimit nproc=4096:4096"},
+	}
+	for _, l := range limits {
+		var limit unix.Rlimit
+		err := unix.Getrlimit(l.resource, &limit)
+		if err != nil {
+			results = append(results, PreflightResult{l.check, PreflightSkipped, fmt.Sprintf("Unable to get limit: %v", err)})
+			continue
+		}
+		results = append(results, checkLimit(l.check, limit.Cur, limit.Max, l.minimum, l.remedy))
+	}
+	return results
+}
+
+// checkFreeSpace checks that there is enough free space for the recovery log
+func checkFreeSpace(dir string, required uint64) PreflightResult {
+	check := "free space for recovery log"
+	statfs := &unix.Statfs_t{}
+	err := unix.Statfs(existingDir(dir), statfs)
+	if err != nil {
+		return PreflightResult{check, PreflightSkipped, fmt.Sprintf("Unable to check %v: %v", dir, err)}
+	}
+	available := uint64(statfs.Bavail) * uint64(statfs.Bsize)
+	if available < required {
+		return PreflightResult{check, PreflightFailed, fmt.Sprintf("%v available for %v, but the recovery log needs %v. Use a larger volume, or reduce the log file size or number of log files", formatBytes(available), dir, formatBytes(required))}
+	}
+	return PreflightResult{check, PreflightPassed, fmt.Sprintf("%v available for %v, %v needed", formatBytes(available), dir, formatBytes(required))}
+}
+
+// checkWritable checks that a directory can be written to.  If the directory doesn't exist yet, its parent must be writable.
+func checkWritable(dir string) PreflightResult {
+	check := dir + " writable"
+	path := existingDir(dir)
+	if unix.Access(path, unix.W_OK) != nil {
+		detail := fmt.Sprintf("%v is not writable", path)
+		statfs := &unix.Statfs_t{}
+		if unix.Statfs(path, statfs) == nil && statfs.Flags&unix.ST_RDONLY != 0 {
+			detail += fmt.Sprintf(", because it is on a read-only filesystem. When running with a read-only root filesystem, mount a volume at %v", dir)
+		} else {
+			detail += ". Check the ownership and permissions of the volume"
+		}
+		return PreflightResult{check, PreflightFailed, detail}
+	}
+	return PreflightResult{check, PreflightPassed, ""}
+}
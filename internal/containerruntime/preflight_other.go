@@ -0,0 +1,37 @@
+//go:build !linux
+
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package containerruntime
+
+// Dummy versions of the preflight checks, only for non-Linux systems.
+// Having these allows unit tests to be run on other platforms (e.g. macOS)
+
+func checkSharedMemory(dir string, minimum uint64) PreflightResult {
+	return PreflightResult{dir + " size", PreflightSkipped, "Not supported on this platform"}
+}
+
+func checkResourceLimits() []PreflightResult {
+	return nil
+}
+
+func checkFreeSpace(dir string, required uint64) PreflightResult {
+	return PreflightResult{"free space for recovery log", PreflightSkipped, "Not supported on this platform"}
+}
+
+func checkWritable(dir string) PreflightResult {
+	return PreflightResult{dir + " writable", PreflightSkipped, "Not supported on this platform"}
+}
@@ -0,0 +1,120 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package containerruntime
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeKernelParams(t *testing.T, params map[string]string) string {
+	dir := t.TempDir()
+	for name, value := range params {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(value+"\n"), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCheckKernelParams(t *testing.T) {
+	dir := writeKernelParams(t, map[string]string{
+		"shmmni": "4096",
+		"shmmax": "18446744073692774399",
+		"shmall": "1024",
+		"sem":    "32000\t1024000000\t500\t32",
+	})
+	expected := map[string]PreflightStatus{
+		"kernel.shmmni": PreflightPassed,
+		"kernel.shmmax": PreflightPassed,
+		"kernel.shmall": PreflightFailed,
+		"kernel.sem":    PreflightFailed,
+	}
+	results := checkKernelParams(dir)
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results; got %+v", len(expected), results)
+	}
+	for _, result := range results {
+		if result.Status != expected[result.Check] {
+			t.Errorf("Expected %v to be %v; got %+v", result.Check, expected[result.Check], result)
+		}
+		if result.Check == "kernel.sem" && (!strings.Contains(result.Detail, "SEMMNI is 32") || strings.Contains(result.Detail, "SEMMSL")) {
+			t.Errorf("Unexpected detail for kernel.sem: %v", result.Detail)
+		}
+	}
+}
+
+func TestCheckKernelParamsMissing(t *testing.T) {
+	dir := writeKernelParams(t, map[string]string{"sem": "32 4096"})
+	for _, result := range checkKernelParams(dir) {
+		if result.Status != PreflightSkipped {
+			t.Errorf("Expected %v to be skipped; got %+v", result.Check, result)
+		}
+	}
+}
+
+func TestCheckLimit(t *testing.T) {
+	tests := []struct {
+		soft     uint64
+		hard     uint64
+		expected PreflightStatus
+	}{
+		{1024, 4096, PreflightFailed},
+		{10240, 10240, PreflightPassed},
+		{^uint64(0), ^uint64(0), PreflightPassed},
+	}
+	for _, test := range tests {
+		result := checkLimit("nofile", test.soft, test.hard, minOpenFiles, "")
+		if result.Status != test.expected {
+			t.Errorf("Expected %v for soft limit %v; got %+v", test.expected, test.soft, result)
+		}
+	}
+}
+
+func TestCheckFreeSpace(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "log", "QM1")
+	result := checkFreeSpace(dir, 1)
+	if result.Status != PreflightPassed {
+		t.Errorf("Expected check to pass; got %+v", result)
+	}
+	result = checkFreeSpace(dir, ^uint64(0))
+	if result.Status != PreflightFailed {
+		t.Errorf("Expected check to fail; got %+v", result)
+	}
+}
+
+func TestCheckWritable(t *testing.T) {
+	dir := t.TempDir()
+	result := checkWritable(filepath.Join(dir, "data"))
+	if result.Status != PreflightPassed {
+		t.Errorf("Expected check to pass; got %+v", result)
+	}
+	if os.Geteuid() == 0 {
+		t.Skip("Permissions are not enforced for root")
+	}
+	err := os.Chmod(dir, 0500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(dir, 0700)
+	result = checkWritable(filepath.Join(dir, "data"))
+	if result.Status != PreflightFailed {
+		t.Errorf("Expected check to fail; got %+v", result)
+	}
+}
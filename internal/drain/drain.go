@@ -0,0 +1,267 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drain moves client applications off a queue manager before it is stopped, by stopping its listeners
+// and quiescing its server-connection channels
+package drain
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/command"
+	"github.com/ibm-messaging/mq-container/internal/envvars"
+	"github.com/ibm-messaging/mq-container/internal/mqscattr"
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+)
+
+// DefaultTimeout is the time to wait for client connections to end, if MQ_DRAIN_TIMEOUT isn't set
+const DefaultTimeout = 30 * time.Second
+
+// pollInterval is the time between checks of the number of client connections
+const pollInterval = 2 * time.Second
+
+// mqscRunner runs MQSC commands against the queue manager, and returns the output
+type mqscRunner func(ctx context.Context, mqsc string) (string, error)
+
+// GetTimeout returns the time to wait for client connections to end, from MQ_DRAIN_TIMEOUT
+func GetTimeout() (time.Duration, error) {
+	value := strings.TrimSpace(os.Getenv("MQ_DRAIN_TIMEOUT"))
+	if value == "" {
+		return DefaultTimeout, nil
+	}
+	seconds, err := envvars.ParsePositiveInteger(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for MQ_DRAIN_TIMEOUT: '%v'", value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// Drain stops the listeners of the named queue manager, so that no new clients can connect, then stops its
+// server-connection channels in quiesce mode, so that clients end their connections at the next opportunity.
+// It returns once there are no client connections, or returns an error if there are still connections
+// after the timeout.
+func Drain(ctx context.Context, name string, timeout time.Duration, log *logger.Logger) error {
+	run := func(ctx context.Context, mqsc string) (string, error) {
+		out, rc, err := command.RunWithInput(ctx, mqsc, "runmqsc", name)
+		// A return code of 10 means that some commands failed, such as a display which found nothing
+		if err != nil && rc != 10 {
+			return out, fmt.Errorf("the 'runmqsc' command returned with code: %v. Reason: %v", rc, err)
+		}
+		return out, nil
+	}
+	return drain(ctx, run, timeout, pollInterval, log)
+}
+
+func drain(ctx context.Context, run mqscRunner, timeout time.Duration, interval time.Duration, log *logger.Logger) error {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	log.Printf("Draining client connections, waiting up to %v", timeout)
+	listeners, err := displayNames(ctx, run, "DISPLAY LSSTATUS(*)", "LISTENER")
+	if err != nil {
+		return fmt.Errorf("unable to find running listeners: %w", err)
+	}
+	if len(listeners) > 0 {
+		log.Printf("Stopping listeners: %v", strings.Join(listeners, ", "))
+		err = stopObjects(ctx, run, "STOP LISTENER(%v)", listeners, log)
+		if err != nil {
+			return err
+		}
+	}
+	connections, err := displayConnections(ctx, run)
+	if err != nil {
+		return fmt.Errorf("unable to find client connections: %w", err)
+	}
+	channels := channelNames(connections)
+	if len(channels) > 0 {
+		log.Printf("Stopping server-connection channels in quiesce mode: %v", strings.Join(channels, ", "))
+		err = stopObjects(ctx, run, "STOP CHANNEL(%v) MODE(QUIESCE)", channels, log)
+		if err != nil {
+			return err
+		}
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if len(connections) == 0 {
+			log.Printf("Drained client connections in %v", time.Since(start).Round(time.Millisecond))
+			return nil
+		}
+		log.Printf("Waiting for %v client connections to end: %v", len(connections), describeConnections(connections))
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%v client connections did not end within %v", len(connections), timeout)
+		case <-ticker.C:
+		}
+		connections, err = displayConnections(ctx, run)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("client connections did not end within %v", timeout)
+			}
+			return fmt.Errorf("unable to find client connections: %w", err)
+		}
+	}
+}
+
+// displayConnections returns the channel name of each server-connection channel instance which has not stopped
+func displayConnections(ctx context.Context, run mqscRunner) ([]string, error) {
+	out, err := run(ctx, "DISPLAY CHSTATUS(*) CHLTYPE(SVRCONN) STATUS")
+	if err != nil {
+		return nil, err
+	}
+	connections := []string{}
+	for _, record := range parseRecords(out) {
+		if record["CHANNEL"] == "" || record["STATUS"] == "STOPPED" {
+			continue
+		}
+		connections = append(connections, record["CHANNEL"])
+	}
+	return connections, nil
+}
+
+// channelNames returns the names of the channels used by the connections, without duplicates
+func channelNames(connections []string) []string {
+	names := []string{}
+	for _, name := range connections {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// describeConnections returns the number of connections to each channel, such as "DEV.APP.SVRCONN (3)"
+func describeConnections(connections []string) string {
+	counts := map[string]int{}
+	for _, name := range connections {
+		counts[name]++
+	}
+	descriptions := []string{}
+	for _, name := range channelNames(connections) {
+		descriptions = append(descriptions, fmt.Sprintf("%v (%v)", name, counts[name]))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// displayNames runs an MQSC display command, and returns the values of the given attribute
+func displayNames(ctx context.Context, run mqscRunner, mqsc string, attribute string) ([]string, error) {
+	out, err := run(ctx, mqsc)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, record := range parseRecords(out) {
+		if name := record[attribute]; name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// stopObjects runs an MQSC stop command for each of the named objects.  Objects which couldn't be stopped
+// are logged, as they may have stopped already.
+func stopObjects(ctx context.Context, run mqscRunner, format string, names []string, log *logger.Logger) error {
+	commands := []string{}
+	for _, name := range names {
+		commands = append(commands, fmt.Sprintf(format, quote(name)))
+	}
+	out, err := run(ctx, strings.Join(commands, "\n")+"\n")
+	if err != nil {
+		return err
+	}
+	for _, record := range parseMessages(out) {
+		if strings.HasSuffix(record[0], "E") {
+			log.Printf("Warning: %v", strings.Join(record, " "))
+		}
+	}
+	return nil
+}
+
+// quote returns an object name as a quoted MQSC string, so that its case is preserved
+func quote(name string) string {
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// parseMessages splits runmqsc output into the messages it contains, each starting with a message ID,
+// such as "AMQ8417I", followed by the lines of the message
+func parseMessages(out string) [][]string {
+	messages := [][]string{}
+	inMessage := false
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case isMessageID(line):
+			id, text, _ := strings.Cut(line, ":")
+			messages = append(messages, []string{id, strings.TrimSpace(text)})
+			inMessage = true
+		case isCommandEcho(line):
+			inMessage = false
+		case inMessage:
+			messages[len(messages)-1] = append(messages[len(messages)-1], line)
+		}
+	}
+	return messages
+}
+
+// isCommandEcho returns true if the line is a command echoed by runmqsc, such as "1 : DISPLAY LSSTATUS(*)"
+func isCommandEcho(line string) bool {
+	number, _, found := strings.Cut(line, " : ")
+	if !found {
+		return false
+	}
+	_, err := strconv.Atoi(number)
+	return err == nil
+}
+
+// isMessageID returns true if the line starts with an MQ message ID, such as "AMQ8417I:"
+func isMessageID(line string) bool {
+	id, _, found := strings.Cut(line, ":")
+	if !found || len(id) != 8 || !strings.HasPrefix(id, "AMQ") {
+		return false
+	}
+	for _, c := range id[3:7] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseRecords returns the attributes in each message of runmqsc output, such as the status of a channel
+func parseRecords(out string) []map[string]string {
+	records := []map[string]string{}
+	for _, message := range parseMessages(out) {
+		record := map[string]string{}
+		for _, line := range message[2:] {
+			for _, attribute := range mqscattr.Parse(line) {
+				record[attribute.Name] = attribute.Value
+			}
+		}
+		if len(record) > 0 {
+			records = append(records, record)
+		}
+	}
+	return records
+}
@@ -0,0 +1,203 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package drain
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+)
+
+const lsstatusOutput = `5724-H72 (C) Copyright IBM Corp. 1994, 2025.
+Starting MQSC for queue manager QM1.
+
+
+     1 : DISPLAY LSSTATUS(*)
+AMQ8631I: Display listener status details.
+   LISTENER(SYSTEM.LISTENER.TCP.1)         STATUS(RUNNING)
+   PID(88)                               
+AMQ8631I: Display listener status details.
+   LISTENER(dev.listener)                  STATUS(RUNNING)
+   PID(89)                               
+One MQSC command read.
+No commands have a syntax error.
+All valid MQSC commands were processed.
+`
+
+const chstatusOutput = `5724-H72 (C) Copyright IBM Corp. 1994, 2025.
+Starting MQSC for queue manager QM1.
+
+
+     1 : DISPLAY CHSTATUS(*) CHLTYPE(SVRCONN) STATUS
+AMQ8417I: Display Channel Status details.
+   CHANNEL(DEV.APP.SVRCONN)                CHLTYPE(SVRCONN)
+   CONNAME(10.0.0.1)                       CURRENT
+   STATUS(RUNNING)                         SUBSTATE(RECEIVE)
+AMQ8417I: Display Channel Status details.
+   CHANNEL(DEV.APP.SVRCONN)                CHLTYPE(SVRCONN)
+   CONNAME(10.0.0.2)                       CURRENT
+   STATUS(STOPPING)                      
+AMQ8417I: Display Channel Status details.
+   CHANNEL(DEV.ADMIN.SVRCONN)              CHLTYPE(SVRCONN)
+   CONNAME(10.0.0.3)                       CURRENT
+   STATUS(RUNNING)                       
+AMQ8417I: Display Channel Status details.
+   CHANNEL(SYSTEM.DEF.SVRCONN)             CHLTYPE(SVRCONN)
+   CONNAME( )                              CURRENT
+   STATUS(STOPPED)                       
+One MQSC command read.
+No commands have a syntax error.
+All valid MQSC commands were processed.
+`
+
+const chstatusNotFoundOutput = `     1 : DISPLAY CHSTATUS(*) CHLTYPE(SVRCONN) STATUS
+AMQ8420I: Channel Status not found.
+One MQSC command read.
+`
+
+func TestParseRecords(t *testing.T) {
+	records := parseRecords(chstatusOutput)
+	if len(records) != 4 {
+		t.Fatalf("Expected 4 records; got %v", records)
+	}
+	if records[0]["CHANNEL"] != "DEV.APP.SVRCONN" || records[0]["CONNAME"] != "10.0.0.1" || records[0]["STATUS"] != "RUNNING" {
+		t.Errorf("Unexpected first record: %v", records[0])
+	}
+	if len(parseRecords(chstatusNotFoundOutput)) != 0 {
+		t.Errorf("Expected no records when channel status is not found")
+	}
+}
+
+func TestDisplayNames(t *testing.T) {
+	run := func(ctx context.Context, mqsc string) (string, error) {
+		return lsstatusOutput, nil
+	}
+	names, err := displayNames(context.Background(), run, "DISPLAY LSSTATUS(*)", "LISTENER")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"SYSTEM.LISTENER.TCP.1", "dev.listener"}
+	if !slices.Equal(names, expected) {
+		t.Errorf("Expected %v; got %v", expected, names)
+	}
+}
+
+func TestDescribeConnections(t *testing.T) {
+	run := func(ctx context.Context, mqsc string) (string, error) {
+		return chstatusOutput, nil
+	}
+	connections, err := displayConnections(context.Background(), run)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "DEV.APP.SVRCONN (2), DEV.ADMIN.SVRCONN (1)"
+	if description := describeConnections(connections); description != expected {
+		t.Errorf("Expected %v; got %v", expected, description)
+	}
+}
+
+// fakeQueueManager responds to MQSC commands, with its channels ending after a number of status checks
+type fakeQueueManager struct {
+	commands []string
+	checks   int
+	endAfter int
+}
+
+func (f *fakeQueueManager) run(ctx context.Context, mqsc string) (string, error) {
+	f.commands = append(f.commands, strings.Split(strings.TrimSpace(mqsc), "\n")...)
+	switch {
+	case strings.HasPrefix(mqsc, "DISPLAY LSSTATUS"):
+		return lsstatusOutput, nil
+	case strings.HasPrefix(mqsc, "DISPLAY CHSTATUS"):
+		f.checks++
+		if f.checks > f.endAfter {
+			return chstatusNotFoundOutput, nil
+		}
+		return chstatusOutput, nil
+	}
+	return "", nil
+}
+
+func TestDrain(t *testing.T) {
+	log, err := logger.NewLogger(io.Discard, false, false, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	qm := &fakeQueueManager{endAfter: 2}
+	err = drain(context.Background(), qm.run, time.Second, time.Millisecond, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"STOP LISTENER('SYSTEM.LISTENER.TCP.1')",
+		"STOP LISTENER('dev.listener')",
+		"STOP CHANNEL('DEV.APP.SVRCONN') MODE(QUIESCE)",
+		"STOP CHANNEL('DEV.ADMIN.SVRCONN') MODE(QUIESCE)",
+	} {
+		if !slices.Contains(qm.commands, expected) {
+			t.Errorf("Expected command %v; got %v", expected, qm.commands)
+		}
+	}
+	if slices.Contains(qm.commands, "STOP CHANNEL('SYSTEM.DEF.SVRCONN') MODE(QUIESCE)") {
+		t.Errorf("Expected stopped channel not to be stopped again")
+	}
+	if qm.checks != 3 {
+		t.Errorf("Expected 3 status checks; got %v", qm.checks)
+	}
+}
+
+func TestDrainTimeout(t *testing.T) {
+	log, err := logger.NewLogger(io.Discard, false, false, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	qm := &fakeQueueManager{endAfter: 1000}
+	err = drain(context.Background(), qm.run, 20*time.Millisecond, time.Millisecond, log)
+	if err == nil || !strings.Contains(err.Error(), "did not end within") {
+		t.Errorf("Expected timeout error; got %v", err)
+	}
+}
+
+func TestGetTimeout(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		err      bool
+	}{
+		{"", DefaultTimeout, false},
+		{"60", time.Minute, false},
+		{"0", 0, true},
+		{"-1", 0, true},
+		{"ten", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			t.Setenv("MQ_DRAIN_TIMEOUT", test.value)
+			timeout, err := GetTimeout()
+			if (err != nil) != test.err {
+				t.Fatalf("Expected error=%v; got %v", test.err, err)
+			}
+			if timeout != test.expected {
+				t.Errorf("Expected %v; got %v", test.expected, timeout)
+			}
+		})
+	}
+}
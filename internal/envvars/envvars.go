@@ -0,0 +1,329 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envvars contains the registry of environment variables supported by the container
+package envvars
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ibm-messaging/mq-container/internal/command"
+)
+
+// Type is the type of value held by an environment variable
+type Type string
+
+const (
+	// String is any value
+	String Type = "string"
+	// Bool is 'true' or 'false', or '1' or '0'
+	Bool Type = "bool"
+	// Integer is a non-negative integer
+	Integer Type = "integer"
+	// PositiveInteger is an integer greater than zero
+	PositiveInteger Type = "positiveInteger"
+	// Port is a TCP port number
+	Port Type = "port"
+	// Enum is one of a set of allowed values, ignoring case
+	Enum Type = "enum"
+	// List is a comma-separated list of values.  If allowed values are set, each item must be one of them.
+	List Type = "list"
+)
+
+// Variable describes an environment variable supported by the container
+type Variable struct {
+	Name        string
+	Type        Type
+	Default     string
+	Allowed     []string
+	Description string
+	// Deprecated describes what to use instead, if the variable is deprecated
+	Deprecated string
+	// Validator is an additional check of the value, shared with the code which reads the variable
+	Validator func(value string) error
+}
+
+// Problem describes a problem found with an environment variable
+type Problem struct {
+	Name    string
+	Message string
+	// Warning is true if the problem doesn't prevent the container from running
+	Warning bool
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%v: %v", p.Name, p.Message)
+}
+
+const (
+	defaultEventQueues = "SYSTEM.ADMIN.QMGR.EVENT,SYSTEM.ADMIN.CHANNEL.EVENT,SYSTEM.ADMIN.PERFM.EVENT,SYSTEM.ADMIN.CONFIG.EVENT,SYSTEM.ADMIN.COMMAND.EVENT"
+	defaultExcludeIDs  = "AMQ5041I,AMQ5052I,AMQ5051I,AMQ5037I,AMQ5975I"
+)
+
+// Variables is the registry of environment variables supported by the container
+var Variables = []Variable{
+	{Name: "LICENSE", Type: Enum, Allowed: []string{"accept", "view"}, Description: "Set to 'accept' to agree to the license, or 'view' to print it"},
+	{Name: "LANG", Type: String, Description: "The language the license is printed in"},
+	{Name: "DEBUG", Type: Bool, Default: "false", Description: "Enables debug logging"},
+	{Name: "LOG_FORMAT", Type: Enum, Allowed: []string{"basic", "json"}, Description: "The format of the logs printed on stdout", Deprecated: "use MQ_LOGGING_CONSOLE_FORMAT instead"},
+	{Name: "MQ_QMGR_NAME", Type: String, Description: "The name of the queue manager"},
+	{Name: "MQ_QMGR_LOG_FILE_PAGES", Type: Integer, Description: "The LogFilePages passed to crtmqm"},
+	{Name: "MQ_QMGR_ENDED_POLICY", Type: Enum, Default: "none", Allowed: []string{"none", "exit", "restart"}, Description: "What to do if the queue manager ends unexpectedly"},
+	{Name: "MQ_PREFLIGHT_CHECKS", Type: Enum, Default: "warn", Allowed: []string{"off", "warn", "fail"}, Description: "What to do if a preflight check of the container resources fails"},
+	{Name: "MQ_QMGR_RESTART_LIMIT", Type: Integer, Default: "3", Description: "The maximum number of consecutive restarts of the queue manager"},
+	{Name: "MQ_GRACE_PERIOD", Type: PositiveInteger, Default: "30", Description: "The number of seconds to wait for the queue manager to end"},
+	{Name: "MQ_SHUTDOWN_QUIESCE_TIMEOUT", Type: PositiveInteger, Description: "The number of seconds to wait for a controlled shutdown before escalating to an immediate shutdown"},
+	{Name: "MQ_SHUTDOWN_IMMEDIATE_TIMEOUT", Type: PositiveInteger, Description: "The number of seconds to wait for an immediate shutdown before escalating to a preemptive shutdown"},
+	{Name: "MQ_SHUTDOWN_PREEMPTIVE_TIMEOUT", Type: PositiveInteger, Description: "The number of seconds to wait for a preemptive shutdown before killing the queue manager processes"},
+	{Name: "MQ_DRAIN_ON_STOP", Type: Bool, Default: "false", Description: "Drains client connections when the container is stopped, before stopping the queue manager"},
+	{Name: "MQ_DRAIN_TIMEOUT", Type: PositiveInteger, Default: "30", Description: "The number of seconds to wait for client connections to end when draining the queue manager"},
+	{Name: "MQ_HOOKS_TIMEOUT", Type: PositiveInteger, Default: "60", Description: "The number of seconds each lifecycle hook is allowed to run"},
+	{Name: "MQ_HOOKS_PRE_CREATE_POLICY", Type: Enum, Default: "fail", Allowed: []string{"fail", "warn"}, Description: "What to do if a pre-create hook fails"},
+	{Name: "MQ_HOOKS_POST_CREATE_POLICY", Type: Enum, Default: "fail", Allowed: []string{"fail", "warn"}, Description: "What to do if a post-create hook fails"},
+	{Name: "MQ_HOOKS_POST_START_POLICY", Type: Enum, Default: "warn", Allowed: []string{"fail", "warn"}, Description: "What to do if a post-start hook fails"},
+	{Name: "MQ_COMMAND_TIMEOUT", Type: Integer, Default: "300", Description: "The number of seconds each MQ command is allowed to run, unless set in MQ_COMMAND_TIMEOUTS"},
+	{Name: "MQ_COMMAND_TIMEOUTS", Type: List, Default: "crtmqm=1800,strmqm=1800", Description: "The number of seconds specific MQ commands are allowed to run, such as 'crtmqm=600'", Validator: validateCommandTimeouts},
+	{Name: "MQ_CMDLEVEL", Type: Integer, Description: "The command level to set before starting the queue manager"},
+	{Name: "MQ_DEV", Type: Bool, Default: "true", Description: "Enables the developer defaults"},
+	{Name: "MQ_ADMIN_PASSWORD", Type: String, Description: "The password of the admin user", Deprecated: "use secrets to set the passwords"},
+	{Name: "MQ_APP_PASSWORD", Type: String, Description: "The password of the app user", Deprecated: "use secrets to set the passwords"},
+	{Name: "MQ_ADMIN_PASSWORD_SECURE", Type: String, Description: "The encoded password of the admin user"},
+	{Name: "MQ_APP_PASSWORD_SECURE", Type: String, Description: "The encoded password of the app user"},
+	{Name: "MQ_CONNAUTH_USE_HTP", Type: Bool, Default: "false", Description: "Enables authentication using an htpasswd file"},
+	{Name: "MQ_CONSOLE_DEFAULT_CCDT_HOSTNAME", Type: String, Description: "The host name in the CCDT generated by the web console"},
+	{Name: "MQ_CONSOLE_DEFAULT_CCDT_PORT", Type: Port, Description: "The port in the CCDT generated by the web console"},
+	{Name: "MQ_MULTI_INSTANCE", Type: Bool, Default: "false", Description: "Runs a multi-instance queue manager"},
+	{Name: "MQ_NATIVE_HA", Type: Bool, Default: "false", Description: "Runs a native HA queue manager"},
+	{Name: "MQ_NATIVE_HA_TLS", Type: Bool, Default: "false", Description: "Enables TLS for native HA replication"},
+	{Name: "MQ_NATIVE_HA_CIPHERSPEC", Type: String, Description: "The CipherSpec used for native HA replication"},
+	{Name: "MQ_NATIVE_HA_KEY_REPOSITORY", Type: String, Description: "The key repository used for native HA replication"},
+	{Name: "MQ_NATIVE_HA_IN_SYNC_TIMEOUT", Type: Integer, Description: "The number of seconds to wait for a native HA replica to be in sync"},
+	{Name: "MQ_NATIVE_HA_INSTANCE_0_NAME", Type: String, Description: "The name of the first native HA instance"},
+	{Name: "MQ_NATIVE_HA_INSTANCE_0_REPLICATION_ADDRESS", Type: String, Description: "The replication address of the first native HA instance"},
+	{Name: "MQ_NATIVE_HA_INSTANCE_1_NAME", Type: String, Description: "The name of the second native HA instance"},
+	{Name: "MQ_NATIVE_HA_INSTANCE_1_REPLICATION_ADDRESS", Type: String, Description: "The replication address of the second native HA instance"},
+	{Name: "MQ_NATIVE_HA_INSTANCE_2_NAME", Type: String, Description: "The name of the third native HA instance"},
+	{Name: "MQ_NATIVE_HA_INSTANCE_2_REPLICATION_ADDRESS", Type: String, Description: "The replication address of the third native HA instance"},
+	{Name: "MQ_NATIVE_HA_GROUP_LOCAL_NAME", Type: String, Description: "The name of the local native HA group"},
+	{Name: "MQ_NATIVE_HA_GROUP_LOCAL_ADDRESS", Type: String, Description: "The address of the local native HA group"},
+	{Name: "MQ_NATIVE_HA_GROUP_ROLE", Type: Enum, Allowed: []string{"Live", "Recovery"}, Description: "The role of the local native HA group"},
+	{Name: "MQ_NATIVE_HA_GROUP_RECOVERY_NAME", Type: String, Description: "The name of the recovery native HA group"},
+	{Name: "MQ_NATIVE_HA_GROUP_REPLICATION_ADDRESS", Type: String, Description: "The replication address of the recovery native HA group"},
+	{Name: "MQ_NATIVE_HA_GROUP_RECOVERY_ENABLED", Type: Bool, Default: "true", Description: "Enables replication to the recovery native HA group"},
+	{Name: "MQ_NATIVE_HA_GROUP_CIPHERSPEC", Type: String, Description: "The CipherSpec used for native HA group replication"},
+	{Name: "MQ_LOGGING_CONSOLE_SOURCE", Type: List, Default: "qmgr,web", Allowed: []string{"qmgr", "web", "mqsc", "event"}, Description: "The sources of logs mirrored to stdout"},
+	{Name: "MQ_LOGGING_CONSOLE_FORMAT", Type: Enum, Default: "basic", Allowed: []string{"basic", "json"}, Description: "The format of the logs printed on stdout"},
+	{Name: "MQ_LOGGING_CONSOLE_EXCLUDE_ID", Type: List, Default: defaultExcludeIDs, Description: "The IDs of messages which are not printed on stdout"},
+	{Name: "MQ_LOGGING_CONSOLE_QMGR_MIN_SEVERITY", Type: Enum, Allowed: []string{"info", "warning", "error"}, Description: "The lowest severity of queue manager log messages printed on stdout"},
+	{Name: "MQ_LOGGING_CONSOLE_QMGR_INCLUDE_ID", Type: List, Description: "The IDs of queue manager log messages printed on stdout regardless of their severity"},
+	{Name: "MQ_LOGGING_CONSOLE_WEB_MIN_SEVERITY", Type: Enum, Allowed: []string{"info", "warning", "error"}, Description: "The lowest severity of web server log messages printed on stdout"},
+	{Name: "MQ_LOGGING_CONSOLE_WEB_INCLUDE_ID", Type: List, Description: "The IDs of web server log messages printed on stdout regardless of their severity"},
+	{Name: "MQ_LOGGING_CONSOLE_MQSC_MIN_SEVERITY", Type: Enum, Allowed: []string{"info", "warning", "error"}, Description: "The lowest severity of MQSC log messages printed on stdout"},
+	{Name: "MQ_LOGGING_CONSOLE_MQSC_INCLUDE_ID", Type: List, Description: "The IDs of MQSC log messages printed on stdout regardless of their severity"},
+	{Name: "MQ_LOGGING_SYSLOG_ADDRESS", Type: String, Description: "The address of a syslog server to forward logs to, such as tcp://host:514"},
+	{Name: "MQ_LOGGING_SYSLOG_FACILITY", Type: Enum, Default: "user", Allowed: []string{"user", "daemon", "local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7"}, Description: "The syslog facility used for forwarded logs"},
+	{Name: "MQ_LOGGING_EVENT_QUEUES", Type: List, Default: defaultEventQueues, Description: "The event queues mirrored to stdout"},
+	{Name: "MQ_LOGGING_METRICS_AUDIT_ENABLED", Type: Bool, Default: "false", Description: "Enables audit logging of access to the metrics endpoint"},
+	{Name: "MQ_ENABLE_METRICS", Type: Bool, Default: "false", Description: "Enables Prometheus metrics"},
+	{Name: "MQ_METRICS_QUEUES", Type: List, Description: "The queues to generate queue metrics for"},
+	{Name: "MQ_METRICS_CHANNELS", Type: List, Description: "The channels to generate channel metrics for"},
+	{Name: "MQ_METRICS_SELECTION", Type: List, Description: "Metric name patterns to enable, or to disable if they start with '!'"},
+	{Name: "MQ_METRICS_EXPORT_UNMAPPED", Type: Bool, Default: "false", Description: "Exports metrics which have no mapped name, using generated names"},
+	{Name: "MQ_ENABLE_PROBE_SERVER", Type: Bool, Default: "false", Description: "Serves liveness, readiness and startup probes over HTTP"},
+	{Name: "MQ_PROBE_PORT", Type: Port, Default: "9158", Description: "The port used by the HTTP probe server"},
+	{Name: "MQ_OTLP_ENDPOINT", Type: String, Description: "The URL of an OpenTelemetry collector to export metrics and logs to using OTLP/HTTP, such as http://collector:4318"},
+	{Name: "MQ_OTLP_SIGNALS", Type: List, Default: "metrics,logs", Allowed: []string{"metrics", "logs"}, Description: "The signals exported to the OpenTelemetry collector"},
+	{Name: "MQ_OTLP_EXPORT_INTERVAL", Type: PositiveInteger, Default: "60", Description: "The number of seconds between exports of metrics to the OpenTelemetry collector"},
+	{Name: "MQ_ENABLE_EMBEDDED_WEB_SERVER", Type: Bool, Default: "false", Description: "Enables the MQ web server"},
+	{Name: "MQ_ENABLE_EMBEDDED_WEB_SERVER_LOG", Type: Bool, Default: "false", Description: "Mirrors the web server log to stdout"},
+	{Name: "MQ_ENABLE_CLEAN_TMP_ON_START", Type: Bool, Default: "true", Description: "Deletes the contents of /tmp on startup"},
+	{Name: "MQ_ENABLE_SOFT_FILE_LIMIT_INCREASE", Type: Bool, Default: "true", Description: "Increases the soft limit for open files up to the hard limit"},
+	{Name: "MQ_ENABLE_TRACE_CRTMQDIR", Type: Bool, Default: "false", Description: "Enables MQ trace of crtmqdir"},
+	{Name: "MQ_ENABLE_TRACE_CRTMQM", Type: Bool, Default: "false", Description: "Enables MQ trace of crtmqm"},
+	{Name: "MQ_ENABLE_TRACE_STRMQM", Type: Bool, Default: "false", Description: "Enables MQ trace of strmqm"},
+	{Name: "MQ_ENABLE_TLS_RELOAD", Type: Bool, Default: "false", Description: "Reloads the queue manager TLS keys when they change"},
+	{Name: "MQ_ENABLE_QMINI_OVERLAY", Type: Bool, Default: "false", Description: "Merges the INI files in /etc/mqm/qm.ini.d into the qm.ini on every start"},
+	{Name: "MQ_ENABLE_FIPS", Type: Enum, Default: "auto", Allowed: []string{"true", "false", "1", "0", "auto"}, Description: "Enables FIPS cryptography"},
+	{Name: "MQ_ENABLE_CERT_VALIDATION", Type: Bool, Default: "true", Description: "Checks the queue manager certificate is not the same as its issuer"},
+	{Name: "MQ_GENERATE_CERTIFICATE_HOSTNAME", Type: String, Description: "The host name of a self-signed certificate generated for the web server"},
+	{Name: "MQ_OVERRIDE_DATA_PATH", Type: String, Description: "Set by the image"},
+	{Name: "MQ_OVERRIDE_INSTALLATION_NAME", Type: String, Description: "Set by the image"},
+	{Name: "MQ_USER_NAME", Type: String, Description: "Set by the image"},
+}
+
+// kubernetesServiceVariable matches the variables which Kubernetes sets for services, which might have an MQ_ prefix
+var kubernetesServiceVariable = regexp.MustCompile(`_(SERVICE_HOST|SERVICE_PORT(_.+)?|PORT(_\d+_(TCP|UDP|SCTP)(_PROTO|_PORT|_ADDR)?)?)$`)
+
+// Lookup returns the registered variable with the specified name
+func Lookup(name string) (Variable, bool) {
+	for _, v := range Variables {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Variable{}, false
+}
+
+// Check returns an error if the value isn't valid for the variable's type
+func (v Variable) Check(value string) error {
+	switch v.Type {
+	case Bool:
+		if !slices.Contains([]string{"true", "false", "1", "0"}, value) {
+			return fmt.Errorf("invalid value '%v'. Allowed values are 'true' and 'false'", value)
+		}
+	case Integer:
+		if _, err := ParseInteger(value); err != nil {
+			return err
+		}
+	case PositiveInteger:
+		if _, err := ParsePositiveInteger(value); err != nil {
+			return err
+		}
+	case Port:
+		if _, err := ParsePort(value); err != nil {
+			return err
+		}
+	case Enum:
+		if !v.allowed(strings.TrimSpace(value)) {
+			return fmt.Errorf("invalid value '%v'. Allowed values are '%v'", value, strings.Join(v.Allowed, "', '"))
+		}
+	case List:
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item != "" && len(v.Allowed) > 0 && !v.allowed(item) {
+				return fmt.Errorf("invalid value '%v'. Allowed values are '%v'", item, strings.Join(v.Allowed, "', '"))
+			}
+		}
+	}
+	if v.Validator != nil {
+		return v.Validator(value)
+	}
+	return nil
+}
+
+// ParseInteger parses the value of an Integer variable
+func ParseInteger(value string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid value '%v'. The value must be a non-negative integer", value)
+	}
+	return n, nil
+}
+
+// ParsePositiveInteger parses the value of a PositiveInteger variable
+func ParsePositiveInteger(value string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid value '%v'. The value must be a positive integer", value)
+	}
+	return n, nil
+}
+
+// ParsePort parses the value of a Port variable
+func ParsePort(value string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 1 || n > 65535 {
+		return 0, fmt.Errorf("invalid value '%v'. The value must be a port number between 1 and 65535", value)
+	}
+	return n, nil
+}
+
+// validateCommandTimeouts checks the value of MQ_COMMAND_TIMEOUTS, in the same way as when the timeouts are read
+func validateCommandTimeouts(value string) error {
+	_, err := command.ParseTimeouts(value)
+	return err
+}
+
+func (v Variable) allowed(value string) bool {
+	for _, a := range v.Allowed {
+		if strings.EqualFold(a, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the supplied environment, in the form returned by os.Environ, and returns any problems
+// found.  Values which are invalid for a registered variable are errors.  Deprecated variables, and
+// variables with an MQ_ prefix which aren't registered, are warnings.
+func Validate(environ []string) []Problem {
+	problems := []Problem{}
+	for _, e := range environ {
+		name, value, _ := strings.Cut(e, "=")
+		v, ok := Lookup(name)
+		if !ok {
+			if strings.HasPrefix(name, "MQ_") && !kubernetesServiceVariable.MatchString(name) {
+				message := "unknown environment variable"
+				if suggestion := suggest(name); suggestion != "" {
+					message = fmt.Sprintf("%v. Did you mean %v?", message, suggestion)
+				}
+				problems = append(problems, Problem{Name: name, Message: message, Warning: true})
+			}
+			continue
+		}
+		if v.Deprecated != "" {
+			problems = append(problems, Problem{Name: name, Message: "deprecated, " + v.Deprecated, Warning: true})
+		}
+		if value == "" {
+			continue
+		}
+		err := v.Check(value)
+		if err != nil {
+			problems = append(problems, Problem{Name: name, Message: err.Error()})
+		}
+	}
+	return problems
+}
+
+// suggest returns the registered variable with the name closest to the specified name, if there is one which is close
+func suggest(name string) string {
+	const maxDistance = 3
+	best := ""
+	bestDistance := maxDistance + 1
+	for _, v := range Variables {
+		d := distance(name, v.Name)
+		if d < bestDistance {
+			best = v.Name
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// distance returns the Levenshtein distance between two strings
+func distance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,87 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package envvars
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		environ  []string
+		expected []Problem
+	}{
+		{
+			name:     "Valid",
+			environ:  []string{"PATH=/usr/bin", "MQ_QMGR_NAME=QM1", "MQ_ENABLE_METRICS=true", "MQ_GRACE_PERIOD=60", "MQ_LOGGING_CONSOLE_SOURCE=qmgr, web,event", "MQ_QMGR_ENDED_POLICY=Restart", "MQ_CMDLEVEL="},
+			expected: []Problem{},
+		},
+		{
+			name:    "InvalidValues",
+			environ: []string{"MQ_ENABLE_METRICS=yes", "MQ_GRACE_PERIOD=30s", "MQ_DRAIN_TIMEOUT=0", "MQ_PROBE_PORT=65536", "MQ_COMMAND_TIMEOUTS=crtmqm", "MQ_LOGGING_CONSOLE_SOURCE=qmgr,wbe", "MQ_QMGR_ENDED_POLICY=stop"},
+			expected: []Problem{
+				{Name: "MQ_ENABLE_METRICS", Message: "invalid value 'yes'. Allowed values are 'true' and 'false'"},
+				{Name: "MQ_GRACE_PERIOD", Message: "invalid value '30s'. The value must be a positive integer"},
+				{Name: "MQ_DRAIN_TIMEOUT", Message: "invalid value '0'. The value must be a positive integer"},
+				{Name: "MQ_PROBE_PORT", Message: "invalid value '65536'. The value must be a port number between 1 and 65535"},
+				{Name: "MQ_COMMAND_TIMEOUTS", Message: "each item must be a command name and a number of seconds, such as 'crtmqm=600', not 'crtmqm'"},
+				{Name: "MQ_LOGGING_CONSOLE_SOURCE", Message: "invalid value 'wbe'. Allowed values are 'qmgr', 'web', 'mqsc', 'event'"},
+				{Name: "MQ_QMGR_ENDED_POLICY", Message: "invalid value 'stop'. Allowed values are 'none', 'exit', 'restart'"},
+			},
+		},
+		{
+			name:    "Unknown",
+			environ: []string{"MQ_ENABLE_METRIC=true", "MQ_SOMETHING_ELSE=1", "MQ_SERVICE_HOST=10.0.0.1", "MQ_PORT_1414_TCP_ADDR=10.0.0.1", "OTHER_VAR=1"},
+			expected: []Problem{
+				{Name: "MQ_ENABLE_METRIC", Message: "unknown environment variable. Did you mean MQ_ENABLE_METRICS?", Warning: true},
+				{Name: "MQ_SOMETHING_ELSE", Message: "unknown environment variable", Warning: true},
+			},
+		},
+		{
+			name:    "Deprecated",
+			environ: []string{"LOG_FORMAT=json"},
+			expected: []Problem{
+				{Name: "LOG_FORMAT", Message: "deprecated, use MQ_LOGGING_CONSOLE_FORMAT instead", Warning: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := Validate(tt.environ)
+			if !reflect.DeepEqual(problems, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, problems)
+			}
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	seen := map[string]bool{}
+	for _, v := range Variables {
+		if seen[v.Name] {
+			t.Errorf("%v is registered more than once", v.Name)
+		}
+		seen[v.Name] = true
+		if v.Default != "" {
+			err := v.Check(v.Default)
+			if err != nil {
+				t.Errorf("Default value of %v is invalid: %v", v.Name, err)
+			}
+		}
+	}
+}
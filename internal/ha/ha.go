@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2020, 2024
+© Copyright IBM Corporation 2020, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -18,8 +18,12 @@ limitations under the License.
 package ha
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"slices"
 
 	"github.com/ibm-messaging/mq-container/internal/fips"
 	"github.com/ibm-messaging/mq-container/internal/mqtemplate"
@@ -28,17 +32,52 @@ import (
 )
 
 // ConfigureNativeHA configures native high availability
-func ConfigureNativeHA(log *logger.Logger) error {
+func ConfigureNativeHA(ctx context.Context, log *logger.Logger) error {
 	if os.Getenv("MQ_NATIVE_HA") != "true" {
 		return nil
 	}
 	fipsAvailable := fips.IsFIPSEnabled()
 
-	haCertLabel, haGroupCertLabel, _, _, err := tls.ConfigureHATLSKeystore(log)
+	haCertLabel, haGroupCertLabel, _, _, err := tls.ConfigureHATLSKeystore(ctx, log)
 	if err != nil {
 		return fmt.Errorf("error loading tls keys: %w", err)
 	}
 
+	configFiles := getConfigFiles(haCertLabel, haGroupCertLabel, log)
+	return loadConfigAndGenerate(configFiles, fipsAvailable, haCertLabel, haGroupCertLabel, log)
+}
+
+// RenderNativeHA writes the INI files which ConfigureNativeHA would generate to w, each preceded by a
+// comment naming the file.  The keystore in use by a running queue manager is left unchanged.
+func RenderNativeHA(ctx context.Context, w io.Writer, log *logger.Logger) error {
+	if os.Getenv("MQ_NATIVE_HA") != "true" {
+		return nil
+	}
+	haCertLabel, haGroupCertLabel, err := tls.GetHATLSCertificateLabels(ctx, log)
+	if err != nil {
+		return fmt.Errorf("error loading tls keys: %w", err)
+	}
+	cfg, err := loadConfigFromEnv(log)
+	if err != nil {
+		return err
+	}
+	err = cfg.updateTLS(fips.IsFIPSEnabled(), haCertLabel, haGroupCertLabel)
+	if err != nil {
+		return err
+	}
+	configFiles := getConfigFiles(haCertLabel, haGroupCertLabel, log)
+	for _, outputPath := range slices.Sorted(maps.Keys(configFiles)) {
+		fmt.Fprintf(w, "# %v\n", outputPath)
+		err = mqtemplate.ProcessTemplate(configFiles[outputPath], w, cfg, log)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getConfigFiles returns the INI files to generate, mapped to the template for each one
+func getConfigFiles(haCertLabel, haGroupCertLabel string, log *logger.Logger) map[string]string {
 	configFiles := map[string]string{
 		"/run/10-native-ha-instance.ini": "/etc/mqm/10-native-ha-instance.ini.tpl",
 	}
@@ -49,7 +88,7 @@ func ConfigureNativeHA(log *logger.Logger) error {
 		log.Println("Configuring Native HA using values provided in environment variables")
 		configFiles["/run/10-native-ha.ini"] = "/etc/mqm/10-native-ha.ini.tpl"
 	}
-	return loadConfigAndGenerate(configFiles, fipsAvailable, haCertLabel, haGroupCertLabel, log)
+	return configFiles
 }
 
 func loadConfigAndGenerate(templateConfigs map[string]string, fipsAvailable bool, haCertLabel, haGroupCertLabel string, log *logger.Logger) error {
@@ -0,0 +1,191 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hooks runs the executables supplied in a hooks directory at defined phases of the container's lifecycle
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+)
+
+// Phase is a point in the container's lifecycle at which hooks are run
+type Phase string
+
+const (
+	// PreCreate hooks run before the queue manager is created
+	PreCreate Phase = "pre-create"
+	// PostCreate hooks run after the queue manager is created, or an existing queue manager is found
+	PostCreate Phase = "post-create"
+	// PostStart hooks run after the queue manager is started, before the container is marked as ready
+	PostStart Phase = "post-start"
+	// PreStop hooks run when the container is stopped, before the queue manager is stopped
+	PreStop Phase = "pre-stop"
+)
+
+// Phases lists all the phases, in the order they are run
+var Phases = []Phase{PreCreate, PostCreate, PostStart, PreStop}
+
+// waitDelay is the time allowed for a hook's output to be closed after it has ended or been killed
+const waitDelay = 5 * time.Second
+
+// Config describes how hooks are run
+type Config struct {
+	// Dir is the directory containing a sub-directory of hooks for each phase, such as "pre-create.d"
+	Dir string
+	// Timeout is the time allowed for each hook to run
+	Timeout time.Duration
+	// Env is added to the container's environment when running each hook
+	Env []string
+	// StopOnFailure prevents the remaining hooks in the phase from running once a hook fails
+	StopOnFailure bool
+}
+
+// Find returns the path of each hook in the phase's directory, in lexical order.  Hidden files, directories
+// and files which aren't executable are skipped.  There are no hooks if the directory doesn't exist.
+func Find(dir string, phase Phase) ([]string, error) {
+	phaseDir := filepath.Join(dir, string(phase)+".d")
+	entries, err := os.ReadDir(phaseDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	paths := []string{}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(phaseDir, entry.Name())
+		// Follow symbolic links, such as those created for a mounted ConfigMap
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Run runs the hooks for a phase in turn, logging their output.  An error is returned describing each
+// hook which failed or timed out.
+func Run(ctx context.Context, phase Phase, config Config, log *logger.Logger) error {
+	paths, err := Find(config.Dir, phase)
+	if err != nil {
+		return fmt.Errorf("unable to find %v hooks: %w", phase, err)
+	}
+	if len(paths) == 0 {
+		log.Debugf("No %v hooks found", phase)
+		return nil
+	}
+	env := append(os.Environ(), "MQ_HOOK_PHASE="+string(phase))
+	env = append(env, config.Env...)
+	errs := []error{}
+	for _, path := range paths {
+		err := runHook(ctx, phase, path, env, config.Timeout, log)
+		if err != nil {
+			log.Printf("Error: %v", err)
+			errs = append(errs, err)
+			if config.StopOnFailure {
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runHook runs a single hook, logging each line of its output and the time it took
+func runHook(ctx context.Context, phase Phase, path string, env []string, timeout time.Duration, log *logger.Logger) error {
+	hook := filepath.Base(path)
+	log.Printf("Running %v hook %v", phase, hook)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	output := &lineLogger{prefix: fmt.Sprintf("%v/%v: ", phase, hook), log: log}
+	// #nosec G204 - hooks are supplied by the administrator of the container
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Dir = filepath.Dir(path)
+	cmd.Env = env
+	cmd.Stdout = output
+	cmd.Stderr = output
+	// Run the hook in its own process group, so that any processes it starts are killed on timeout
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = waitDelay
+
+	start := time.Now()
+	err := cmd.Run()
+	output.Flush()
+	elapsed := time.Since(start).Round(time.Millisecond)
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		return fmt.Errorf("%v hook %v did not complete within %v", phase, hook, timeout)
+	case err != nil:
+		return fmt.Errorf("%v hook %v failed after %v: %w", phase, hook, elapsed, err)
+	}
+	log.Printf("Completed %v hook %v in %v", phase, hook, elapsed)
+	return nil
+}
+
+// lineLogger is a writer which logs each complete line written to it
+type lineLogger struct {
+	prefix string
+	log    *logger.Logger
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (l *lineLogger) Write(p []byte) (int, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.buffer.Write(p)
+	for {
+		line, err := l.buffer.ReadString('\n')
+		if err != nil {
+			// Keep the partial line until the rest of it is written
+			l.buffer.Reset()
+			l.buffer.WriteString(line)
+			return len(p), nil
+		}
+		l.log.Println(l.prefix + strings.TrimRight(line, "\r\n"))
+	}
+}
+
+// Flush logs any partial line which hasn't been logged yet
+func (l *lineLogger) Flush() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.buffer.Len() > 0 {
+		l.log.Println(l.prefix + l.buffer.String())
+		l.buffer.Reset()
+	}
+}
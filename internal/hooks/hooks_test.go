@@ -0,0 +1,134 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+)
+
+// writeHooks creates the hooks for a phase, and returns the hooks directory
+func writeHooks(t *testing.T, phase Phase, hooks map[string]string) string {
+	dir := t.TempDir()
+	phaseDir := filepath.Join(dir, string(phase)+".d")
+	err := os.Mkdir(phaseDir, 0700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, script := range hooks {
+		mode := os.FileMode(0700)
+		if strings.HasSuffix(name, ".txt") {
+			mode = 0600
+		}
+		err = os.WriteFile(filepath.Join(phaseDir, name), []byte("#!/bin/sh\n"+script+"\n"), mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func newTestLogger(t *testing.T) (*logger.Logger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	log, err := logger.NewLogger(buf, true, false, t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return log, buf
+}
+
+func TestFind(t *testing.T) {
+	dir := writeHooks(t, PreCreate, map[string]string{
+		"20-second": "true",
+		"10-first":  "true",
+		".hidden":   "true",
+		"notes.txt": "true",
+	})
+	paths, err := Find(dir, PreCreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 2 || filepath.Base(paths[0]) != "10-first" || filepath.Base(paths[1]) != "20-second" {
+		t.Errorf("Expected 10-first and 20-second; got %v", paths)
+	}
+	paths, err = Find(dir, PostStart)
+	if err != nil || len(paths) != 0 {
+		t.Errorf("Expected no hooks for missing directory; got %v, %v", paths, err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := writeHooks(t, PostStart, map[string]string{
+		"10-env":     "echo \"$MQ_HOOK_PHASE $MQ_QMGR_NAME\"\necho error >&2",
+		"20-partial": "printf 'no newline'",
+	})
+	log, buf := newTestLogger(t)
+	err := Run(context.Background(), PostStart, Config{Dir: dir, Timeout: 10 * time.Second, Env: []string{"MQ_QMGR_NAME=QM1"}}, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"post-start/10-env: post-start QM1", "post-start/10-env: error", "post-start/20-partial: no newline", "Completed post-start hook 20-partial"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected log to contain %q; got %v", expected, buf.String())
+		}
+	}
+}
+
+func TestRunFailure(t *testing.T) {
+	dir := writeHooks(t, PreCreate, map[string]string{
+		"10-fail":  "exit 3",
+		"20-after": "echo after",
+	})
+	tests := []struct {
+		stopOnFailure bool
+		expectAfter   bool
+	}{
+		{true, false},
+		{false, true},
+	}
+	for _, test := range tests {
+		log, buf := newTestLogger(t)
+		err := Run(context.Background(), PreCreate, Config{Dir: dir, Timeout: 10 * time.Second, StopOnFailure: test.stopOnFailure}, log)
+		if err == nil || !strings.Contains(err.Error(), "exit status 3") {
+			t.Errorf("Expected hook to fail with exit status 3; got %v", err)
+		}
+		if strings.Contains(buf.String(), "pre-create/20-after: after") != test.expectAfter {
+			t.Errorf("Expected later hook to run=%v with StopOnFailure=%v; got %v", test.expectAfter, test.stopOnFailure, buf.String())
+		}
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	dir := writeHooks(t, PreStop, map[string]string{
+		"10-slow": "sleep 30 &\nwait",
+	})
+	log, _ := newTestLogger(t)
+	start := time.Now()
+	err := Run(context.Background(), PreStop, Config{Dir: dir, Timeout: 100 * time.Millisecond}, log)
+	if err == nil || !strings.Contains(err.Error(), "did not complete within") {
+		t.Errorf("Expected timeout error; got %v", err)
+	}
+	if time.Since(start) > waitDelay {
+		t.Errorf("Expected hook and its child processes to be killed on timeout; took %v", time.Since(start))
+	}
+}
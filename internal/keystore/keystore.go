@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2024
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -19,9 +19,9 @@ package keystore
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -84,7 +84,7 @@ func NewPKCS12KeyStore(filename string, password *sensitive.Sensitive) *KeyStore
 }
 
 // Create a key store, if it doesn't already exist
-func (ks *KeyStore) Create() error {
+func (ks *KeyStore) Create(ctx context.Context) error {
 	_, err := os.Stat(ks.Filename)
 	if err == nil {
 		// Keystore already exists so we should refresh it by deleting it.
@@ -117,7 +117,7 @@ func (ks *KeyStore) Create() error {
 	}
 
 	// Create the keystore now we're sure it doesn't exist
-	out, _, err := command.Run(ks.command, "-keydb", "-create", ks.getFipsEnabledFlag(), "-type", ks.keyStoreType, "-db", ks.Filename, "-pw", ks.Password.String(), "-stash")
+	out, _, err := command.RunContext(ctx, ks.command, "-keydb", "-create", ks.getFipsEnabledFlag(), "-type", ks.keyStoreType, "-db", ks.Filename, "-pw", ks.Password.String(), "-stash")
 	if err != nil {
 		return fmt.Errorf("error running \"%v -keydb -create\": %v %s", ks.command, err, out)
 	}
@@ -126,13 +126,13 @@ func (ks *KeyStore) Create() error {
 }
 
 // CreateStash creates a key stash, if it doesn't already exist
-func (ks *KeyStore) CreateStash() error {
+func (ks *KeyStore) CreateStash(ctx context.Context) error {
 	extension := filepath.Ext(ks.Filename)
 	stashFile := ks.Filename[0:len(ks.Filename)-len(extension)] + ".sth"
 	_, err := os.Stat(stashFile)
 	if err != nil {
 		if os.IsNotExist(err) {
-			out, _, err := command.Run(ks.command, "-keydb", ks.getFipsEnabledFlag(), "-stashpw", "-type", ks.keyStoreType, "-db", ks.Filename, "-pw", ks.Password.String())
+			out, _, err := command.RunContext(ctx, ks.command, "-keydb", ks.getFipsEnabledFlag(), "-stashpw", "-type", ks.keyStoreType, "-db", ks.Filename, "-pw", ks.Password.String())
 			if err != nil {
 				return fmt.Errorf("error running \"%v -keydb -stashpw\": %v %s", ks.command, err, out)
 			}
@@ -143,8 +143,8 @@ func (ks *KeyStore) CreateStash() error {
 }
 
 // Import imports a certificate file in the keystore
-func (ks *KeyStore) Import(inputFile string, password *sensitive.Sensitive) error {
-	out, _, err := command.Run(ks.command, "-cert", "-import", ks.getFipsEnabledFlag(), "-file", inputFile, "-pw", password.String(), "-target", ks.Filename, "-target_pw", ks.Password.String(), "-target_type", ks.keyStoreType)
+func (ks *KeyStore) Import(ctx context.Context, inputFile string, password *sensitive.Sensitive) error {
+	out, _, err := command.RunContext(ctx, ks.command, "-cert", "-import", ks.getFipsEnabledFlag(), "-file", inputFile, "-pw", password.String(), "-target", ks.Filename, "-target_pw", ks.Password.String(), "-target_type", ks.keyStoreType)
 	if err != nil {
 		return fmt.Errorf("error running \"%v -cert -import\": %v %s", ks.command, err, out)
 	}
@@ -152,8 +152,8 @@ func (ks *KeyStore) Import(inputFile string, password *sensitive.Sensitive) erro
 }
 
 // CreateSelfSignedCertificate creates a self-signed certificate in the keystore
-func (ks *KeyStore) CreateSelfSignedCertificate(label, dn, hostname string) error {
-	out, _, err := command.Run(ks.command, "-cert", "-create", ks.getFipsEnabledFlag(), "-db", ks.Filename, "-pw", ks.Password.String(), "-label", label, "-dn", dn, "-san_dnsname", hostname, "-size 2048 -sig_alg sha512 -eku serverAuth")
+func (ks *KeyStore) CreateSelfSignedCertificate(ctx context.Context, label, dn, hostname string) error {
+	out, _, err := command.RunContext(ctx, ks.command, "-cert", "-create", ks.getFipsEnabledFlag(), "-db", ks.Filename, "-pw", ks.Password.String(), "-label", label, "-dn", dn, "-san_dnsname", hostname, "-size 2048 -sig_alg sha512 -eku serverAuth")
 	if err != nil {
 		return fmt.Errorf("error running \"%v -cert -create\": %v %s", ks.command, err, out)
 	}
@@ -161,8 +161,8 @@ func (ks *KeyStore) CreateSelfSignedCertificate(label, dn, hostname string) erro
 }
 
 // Add adds a CA certificate to the keystore
-func (ks *KeyStore) Add(inputFile, label string) error {
-	out, _, err := command.Run(ks.command, "-cert", "-add", ks.getFipsEnabledFlag(), "-db", ks.Filename, "-type", ks.keyStoreType, "-pw", ks.Password.String(), "-file", inputFile, "-label", label)
+func (ks *KeyStore) Add(ctx context.Context, inputFile, label string) error {
+	out, _, err := command.RunContext(ctx, ks.command, "-cert", "-add", ks.getFipsEnabledFlag(), "-db", ks.Filename, "-type", ks.keyStoreType, "-pw", ks.Password.String(), "-file", inputFile, "-label", label)
 	if err != nil {
 		return fmt.Errorf("error running \"%v -cert -add\": %v %s", ks.command, err, out)
 	}
@@ -170,8 +170,8 @@ func (ks *KeyStore) Add(inputFile, label string) error {
 }
 
 // Add adds a CA certificate to the keystore
-func (ks *KeyStore) AddNoLabel(inputFile string) error {
-	out, _, err := command.Run(ks.command, "-cert", "-add", ks.getFipsEnabledFlag(), "-db", ks.Filename, "-type", ks.keyStoreType, "-pw", ks.Password.String(), "-file", inputFile)
+func (ks *KeyStore) AddNoLabel(ctx context.Context, inputFile string) error {
+	out, _, err := command.RunContext(ctx, ks.command, "-cert", "-add", ks.getFipsEnabledFlag(), "-db", ks.Filename, "-type", ks.keyStoreType, "-pw", ks.Password.String(), "-file", inputFile)
 	if err != nil {
 		return fmt.Errorf("error running \"%v -cert -add\": %v %s", ks.command, err, out)
 	}
@@ -179,8 +179,8 @@ func (ks *KeyStore) AddNoLabel(inputFile string) error {
 }
 
 // GetCertificateLabels returns the labels of all certificates in the key store
-func (ks *KeyStore) GetCertificateLabels() ([]string, error) {
-	out, _, err := command.Run(ks.command, "-cert", "-list", ks.getFipsEnabledFlag(), "-type", ks.keyStoreType, "-db", ks.Filename, "-pw", ks.Password.String())
+func (ks *KeyStore) GetCertificateLabels(ctx context.Context) ([]string, error) {
+	out, _, err := command.RunContext(ctx, ks.command, "-cert", "-list", ks.getFipsEnabledFlag(), "-type", ks.keyStoreType, "-db", ks.Filename, "-pw", ks.Password.String())
 	if err != nil {
 		return nil, fmt.Errorf("error running \"%v -cert -list\": %v %s", ks.command, err, out)
 	}
@@ -201,7 +201,7 @@ func (ks *KeyStore) GetCertificateLabels() ([]string, error) {
 }
 
 // RenameCertificate renames the specified certificate
-func (ks *KeyStore) RenameCertificate(from, to string) error {
+func (ks *KeyStore) RenameCertificate(ctx context.Context, from, to string) error {
 	if ks.command == "/opt/mqm/bin/runmqakm" {
 		// runmqakm can't handle certs with ' in them so just use capicmd or certutil
 		var gskitLib, gskitCommand string
@@ -219,16 +219,12 @@ func (ks *KeyStore) RenameCertificate(from, to string) error {
 		if err != nil {
 			return err
 		}
-		// Overriding gosec here as this function is in an internal package and only callable by our internal functions.
-		// #nosec G204
-		cmd := exec.Command(gskitCommand, "-cert", "-rename", "-db", ks.Filename, "-pw", ks.Password.String(), "-label", from, "-new_label", to)
-		cmd.Env = append(os.Environ(), "LD_LIBRARY_PATH="+gskitLib)
-		out, err := cmd.CombinedOutput()
+		out, _, err := command.RunWithEnv(ctx, []string{"LD_LIBRARY_PATH=" + gskitLib}, gskitCommand, "-cert", "-rename", "-db", ks.Filename, "-pw", ks.Password.String(), "-label", from, "-new_label", to)
 		if err != nil {
 			return fmt.Errorf("error running \"%v -cert -rename\": %v %s", gskitCommand, err, out)
 		}
 	} else {
-		out, _, err := command.Run(ks.command, "-cert", "-rename", "-db", ks.Filename, "-pw", ks.Password.String(), "-label", from, "-new_label", to)
+		out, _, err := command.RunContext(ctx, ks.command, "-cert", "-rename", "-db", ks.Filename, "-pw", ks.Password.String(), "-label", from, "-new_label", to)
 		if err != nil {
 			return fmt.Errorf("error running \"%v -cert -rename\": %v %s", ks.command, err, out)
 		}
@@ -237,8 +233,8 @@ func (ks *KeyStore) RenameCertificate(from, to string) error {
 }
 
 // ListAllCertificates Lists all certificates in the keystore
-func (ks *KeyStore) ListAllCertificates() ([]string, error) {
-	out, _, err := command.Run(ks.command, "-cert", "-list", ks.getFipsEnabledFlag(), "-type", ks.keyStoreType, "-db", ks.Filename, "-pw", ks.Password.String())
+func (ks *KeyStore) ListAllCertificates(ctx context.Context) ([]string, error) {
+	out, _, err := command.RunContext(ctx, ks.command, "-cert", "-list", ks.getFipsEnabledFlag(), "-type", ks.keyStoreType, "-db", ks.Filename, "-pw", ks.Password.String())
 	if err != nil {
 		return nil, fmt.Errorf("error running \"%v -cert -list\": %v %s", ks.command, err, out)
 	}
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2025
+© Copyright IBM Corporation 2025, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -46,15 +46,7 @@ func passthroughHandlerFuncWrapper(base http.HandlerFunc) http.HandlerFunc {
 func newAuditingHandlerFuncWrapper(qmName string, logger logHandler) handlerFuncWrapper {
 	return func(base http.HandlerFunc) http.HandlerFunc {
 		return func(w http.ResponseWriter, req *http.Request) {
-			podName, _ := os.Hostname()
-			event := auditEvent{
-				Timestamp:        time.Now().UTC().Format(time.RFC3339),
-				Event:            "metrics",
-				Pod:              podName,
-				Endpoint:         req.URL.RequestURI(),
-				QueuemanagerName: qmName,
-				RemoteAddr:       req.RemoteAddr,
-			}
+			event := newAuditEvent(qmName, req.RemoteAddr, req.URL.RequestURI())
 
 			capWriter := newStatusCapturingResponseWriter(w)
 			base(capWriter, req)
@@ -62,16 +54,41 @@ func newAuditingHandlerFuncWrapper(qmName string, logger logHandler) handlerFunc
 			event.StatusCode = statusCode
 			event.Result = http.StatusText(statusCode)
 
-			eventBytes, err := json.Marshal(event)
-			if err != nil {
-				logger.Append(fmt.Sprintf("Error writing audit log; next event may contain incomplete data: %s", err.Error()), false)
-				syncwriter.For(os.Stderr).Printf("Error constructing audit log event: %s\n", err.Error())
-			}
-			logger.Append(string(eventBytes), false)
+			appendAuditEvent(logger, event)
 		}
 	}
 }
 
+// auditFailure logs an audit entry for a request which was rejected before it reached a handler, such as a
+// connection with an invalid client certificate
+func auditFailure(qmName string, logger logHandler, remoteAddr string, endpoint string, statusCode int, result string) {
+	event := newAuditEvent(qmName, remoteAddr, endpoint)
+	event.StatusCode = statusCode
+	event.Result = result
+	appendAuditEvent(logger, event)
+}
+
+func newAuditEvent(qmName string, remoteAddr string, endpoint string) auditEvent {
+	podName, _ := os.Hostname()
+	return auditEvent{
+		Timestamp:        time.Now().UTC().Format(time.RFC3339),
+		Event:            "metrics",
+		Pod:              podName,
+		Endpoint:         endpoint,
+		QueuemanagerName: qmName,
+		RemoteAddr:       remoteAddr,
+	}
+}
+
+func appendAuditEvent(logger logHandler, event auditEvent) {
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		logger.Append(fmt.Sprintf("Error writing audit log; next event may contain incomplete data: %s", err.Error()), false)
+		syncwriter.For(os.Stderr).Printf("Error constructing audit log event: %s\n", err.Error())
+	}
+	logger.Append(string(eventBytes), false)
+}
+
 // wrappedHandler implements http.Handler using a stored http.HandlerFunc for the ServeHTTP method
 type wrappedHandler struct {
 	handlerFunc http.HandlerFunc
@@ -0,0 +1,161 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// clientCADirMetrics is the location of the CA certificates used to verify client certificates for HTTPS metrics
+	clientCADirMetrics = "/etc/mqm/metrics/pki/clientca"
+
+	// tokenFileMetrics is the location of the bearer token which must be sent to access the metrics
+	tokenFileMetrics = "/etc/mqm/metrics/auth/token"
+)
+
+// loadClientCAs loads the CA certificates used to verify client certificates from ca.crt in the
+// specified directory.  If the file does not exist, client certificates are not required, and nil is returned.
+func loadClientCAs(clientCADir string) (*x509.CertPool, error) {
+	caFile := filepath.Join(clientCADir, "ca.crt")
+	caPEM, err := os.ReadFile(filepath.Clean(caFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file (%s): %w", caFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in client CA file (%s)", caFile)
+	}
+	return pool, nil
+}
+
+// requireClientCertificates configures the TLS server to require a client certificate signed by one of the
+// client CAs.  This is equivalent to using tls.RequireAndVerifyClientCert, except that the certificate is
+// verified after the handshake has started, so that a rejected client can be recorded in the audit log along
+// with its remote address.
+func requireClientCertificates(config *tls.Config, clientCAs *x509.CertPool, qmName string, auditLog logHandler) {
+	config.ClientAuth = tls.RequestClientCert
+	config.ClientCAs = clientCAs
+	base := config.Clone()
+	config.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+		remoteAddr := ""
+		if hello.Conn != nil {
+			remoteAddr = hello.Conn.RemoteAddr().String()
+		}
+		connConfig := base.Clone()
+		connConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			err := verifyClientCertificate(cs, clientCAs)
+			if err != nil && auditLog != nil {
+				auditFailure(qmName, auditLog, remoteAddr, "", http.StatusUnauthorized, "Client certificate rejected: "+err.Error())
+			}
+			return err
+		}
+		return connConfig, nil
+	}
+}
+
+// verifyClientCertificate checks that the client sent a certificate for client authentication, signed by one of the client CAs
+func verifyClientCertificate(cs tls.ConnectionState, clientCAs *x509.CertPool) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("no client certificate provided")
+	}
+	opts := x509.VerifyOptions{
+		Roots:         clientCAs,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
+
+// checkBearerTokenFile checks that the bearer token file exists and is not empty.  It returns false if the
+// file does not exist, in which case a bearer token is not required.
+func checkBearerTokenFile(tokenFile string) (bool, error) {
+	token, err := readBearerToken(tokenFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if len(token) == 0 {
+		return false, fmt.Errorf("bearer token file (%s) is empty", tokenFile)
+	}
+	return true, nil
+}
+
+// readBearerToken reads the bearer token, ignoring any surrounding white space
+func readBearerToken(tokenFile string) ([]byte, error) {
+	token, err := os.ReadFile(filepath.Clean(tokenFile))
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(token), nil
+}
+
+// newBearerTokenHandlerFuncWrapper generates a handlerFuncWrapper which only calls the base handler if the request
+// has an Authorization header containing the bearer token.  The token is read for each request, so that it can be
+// changed without restarting the container.
+func newBearerTokenHandlerFuncWrapper(tokenFile string) handlerFuncWrapper {
+	return func(base http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			token, err := readBearerToken(tokenFile)
+			if err != nil || len(token) == 0 {
+				http.Error(w, "Unable to verify bearer token", http.StatusInternalServerError)
+				return
+			}
+			auth := req.Header.Get("Authorization")
+			scheme, provided, found := strings.Cut(auth, " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(provided)), token) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="metrics", error="invalid_token"`)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			base(w, req)
+		}
+	}
+}
+
+// chainHandlerFuncWrappers combines handlerFuncWrappers, with the first wrapper outermost
+func chainHandlerFuncWrappers(wrappers ...handlerFuncWrapper) handlerFuncWrapper {
+	return func(base http.HandlerFunc) http.HandlerFunc {
+		for i := len(wrappers) - 1; i >= 0; i-- {
+			base = wrappers[i](base)
+		}
+		return base
+	}
+}
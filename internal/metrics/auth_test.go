@@ -0,0 +1,238 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	metricstest "github.com/ibm-messaging/mq-container/internal/metrics/test"
+)
+
+// syncAuditTestLogger is an auditTestLogger which can be used by concurrent connections
+type syncAuditTestLogger struct {
+	mu   sync.Mutex
+	logs []auditEvent
+}
+
+func (a *syncAuditTestLogger) Append(messageLine string, deduplicateLine bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	event := auditEvent{}
+	// #nosec G104
+	json.Unmarshal([]byte(messageLine), &event)
+	a.logs = append(a.logs, event)
+}
+
+func (a *syncAuditTestLogger) events() []auditEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]auditEvent{}, a.logs...)
+}
+
+func TestLoadClientCAs(t *testing.T) {
+	dir := t.TempDir()
+	pool, err := loadClientCAs(dir)
+	if err != nil || pool != nil {
+		t.Fatalf("Expected no client CAs when ca.crt does not exist; got %v, %v", pool, err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "ca.crt"), []byte("not PEM"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = loadClientCAs(dir)
+	if err == nil {
+		t.Error("Expected an error for an invalid client CA file")
+	}
+
+	caCert, _, _, err := metricstest.GenerateTestKeys(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = metricstest.WriteCertsToDir(caCert, nil, nil, dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool, err = loadClientCAs(dir)
+	if err != nil || pool == nil {
+		t.Errorf("Expected client CAs to be loaded; got %v, %v", pool, err)
+	}
+}
+
+func TestCheckBearerTokenFile(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	enabled, err := checkBearerTokenFile(tokenFile)
+	if err != nil || enabled {
+		t.Errorf("Expected bearer token to be disabled when the file does not exist; got %v, %v", enabled, err)
+	}
+	err = os.WriteFile(tokenFile, []byte(" \n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = checkBearerTokenFile(tokenFile)
+	if err == nil {
+		t.Error("Expected an error for an empty token file")
+	}
+	err = os.WriteFile(tokenFile, []byte("secret\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enabled, err = checkBearerTokenFile(tokenFile)
+	if err != nil || !enabled {
+		t.Errorf("Expected bearer token to be enabled; got %v, %v", enabled, err)
+	}
+}
+
+func TestBearerTokenHandler(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	err := os.WriteFile(tokenFile, []byte("secret\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name          string
+		authorization string
+		statusCode    int
+	}{
+		{"NoHeader", "", http.StatusUnauthorized},
+		{"WrongScheme", "Basic c2VjcmV0", http.StatusUnauthorized},
+		{"WrongToken", "Bearer secret2", http.StatusUnauthorized},
+		{"ValidToken", "Bearer secret", http.StatusOK},
+		{"ValidTokenLowerCaseScheme", "bearer secret", http.StatusOK},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger := &auditTestLogger{}
+			wrapper := chainHandlerFuncWrappers(newAuditingHandlerFuncWrapper("QM1", logger), newBearerTokenHandlerFuncWrapper(tokenFile))
+			handler := wrapper(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "http://localhost/metrics", nil)
+			if test.authorization != "" {
+				request.Header.Set("Authorization", test.authorization)
+			}
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.statusCode {
+				t.Errorf("Expected status code %d; got %d", test.statusCode, recorder.Code)
+			}
+			if len(logger.logs) != 1 {
+				t.Fatalf("Expected 1 audit event; got %d", len(logger.logs))
+			}
+			event := auditEvent{}
+			err := json.Unmarshal([]byte(logger.logs[0]), &event)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if event.StatusCode != test.statusCode {
+				t.Errorf("Expected audit status code %d; got %d", test.statusCode, event.StatusCode)
+			}
+		})
+	}
+}
+
+func TestBearerTokenHandlerMissingFile(t *testing.T) {
+	handler := newBearerTokenHandlerFuncWrapper(filepath.Join(t.TempDir(), "token"))(func(w http.ResponseWriter, req *http.Request) {
+		t.Error("Unexpected call to base handler")
+	})
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "http://localhost/metrics", nil)
+	request.Header.Set("Authorization", "Bearer ")
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status code %d; got %d", http.StatusInternalServerError, recorder.Code)
+	}
+}
+
+func TestRequireClientCertificates(t *testing.T) {
+	caCert, certs, keys, err := metricstest.GenerateTestKeys(2, "localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherCerts, otherKeys, err := metricstest.GenerateTestKeys(1, "localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverCert := tls.Certificate{Certificate: [][]byte{certs[0].Raw}, PrivateKey: keys[0]}
+	clientCert := tls.Certificate{Certificate: [][]byte{certs[1].Raw}, PrivateKey: keys[1]}
+	untrustedCert := tls.Certificate{Certificate: [][]byte{otherCerts[0].Raw}, PrivateKey: otherKeys[0]}
+
+	logger := &syncAuditTestLogger{}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{serverCert},
+	}
+	requireClientCertificates(server.TLS, metricstest.MakeCACertPool(caCert), "QM1", logger)
+	server.StartTLS()
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		certs  []tls.Certificate
+		accept bool
+	}{
+		{"TrustedCertificate", []tls.Certificate{clientCert}, true},
+		{"NoCertificate", nil, false},
+		{"UntrustedCertificate", []tls.Certificate{untrustedCert}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before := len(logger.events())
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				RootCAs:      metricstest.MakeCACertPool(caCert),
+				Certificates: test.certs,
+			}}}
+			resp, err := client.Get(strings.Replace(server.URL, "127.0.0.1", "localhost", 1) + "/metrics")
+			if err == nil {
+				resp.Body.Close()
+			}
+			if test.accept {
+				if err != nil || resp.StatusCode != http.StatusOK {
+					t.Fatalf("Expected request to succeed; got %v", err)
+				}
+				if len(logger.events()) != before {
+					t.Error("Unexpected audit event for an accepted client")
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected request to be rejected")
+			}
+			events := logger.events()
+			if len(events) != before+1 {
+				t.Fatalf("Expected an audit event for a rejected client; got %v", events)
+			}
+			event := events[len(events)-1]
+			if event.QueuemanagerName != "QM1" || event.RemoteAddr == "" || event.StatusCode != http.StatusUnauthorized || !strings.HasPrefix(event.Result, "Client certificate rejected") {
+				t.Errorf("Unexpected audit event: %+v", event)
+			}
+		})
+	}
+}
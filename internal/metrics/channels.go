@@ -0,0 +1,275 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ibm-messaging/mq-golang/v5/ibmmq"
+	"github.com/ibm-messaging/mq-golang/v5/mqmetric"
+)
+
+const (
+	// channelStatusKeyPrefix is the prefix of keys for metrics derived from channel status
+	channelStatusKeyPrefix = "CHSTATUS/"
+	// channelLabelValue is the prefix of metric value keys for channels, which are followed by "<type>/<channel>"
+	channelLabelValue = "CHANNEL/"
+)
+
+// Channel status is simplified to one of the following values, so that it can be used in alerts
+const (
+	channelStatusStopped    = 0
+	channelStatusTransition = 1
+	channelStatusRunning    = 2
+)
+
+const (
+	channelStatusMetric     = "status"
+	channelInstancesMetric  = "instances"
+	channelMessagesMetric   = "messages"
+	channelBytesSentMetric  = "bytes_sent"
+	channelBytesRcvdMetric  = "bytes_received"
+	channelBatchesMetric    = "batches"
+	channelSinceMsgMetric   = "time_since_message"
+	channelTypeAMQP         = "AMQP"
+	channelTypeMQTT         = "MQTT"
+	channelTypeUnknownLabel = "UNKNOWN"
+)
+
+// monitoredChannels is a comma-separated list of channel name patterns, for which channel metrics are generated
+var monitoredChannels = ""
+
+// channelMetricLookup describes a metric derived from channel status
+type channelMetricLookup struct {
+	name        string
+	description string
+	isDelta     bool
+}
+
+// generateChannelStatusMetricNamesMap generates the metrics derived from channel status
+func generateChannelStatusMetricNamesMap() map[string]channelMetricLookup {
+	return map[string]channelMetricLookup{
+		channelStatusMetric:    {"status", "Channel status: 0 if stopped or inactive, 1 if starting, stopping or retrying, 2 if running", false},
+		channelInstancesMetric: {"instances", "Number of active instances of the channel", false},
+		channelMessagesMetric:  {"messages_total", "Messages sent or received (MQI calls for SVRCONN channels)", true},
+		channelBytesSentMetric: {"sent_bytes_total", "Bytes sent", true},
+		channelBytesRcvdMetric: {"received_bytes_total", "Bytes received", true},
+		channelBatchesMetric:   {"batches_total", "Completed batches", true},
+		channelSinceMsgMetric:  {"time_since_last_message_seconds", "Time since a message was last sent or received by any instance of the channel", false},
+	}
+}
+
+// getMonitoredChannels returns a comma-separated list of channel name patterns to generate channel metrics for,
+// taken from MQ_METRICS_CHANNELS
+func getMonitoredChannels() (string, error) {
+	var patterns []string
+	for _, pattern := range strings.Split(os.Getenv("MQ_METRICS_CHANNELS"), ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	channels := strings.Join(patterns, ",")
+	if channels == "" {
+		return "", nil
+	}
+	err := mqmetric.VerifyPatterns(channels)
+	if err != nil {
+		return "", fmt.Errorf("Invalid channel patterns in MQ_METRICS_CHANNELS: %v", err)
+	}
+	return channels, nil
+}
+
+// initialiseChannelStatusMetrics sets initial details for metrics derived from channel status
+func initialiseChannelStatusMetrics(metrics map[string]*metricData) {
+	for key, metricLookup := range generateChannelStatusMetricNamesMap() {
+		if !selectedMetrics.enabled(getFullMetricName(metricLookup.name, false, false, true), true) {
+			continue
+		}
+		metrics[channelStatusKeyPrefix+key] = &metricData{
+			name:        metricLookup.name,
+			description: metricLookup.description,
+			isDelta:     metricLookup.isDelta,
+			channelType: true,
+		}
+	}
+}
+
+// rediscoverChannels refreshes the definitions of the monitored channels, which are used to report channels
+// which are not running, and their number of instances
+func rediscoverChannels() error {
+	for _, objectType := range []int32{ibmmq.MQOT_CHANNEL, mqmetric.OT_CHANNEL_AMQP, mqmetric.OT_CHANNEL_MQTT} {
+		err := mqmetric.RediscoverAttributes(objectType, monitoredChannels)
+		if err != nil {
+			return fmt.Errorf("Failed to discover channels: %v", err)
+		}
+	}
+	return nil
+}
+
+// updateChannelStatusMetrics updates values for metrics derived from the status of the monitored channels.
+// The status of each instance of a channel is combined, to give one value for each channel.
+func updateChannelStatusMetrics(metrics map[string]*metricData) error {
+
+	err := mqmetric.CollectChannelStatus(monitoredChannels)
+	if err != nil {
+		return err
+	}
+	err = mqmetric.CollectAMQPChannelStatus(monitoredChannels)
+	if err != nil {
+		return err
+	}
+	err = mqmetric.CollectMQTTChannelStatus(monitoredChannels)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]map[string]float64)
+	for key := range generateChannelStatusMetricNamesMap() {
+		values[key] = make(map[string]float64)
+	}
+	connectionKey := mqmetric.GetConnectionKey()
+	addChannelStatus(values, mqmetric.GetObjectStatus(connectionKey, mqmetric.OT_CHANNEL), "")
+	addChannelStatus(values, mqmetric.GetObjectStatus(connectionKey, mqmetric.OT_CHANNEL_AMQP), channelTypeAMQP)
+	addChannelStatus(values, mqmetric.GetObjectStatus(connectionKey, mqmetric.OT_CHANNEL_MQTT), channelTypeMQTT)
+
+	for key, channelValues := range values {
+		if metric, ok := metrics[channelStatusKeyPrefix+key]; ok {
+			metric.values = channelValues
+		}
+	}
+	return nil
+}
+
+// addChannelStatus combines the status of each channel instance in the supplied status set into the supplied
+// values, which are keyed by metric and then by channel.  If the channel type is empty, it is taken from the status.
+func addChannelStatus(values map[string]map[string]float64, status *mqmetric.StatusSet, channelType string) {
+	names, ok := status.Attributes[mqmetric.ATTR_CHL_NAME]
+	if !ok {
+		return
+	}
+	for key, name := range names.Values {
+		chlType := channelType
+		if chlType == "" {
+			chlType = getChannelTypeName(getStatusInt(status, mqmetric.ATTR_CHL_TYPE, key))
+		}
+		label := channelLabelValue + chlType + "/" + name.ValueString
+
+		chlStatus := getStatusInt(status, mqmetric.ATTR_CHL_STATUS, key)
+		values[channelStatusMetric][label] = max(values[channelStatusMetric][label], simplifyChannelStatus(chlStatus))
+		instances := values[channelInstancesMetric][label]
+		if chlStatus != int64(ibmmq.MQCHS_INACTIVE) {
+			instances++
+		}
+		values[channelInstancesMetric][label] = instances
+
+		// AMQP and MQTT channels report messages sent and received separately
+		values[channelMessagesMetric][label] += getStatusFloat(status, mqmetric.ATTR_CHL_MESSAGES, key) +
+			getStatusFloat(status, mqmetric.ATTR_CHL_AMQP_MESSAGES_SENT, key) +
+			getStatusFloat(status, mqmetric.ATTR_CHL_AMQP_MESSAGES_RECEIVED, key)
+		if channelType == "" {
+			values[channelBytesSentMetric][label] += getStatusFloat(status, mqmetric.ATTR_CHL_BYTES_SENT, key)
+			values[channelBytesRcvdMetric][label] += getStatusFloat(status, mqmetric.ATTR_CHL_BYTES_RCVD, key)
+			values[channelBatchesMetric][label] += getStatusFloat(status, mqmetric.ATTR_CHL_BATCHES, key)
+		}
+
+		// Report the most recent message sent or received by any instance of the channel
+		if getStatusInt(status, mqmetric.ATTR_CHL_SINCE_MSG, key) >= 0 {
+			sinceMsg := getStatusFloat(status, mqmetric.ATTR_CHL_SINCE_MSG, key)
+			current, found := values[channelSinceMsgMetric][label]
+			if !found || sinceMsg < current {
+				values[channelSinceMsgMetric][label] = sinceMsg
+			}
+		}
+	}
+}
+
+// getStatusInt returns the integer value of a status attribute for a channel instance, or -1 if it is not set
+func getStatusInt(status *mqmetric.StatusSet, attr string, key string) int64 {
+	if statusAttribute, ok := status.Attributes[attr]; ok {
+		if value, ok := statusAttribute.Values[key]; ok && value.IsInt64 {
+			return value.ValueInt64
+		}
+	}
+	return -1
+}
+
+// getStatusFloat returns the normalised value of a status attribute for a channel instance, or 0 if it is not set
+func getStatusFloat(status *mqmetric.StatusSet, attr string, key string) float64 {
+	if statusAttribute, ok := status.Attributes[attr]; ok {
+		if value, ok := statusAttribute.Values[key]; ok && value.IsInt64 {
+			return mqmetric.ChannelNormalise(statusAttribute, value.ValueInt64)
+		}
+	}
+	return 0
+}
+
+// simplifyChannelStatus converts an MQCHS_* channel status into one of the simplified channel status values
+func simplifyChannelStatus(status int64) float64 {
+	switch int32(status) {
+	case ibmmq.MQCHS_RUNNING:
+		return channelStatusRunning
+	case ibmmq.MQCHS_INACTIVE, ibmmq.MQCHS_DISCONNECTED, ibmmq.MQCHS_STOPPED, ibmmq.MQCHS_PAUSED:
+		return channelStatusStopped
+	default:
+		if status < 0 {
+			return channelStatusStopped
+		}
+		return channelStatusTransition
+	}
+}
+
+// getChannelTypeName returns the MQSC keyword for an MQCHT_* channel type, for use as a label value
+func getChannelTypeName(channelType int64) string {
+	switch int32(channelType) {
+	case ibmmq.MQCHT_SENDER:
+		return "SDR"
+	case ibmmq.MQCHT_SERVER:
+		return "SVR"
+	case ibmmq.MQCHT_RECEIVER:
+		return "RCVR"
+	case ibmmq.MQCHT_REQUESTER:
+		return "RQSTR"
+	case ibmmq.MQCHT_SVRCONN:
+		return "SVRCONN"
+	case ibmmq.MQCHT_CLNTCONN:
+		return "CLNTCONN"
+	case ibmmq.MQCHT_CLUSRCVR:
+		return "CLUSRCVR"
+	case ibmmq.MQCHT_CLUSSDR:
+		return "CLUSSDR"
+	case ibmmq.MQCHT_AMQP:
+		return channelTypeAMQP
+	case ibmmq.MQCHT_MQTT:
+		return channelTypeMQTT
+	}
+	if channelType < 0 {
+		return channelTypeUnknownLabel
+	}
+	return strconv.FormatInt(channelType, 10)
+}
+
+// splitChannelLabel returns the channel name and type from a channel metric value key
+func splitChannelLabel(label string) (string, string) {
+	parts := strings.SplitN(strings.TrimPrefix(label, channelLabelValue), "/", 2)
+	if len(parts) != 2 {
+		return label, channelTypeUnknownLabel
+	}
+	return parts[1], parts[0]
+}
@@ -0,0 +1,113 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"testing"
+
+	"github.com/ibm-messaging/mq-golang/v5/ibmmq"
+	"github.com/ibm-messaging/mq-golang/v5/mqmetric"
+)
+
+func TestGetMonitoredChannels(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		err      bool
+	}{
+		{"", "", false},
+		{"*", "*", false},
+		{"TO.*, DEV.APP.SVRCONN", "TO.*,DEV.APP.SVRCONN", false},
+		{"TO.*.QM2", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("MQ_METRICS_CHANNELS", tt.value)
+			channels, err := getMonitoredChannels()
+			if (err != nil) != tt.err {
+				t.Fatalf("Expected error=%v; actual %v", tt.err, err)
+			}
+			if channels != tt.expected {
+				t.Errorf("Expected channels=%v; actual %v", tt.expected, channels)
+			}
+		})
+	}
+}
+
+func TestAddChannelStatus(t *testing.T) {
+	status := &mqmetric.StatusSet{Attributes: make(map[string]*mqmetric.StatusAttribute)}
+	setValue := func(attr, key string, value *mqmetric.StatusValue) {
+		if _, ok := status.Attributes[attr]; !ok {
+			status.Attributes[attr] = &mqmetric.StatusAttribute{Values: make(map[string]*mqmetric.StatusValue)}
+		}
+		status.Attributes[attr].Values[key] = value
+	}
+	addInstance := func(key, name string, chlType, chlStatus, messages, sinceMsg int64) {
+		setValue(mqmetric.ATTR_CHL_NAME, key, &mqmetric.StatusValue{ValueString: name})
+		setValue(mqmetric.ATTR_CHL_TYPE, key, &mqmetric.StatusValue{IsInt64: true, ValueInt64: chlType})
+		setValue(mqmetric.ATTR_CHL_STATUS, key, &mqmetric.StatusValue{IsInt64: true, ValueInt64: chlStatus})
+		setValue(mqmetric.ATTR_CHL_MESSAGES, key, &mqmetric.StatusValue{IsInt64: true, ValueInt64: messages})
+		if sinceMsg >= 0 {
+			setValue(mqmetric.ATTR_CHL_SINCE_MSG, key, &mqmetric.StatusValue{IsInt64: true, ValueInt64: sinceMsg})
+		}
+	}
+	addInstance("APP.SVRCONN/10.0.0.1/1/", "APP.SVRCONN", int64(ibmmq.MQCHT_SVRCONN), int64(ibmmq.MQCHS_RUNNING), 5, 30)
+	addInstance("APP.SVRCONN/10.0.0.2/2/", "APP.SVRCONN", int64(ibmmq.MQCHT_SVRCONN), int64(ibmmq.MQCHS_RUNNING), 7, 10)
+	addInstance("TO.QM2/10.0.0.3/3/QM2", "TO.QM2", int64(ibmmq.MQCHT_SENDER), int64(ibmmq.MQCHS_RETRYING), 0, -1)
+	addInstance("TO.QM3/-/-/-", "TO.QM3", int64(ibmmq.MQCHT_SENDER), int64(ibmmq.MQCHS_INACTIVE), 0, -1)
+
+	values := make(map[string]map[string]float64)
+	for key := range generateChannelStatusMetricNamesMap() {
+		values[key] = make(map[string]float64)
+	}
+	addChannelStatus(values, status, "")
+
+	svrconn := channelLabelValue + "SVRCONN/APP.SVRCONN"
+	retrying := channelLabelValue + "SDR/TO.QM2"
+	inactive := channelLabelValue + "SDR/TO.QM3"
+	tests := []struct {
+		metric   string
+		label    string
+		expected float64
+	}{
+		{channelStatusMetric, svrconn, channelStatusRunning},
+		{channelInstancesMetric, svrconn, 2},
+		{channelMessagesMetric, svrconn, 12},
+		{channelSinceMsgMetric, svrconn, 10},
+		{channelStatusMetric, retrying, channelStatusTransition},
+		{channelInstancesMetric, retrying, 1},
+		{channelStatusMetric, inactive, channelStatusStopped},
+		{channelInstancesMetric, inactive, 0},
+	}
+	for _, tt := range tests {
+		actual, ok := values[tt.metric][tt.label]
+		if !ok {
+			t.Errorf("Expected %v value for %v", tt.metric, tt.label)
+		} else if actual != tt.expected {
+			t.Errorf("Expected %v=%v for %v; actual %v", tt.metric, tt.expected, tt.label, actual)
+		}
+	}
+	if _, ok := values[channelSinceMsgMetric][inactive]; ok {
+		t.Errorf("Unexpected %v value for %v", channelSinceMsgMetric, inactive)
+	}
+}
+
+func TestSplitChannelLabel(t *testing.T) {
+	name, chlType := splitChannelLabel(channelLabelValue + "SDR/TO.QM2/WITH/SLASHES")
+	if name != "TO.QM2/WITH/SLASHES" || chlType != "SDR" {
+		t.Errorf("Expected name=TO.QM2/WITH/SLASHES, type=SDR; actual name=%v, type=%v", name, chlType)
+	}
+}
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2025
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -18,8 +18,6 @@ limitations under the License.
 package metrics
 
 import (
-	"strings"
-
 	"github.com/ibm-messaging/mq-container/pkg/logger"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -32,133 +30,68 @@ const (
 	objectLabel       = "object"
 	nhaInstancePrefix = "nha"
 	nhaInstanceLabel  = "instance"
+	channelPrefix     = "channel"
+	channelLabel      = "channel"
+	channelTypeLabel  = "type"
 )
 
+// exporter provides the metrics from the latest snapshot published by processMetrics.  It holds no state
+// of its own, so scrapes from several Prometheus servers at the same time all see the same values.
 type exporter struct {
-	qmName       string
-	gaugeMap     map[string]*prometheus.GaugeVec
-	counterMap   map[string]*prometheus.CounterVec
-	firstCollect bool
-	log          *logger.Logger
+	qmName string
+	log    *logger.Logger
 }
 
 func newExporter(qmName string, log *logger.Logger) *exporter {
 	return &exporter{
-		qmName:       qmName,
-		gaugeMap:     make(map[string]*prometheus.GaugeVec),
-		counterMap:   make(map[string]*prometheus.CounterVec),
-		firstCollect: true,
-		log:          log,
+		qmName: qmName,
+		log:    log,
+	}
+}
+
+// NewGatherer returns a gatherer for the queue manager metrics only, without the Go runtime and process
+// metrics in the default Prometheus registry, for use when exporting the metrics other than to Prometheus
+func NewGatherer(qmName string, log *logger.Logger) (prometheus.Gatherer, error) {
+	registry := prometheus.NewRegistry()
+	err := registry.Register(newExporter(qmName, log))
+	if err != nil {
+		return nil, err
 	}
+	return registry, nil
 }
 
 // Describe provides details of all available metrics
 func (e *exporter) Describe(ch chan<- *prometheus.Desc) {
-
-	requestChannel <- false
-	response := <-responseChannel
-
-	for key, metric := range response {
-
-		if metric.isDelta {
-			// For delta type metrics - allocate a Prometheus Counter
-			counterVec := createCounterVec(metric.name, metric.description, metric.objectType, metric.nhaType)
-			e.counterMap[key] = counterVec
-
-			// Describe metric
-			counterVec.Describe(ch)
-
-		} else {
-			// For non-delta type metrics - allocate a Prometheus Gauge
-			gaugeVec := createGaugeVec(metric.name, metric.description, metric.objectType, metric.nhaType)
-			e.gaugeMap[key] = gaugeVec
-
-			// Describe metric
-			gaugeVec.Describe(ch)
-		}
+	s := getSnapshot()
+	if s == nil {
+		return
+	}
+	for _, counterVec := range s.counters {
+		counterVec.Describe(ch)
+	}
+	for _, gaugeVec := range s.gauges {
+		gaugeVec.Describe(ch)
 	}
 }
 
-// Collect is called at regular intervals to provide the current metric data
+// Collect is called for each scrape to provide the current metric data
 func (e *exporter) Collect(ch chan<- prometheus.Metric) {
-
-	requestChannel <- true
-	response := <-responseChannel
-
-	for key, metric := range response {
-
-		if metric.isDelta {
-			// For delta type metrics - update their Prometheus Counter
-			counterVec := e.counterMap[key]
-
-			// Populate Prometheus Counter with metric values
-			// - Skip on first collect to avoid build-up of accumulated values
-			if !e.firstCollect {
-				for label, value := range metric.values {
-					var err error
-					var counter prometheus.Counter
-
-					if label == qmgrLabelValue {
-						counter, err = counterVec.GetMetricWithLabelValues(e.qmName)
-					} else if strings.HasPrefix(label, nhaLabelValue) {
-						nhaInstance := strings.ReplaceAll(label, nhaLabelValue, "")
-						counter, err = counterVec.GetMetricWithLabelValues(nhaInstance, e.qmName)
-					} else {
-						counter, err = counterVec.GetMetricWithLabelValues(label, e.qmName)
-					}
-					if err == nil {
-						counter.Add(value)
-					} else {
-						e.log.Errorf("Metrics Error: %s", err.Error())
-					}
-				}
-			}
-
-			// Collect metric
-			counterVec.Collect(ch)
-
-		} else {
-			// For non-delta type metrics - reset their Prometheus Gauge
-			gaugeVec := e.gaugeMap[key]
-			gaugeVec.Reset()
-
-			// Populate Prometheus Gauge with metric values
-			// - Skip on first collect to avoid build-up of accumulated values
-			if !e.firstCollect {
-				for label, value := range metric.values {
-					var err error
-					var gauge prometheus.Gauge
-
-					if label == qmgrLabelValue {
-						gauge, err = gaugeVec.GetMetricWithLabelValues(e.qmName)
-					} else if strings.HasPrefix(label, nhaLabelValue) {
-						nhaInstance := strings.ReplaceAll(label, nhaLabelValue, "")
-						gauge, err = gaugeVec.GetMetricWithLabelValues(nhaInstance, e.qmName)
-					} else {
-						gauge, err = gaugeVec.GetMetricWithLabelValues(label, e.qmName)
-					}
-					if err == nil {
-						gauge.Set(value)
-					} else {
-						e.log.Errorf("Metrics Error: %s", err.Error())
-					}
-				}
-			}
-
-			// Collect metric
-			gaugeVec.Collect(ch)
-		}
+	s := getSnapshot()
+	if s == nil {
+		return
 	}
-
-	if e.firstCollect {
-		e.firstCollect = false
+	for _, counterVec := range s.counters {
+		counterVec.Collect(ch)
+	}
+	for _, gaugeVec := range s.gauges {
+		gaugeVec.Collect(ch)
 	}
 }
 
 // createCounterVec returns a Prometheus CounterVec populated with metric details
-func createCounterVec(name, description string, objectType bool, nhaType bool) *prometheus.CounterVec {
+func createCounterVec(name, description string, objectType bool, nhaType bool, channelType bool) *prometheus.CounterVec {
 
-	prefix, labels := getVecDetails(objectType, nhaType)
+	prefix, labels := getVecDetails(objectType, nhaType, channelType)
 
 	counterVec := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -172,9 +105,9 @@ func createCounterVec(name, description string, objectType bool, nhaType bool) *
 }
 
 // createGaugeVec returns a Prometheus GaugeVec populated with metric details
-func createGaugeVec(name, description string, objectType bool, nhaType bool) *prometheus.GaugeVec {
+func createGaugeVec(name, description string, objectType bool, nhaType bool, channelType bool) *prometheus.GaugeVec {
 
-	prefix, labels := getVecDetails(objectType, nhaType)
+	prefix, labels := getVecDetails(objectType, nhaType, channelType)
 
 	gaugeVec := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -188,12 +121,14 @@ func createGaugeVec(name, description string, objectType bool, nhaType bool) *pr
 }
 
 // getVecDetails returns the required prefix and labels for a metric
-func getVecDetails(objectType bool, nhaType bool) (prefix string, labels []string) {
+func getVecDetails(objectType bool, nhaType bool, channelType bool) (prefix string, labels []string) {
 	switch true {
 	case objectType:
 		return objectPrefix, []string{objectLabel, qmgrLabel}
 	case nhaType:
 		return nhaInstancePrefix, []string{nhaInstanceLabel, qmgrLabel}
+	case channelType:
+		return channelPrefix, []string{channelLabel, channelTypeLabel, qmgrLabel}
 	default:
 		return qmgrPrefix, []string{qmgrLabel}
 	}
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2025
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -39,23 +39,18 @@ func testDescribe(t *testing.T, isDelta bool) {
 	defer teardownTestCase()
 	log := getTestLogger()
 
+	if isDelta {
+		mqmetric.Metrics.Classes[0].Types[0].Elements[0].Datatype = ibmmq.MQIAMO_MONITOR_DELTA
+	}
+	metrics, _ := initialiseMetrics(log)
+	publishSnapshot(newSnapshot("qmName", metrics, make(deltaTotals), false, log))
+
 	ch := make(chan *prometheus.Desc)
 	go func() {
 		exporter := newExporter("qmName", log)
 		exporter.Describe(ch)
 	}()
 
-	collect := <-requestChannel
-	if collect {
-		t.Errorf("Received unexpected collect request")
-	}
-
-	if isDelta {
-		mqmetric.Metrics.Classes[0].Types[0].Elements[0].Datatype = ibmmq.MQIAMO_MONITOR_DELTA
-	}
-	metrics, _ := initialiseMetrics(log)
-	responseChannel <- metrics
-
 	select {
 	case prometheusDesc := <-ch:
 		expected := "Desc{fqName: \"ibmmq_qmgr_" + testElement1Name + "\", help: \"" + testElement1Description + "\", constLabels: {}, variableLabels: [qmgr]}"
@@ -83,48 +78,41 @@ func testCollect(t *testing.T, isDelta bool) {
 	log := getTestLogger()
 
 	exporter := newExporter("qmName", log)
-	if isDelta {
-		exporter.counterMap[testKey1] = createCounterVec(testElement1Name, testElement1Description, false, false)
-	} else {
-		exporter.gaugeMap[testKey1] = createGaugeVec(testElement1Name, testElement1Description, false, false)
-	}
+	totals := make(deltaTotals)
 
 	for i := 1; i <= 3; i++ {
 
-		ch := make(chan prometheus.Metric)
-		go func() {
-			exporter.Collect(ch)
-			close(ch)
-		}()
-
-		collect := <-requestChannel
-		if !collect {
-			t.Errorf("Received unexpected describe request")
-		}
-
 		populateTestMetrics(i, false)
 		if isDelta {
 			mqmetric.Metrics.Classes[0].Types[0].Elements[0].Datatype = ibmmq.MQIAMO_MONITOR_DELTA
 		}
 		metrics, _ := initialiseMetrics(log)
 		updateMetrics(metrics)
-		responseChannel <- metrics
+		publishSnapshot(newSnapshot("qmName", metrics, totals, i > 1, log))
+
+		ch := make(chan prometheus.Metric)
+		go func() {
+			exporter.Collect(ch)
+			close(ch)
+		}()
 
 		select {
-		case <-ch:
+		case prometheusMetric := <-ch:
 			var actual float64
-			prometheusMetric := dto.Metric{}
+			metric := dto.Metric{}
+			err := prometheusMetric.Write(&metric)
+			if err != nil {
+				t.Fatal(err)
+			}
 			if isDelta {
-				exporter.counterMap[testKey1].WithLabelValues("qmName").Write(&prometheusMetric)
-				actual = prometheusMetric.GetCounter().GetValue()
+				actual = metric.GetCounter().GetValue()
 			} else {
-				exporter.gaugeMap[testKey1].WithLabelValues("qmName").Write(&prometheusMetric)
-				actual = prometheusMetric.GetGauge().GetValue()
+				actual = metric.GetGauge().GetValue()
 			}
 
-			if i == 1 {
+			if isDelta && i == 1 {
 				if actual != float64(0) {
-					t.Errorf("Expected values to be zero on first collect; actual %f", actual)
+					t.Errorf("Expected delta values to be zero on first publication; actual %f", actual)
 				}
 			} else if isDelta && i != 2 {
 				if actual != float64(i+(i-1)) {
@@ -136,13 +124,15 @@ func testCollect(t *testing.T, isDelta bool) {
 		case <-time.After(1 * time.Second):
 			t.Error("Did not receive channel response from collect")
 		}
+		for range ch {
+		}
 	}
 }
 
 func TestCreateCounterVec(t *testing.T) {
 
 	ch := make(chan *prometheus.Desc)
-	counterVec := createCounterVec("MetricName", "MetricDescription", false, false)
+	counterVec := createCounterVec("MetricName", "MetricDescription", false, false, false)
 	go func() {
 		counterVec.Describe(ch)
 	}()
@@ -158,7 +148,7 @@ func TestCreateCounterVec(t *testing.T) {
 func TestCreateCounterVec_ObjectLabel(t *testing.T) {
 
 	ch := make(chan *prometheus.Desc)
-	counterVec := createCounterVec("MetricName", "MetricDescription", true, false)
+	counterVec := createCounterVec("MetricName", "MetricDescription", true, false, false)
 	go func() {
 		counterVec.Describe(ch)
 	}()
@@ -174,7 +164,7 @@ func TestCreateCounterVec_ObjectLabel(t *testing.T) {
 func TestCreateCounterVec_NHALabel(t *testing.T) {
 
 	ch := make(chan *prometheus.Desc)
-	counterVec := createCounterVec("MetricName", "MetricDescription", false, true)
+	counterVec := createCounterVec("MetricName", "MetricDescription", false, true, false)
 	go func() {
 		counterVec.Describe(ch)
 	}()
@@ -187,10 +177,26 @@ func TestCreateCounterVec_NHALabel(t *testing.T) {
 	}
 }
 
+func TestCreateCounterVec_ChannelLabel(t *testing.T) {
+
+	ch := make(chan *prometheus.Desc)
+	counterVec := createCounterVec("MetricName", "MetricDescription", false, false, true)
+	go func() {
+		counterVec.Describe(ch)
+	}()
+	description := <-ch
+
+	expected := "Desc{fqName: \"ibmmq_channel_MetricName\", help: \"MetricDescription\", constLabels: {}, variableLabels: [channel type qmgr]}"
+	actual := description.String()
+	if actual != expected {
+		t.Errorf("Expected value=%s; actual %s", expected, actual)
+	}
+}
+
 func TestCreateGaugeVec(t *testing.T) {
 
 	ch := make(chan *prometheus.Desc)
-	gaugeVec := createGaugeVec("MetricName", "MetricDescription", false, false)
+	gaugeVec := createGaugeVec("MetricName", "MetricDescription", false, false, false)
 	go func() {
 		gaugeVec.Describe(ch)
 	}()
@@ -206,7 +212,7 @@ func TestCreateGaugeVec(t *testing.T) {
 func TestCreateGaugeVec_ObjectLabel(t *testing.T) {
 
 	ch := make(chan *prometheus.Desc)
-	gaugeVec := createGaugeVec("MetricName", "MetricDescription", true, false)
+	gaugeVec := createGaugeVec("MetricName", "MetricDescription", true, false, false)
 	go func() {
 		gaugeVec.Describe(ch)
 	}()
@@ -222,7 +228,7 @@ func TestCreateGaugeVec_ObjectLabel(t *testing.T) {
 func TestCreateGaugeVec_NHALabel(t *testing.T) {
 
 	ch := make(chan *prometheus.Desc)
-	gaugeVec := createGaugeVec("MetricName", "MetricDescription", false, true)
+	gaugeVec := createGaugeVec("MetricName", "MetricDescription", false, true, false)
 	go func() {
 		gaugeVec.Describe(ch)
 	}()
@@ -234,3 +240,19 @@ func TestCreateGaugeVec_NHALabel(t *testing.T) {
 		t.Errorf("Expected value=%s; actual %s", expected, actual)
 	}
 }
+
+func TestCreateGaugeVec_ChannelLabel(t *testing.T) {
+
+	ch := make(chan *prometheus.Desc)
+	gaugeVec := createGaugeVec("MetricName", "MetricDescription", false, false, true)
+	go func() {
+		gaugeVec.Describe(ch)
+	}()
+	description := <-ch
+
+	expected := "Desc{fqName: \"ibmmq_channel_MetricName\", help: \"MetricDescription\", constLabels: {}, variableLabels: [channel type qmgr]}"
+	actual := description.String()
+	if actual != expected {
+		t.Errorf("Expected value=%s; actual %s", expected, actual)
+	}
+}
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2025
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -17,6 +17,8 @@ limitations under the License.
 // Package metrics contains code to provide metrics for the queue manager
 package metrics
 
+import "github.com/ibm-messaging/mq-golang/v5/mqmetric"
+
 type metricLookup struct {
 	name    string
 	enabled bool
@@ -124,6 +126,57 @@ func generateMetricNamesMap() map[string]metricLookup {
 		"STATMQI/GET/Failed MQCB count":                                           metricLookup{"failed_mqcb_total", true},
 		"STATMQI/SYNCPOINT/Commit count":                                          metricLookup{"commit_total", true},
 		"STATMQI/SYNCPOINT/Rollback count":                                        metricLookup{"rollback_total", true},
+		"STATQ/GENERAL/messages expired":                                          metricLookup{"expired_message_total", true},
+		"STATQ/GENERAL/open browse count":                                         metricLookup{"open_browse_handles", false},
+		"STATQ/GENERAL/open input count":                                          metricLookup{"open_input_handles", true},
+		"STATQ/GENERAL/open output count":                                         metricLookup{"open_output_handles", true},
+		"STATQ/GENERAL/open publish count":                                        metricLookup{"open_publish_handles", false},
+		"STATQ/GENERAL/Queue depth":                                               metricLookup{"depth", true},
+		"STATQ/GENERAL/queue purged count":                                        metricLookup{"purged_queue_total", true},
+		"STATQ/GENERAL/average queue time":                                        metricLookup{"average_queue_time_seconds", true},
+		"STATQ/GET/MQGET browse non-persistent byte count":                        metricLookup{"non_persistent_message_browse_bytes_total", false},
+		"STATQ/GET/MQGET browse persistent byte count":                            metricLookup{"persistent_message_browse_bytes_total", false},
+		"STATQ/GET/MQGET browse fails":                                            metricLookup{"failed_browse_total", true},
+		"STATQ/GET/MQGET browse fails with MQRC_NO_MSG_AVAILABLE":                 metricLookup{"failed_browse_no_msg_available_total", false},
+		"STATQ/GET/MQGET browse non-persistent message count":                     metricLookup{"non_persistent_message_browse_total", false},
+		"STATQ/GET/MQGET browse persistent message count":                         metricLookup{"persistent_message_browse_total", false},
+		"STATQ/GET/MQGET browse fails with MQRC_TRUNCATED_MSG_FAILED":             metricLookup{"failed_browse_truncated_msg_total", false},
+		"STATQ/GET/MQGET byte count":                                              metricLookup{"mqget_bytes_total", true},
+		"STATQ/GET/destructive MQGET non-persistent byte count":                   metricLookup{"non_persistent_message_get_bytes_total", false},
+		"STATQ/GET/destructive MQGET persistent byte count":                       metricLookup{"persistent_message_get_bytes_total", false},
+		"STATQ/GET/destructive MQGET fails":                                       metricLookup{"failed_mqget_total", true},
+		"STATQ/GET/MQGET count":                                                   metricLookup{"mqget_total", true},
+		"STATQ/GET/destructive MQGET non-persistent message count":                metricLookup{"non_persistent_message_destructive_get_total", false},
+		"STATQ/GET/destructive MQGET persistent message count":                    metricLookup{"persistent_message_destructive_get_total", false},
+		"STATQ/GET/destructive MQGET fails with MQRC_NO_MSG_AVAILABLE":            metricLookup{"failed_mqget_no_msg_available_total", false},
+		"STATQ/GET/rolled back MQGET count":                                       metricLookup{"rolled_back_mqget_total", true},
+		"STATQ/GET/destructive MQGET fails with MQRC_TRUNCATED_MSG_FAILED":        metricLookup{"failed_mqget_truncated_msg_total", false},
+		"STATQ/INQSET/MQINQ count":                                                metricLookup{"mqinq_total", false},
+		"STATQ/INQSET/MQSET count":                                                metricLookup{"mqset_total", false},
+		"STATQ/OPENCLOSE/MQCLOSE count":                                           metricLookup{"mqclose_total", false},
+		"STATQ/OPENCLOSE/MQOPEN count":                                            metricLookup{"mqopen_total", false},
+		"STATQ/PUT/queue avoided bytes":                                           metricLookup{"queue_avoided_bytes_total", false},
+		"STATQ/PUT/queue avoided puts":                                            metricLookup{"queue_avoided_puts_total", false},
+		"STATQ/PUT/MQPUT byte count":                                              metricLookup{"mqput_bytes_total", true},
+		"STATQ/PUT/non-persistent byte count":                                     metricLookup{"non_persistent_message_put_bytes_total", false},
+		"STATQ/PUT/persistent byte count":                                         metricLookup{"persistent_message_put_bytes_total", false},
+		"STATQ/PUT/MQPUT1 non-persistent message count":                           metricLookup{"non_persistent_message_mqput1_total", false},
+		"STATQ/PUT/MQPUT1 persistent message count":                               metricLookup{"persistent_message_mqput1_total", false},
+		"STATQ/PUT/MQPUT/MQPUT1 count":                                            metricLookup{"mqput_mqput1_total", true},
+		"STATQ/PUT/MQPUT non-persistent message count":                            metricLookup{"non_persistent_message_mqput_total", false},
+		"STATQ/PUT/MQPUT persistent message count":                                metricLookup{"persistent_message_mqput_total", false},
+		"STATQ/PUT/lock contention":                                               metricLookup{"lock_contention_percentage", false},
+		"STATQ/PUT/rolled back MQPUT count":                                       metricLookup{"rolled_back_mqput_total", true},
+		"STATQ/EXTENDED/correlid mismatch long count":                             metricLookup{"correlid_mismatch_long_total", false},
+		"STATQ/EXTENDED/correlid mismatch short count":                            metricLookup{"correlid_mismatch_short_total", false},
+		"STATQ/EXTENDED/intran get skipped count":                                 metricLookup{"intran_get_skipped_total", false},
+		"STATQ/EXTENDED/intran put skipped count":                                 metricLookup{"intran_put_skipped_total", false},
+		"STATQ/EXTENDED/load msg dtl count":                                       metricLookup{"load_message_detail_total", false},
+		"STATQ/EXTENDED/msg examine count":                                        metricLookup{"message_examine_total", false},
+		"STATQ/EXTENDED/msg search count":                                         metricLookup{"message_search_total", false},
+		"STATQ/EXTENDED/msgid mismatch count":                                     metricLookup{"msgid_mismatch_total", false},
+		"STATQ/EXTENDED/msg not found count":                                      metricLookup{"message_not_found_total", false},
+		"STATQ/EXTENDED/selection mismatch count":                                 metricLookup{"selection_mismatch_total", false},
 		"NHAREPLICA/REPLICATION/Catch-up log bytes sent":                          metricLookup{"replication_catchup_log_sent_bytes", true},
 		"NHAREPLICA/REPLICATION/MQ FDC file count":                                metricLookup{"replication_mq_fdc_file_count", true},
 		"NHAREPLICA/REPLICATION/Catch-up log bytes decompressed":                  metricLookup{"replication_catch_up_log_decompressed_bytes", true},
@@ -167,3 +220,16 @@ func generateMetricNamesMap() map[string]metricLookup {
 	}
 	return metricNamesMap
 }
+
+// generateQueueStatusMetricNamesMap generates metric names mapped from queue status attributes
+func generateQueueStatusMetricNamesMap() map[string]metricLookup {
+
+	queueStatusMetricNamesMap := map[string]metricLookup{
+		mqmetric.ATTR_Q_MSGAGE:    metricLookup{"oldest_message_age_seconds", true},
+		mqmetric.ATTR_Q_SINCE_PUT: metricLookup{"time_since_put_seconds", true},
+		mqmetric.ATTR_Q_SINCE_GET: metricLookup{"time_since_get_seconds", true},
+		mqmetric.ATTR_Q_UNCOM:     metricLookup{"uncommitted_messages", true},
+		mqmetric.ATTR_Q_MAX_DEPTH: metricLookup{"max_depth", true},
+	}
+	return queueStatusMetricNamesMap
+}
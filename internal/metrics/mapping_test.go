@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2025
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -15,14 +15,17 @@ limitations under the License.
 */
 package metrics
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestGenerateMetricNamesMap(t *testing.T) {
 
 	metricNamesMap := generateMetricNamesMap()
 
-	if len(metricNamesMap) != 138 {
-		t.Errorf("Expected mapping-size=%d; actual %d", 138, len(metricNamesMap))
+	if len(metricNamesMap) != 189 {
+		t.Errorf("Expected mapping-size=%d; actual %d", 189, len(metricNamesMap))
 	}
 
 	actual, ok := metricNamesMap[testKey1]
@@ -35,3 +38,17 @@ func TestGenerateMetricNamesMap(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateQueueStatusMetricNamesMap(t *testing.T) {
+
+	metricNamesMap := generateMetricNamesMap()
+	queueStatusMetricNamesMap := generateQueueStatusMetricNamesMap()
+
+	for attr, queueStatusLookup := range queueStatusMetricNamesMap {
+		for key, lookup := range metricNamesMap {
+			if strings.HasPrefix(key, "STATQ/") && lookup.name == queueStatusLookup.name {
+				t.Errorf("Queue status attribute %s has the same metric name as %s: %s", attr, key, lookup.name)
+			}
+		}
+	}
+}
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2025
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -88,17 +88,73 @@ func startMetricsGathering(qmName string, log *logger.Logger) error {
 		return fmt.Errorf("Failed to validate HTTPS metrics configuration: %v", err)
 	}
 
+	// Check if a client CA has been provided for requiring client certificates
+	clientCAs, err := loadClientCAs(clientCADirMetrics)
+	if err != nil {
+		return fmt.Errorf("Failed to validate metrics client authentication configuration: %v", err)
+	}
+	if clientCAs != nil && !httpsMetricsEnabled {
+		return fmt.Errorf("Failed to validate metrics client authentication configuration: client certificates require HTTPS metrics to be enabled")
+	}
+
+	// Check if a bearer token has been provided for authenticating requests
+	bearerTokenEnabled, err := checkBearerTokenFile(tokenFileMetrics)
+	if err != nil {
+		return fmt.Errorf("Failed to validate metrics client authentication configuration: %v", err)
+	}
+
 	// Generate appropriate audit log wrapper based on configuration
 	auditWrapper := passthroughHandlerFuncWrapper
+	var auditLog logHandler
 	if os.Getenv("MQ_LOGGING_METRICS_AUDIT_ENABLED") == "true" {
-		auditLog := logrotation.NewRotatingLogger(auditLogDirectory, auditLogFilenameFormat, auditLogMaxBytes, auditLogNumFiles)
-		err := auditLog.Init()
+		rotatingLog := logrotation.NewRotatingLogger(auditLogDirectory, auditLogFilenameFormat, auditLogMaxBytes, auditLogNumFiles)
+		err := rotatingLog.Init()
 		if err != nil {
 			return fmt.Errorf("Failed to set up metric audit log: %v", err)
 		}
+		auditLog = rotatingLog
 		auditWrapper = newAuditingHandlerFuncWrapper(qmName, auditLog)
 	}
 
+	// Requests are audited before checking the bearer token, so that rejected requests are recorded
+	handlerWrapper := auditWrapper
+	if bearerTokenEnabled {
+		handlerWrapper = chainHandlerFuncWrappers(auditWrapper, newBearerTokenHandlerFuncWrapper(tokenFileMetrics))
+		if !httpsMetricsEnabled {
+			log.Println("Metrics Warning: The metrics bearer token is sent unencrypted, because HTTPS metrics are not enabled")
+		}
+	}
+
+	// Check if any queues have been configured for queue metrics
+	monitoredQueues, err = getMonitoredQueues(queuesConfigFile)
+	if err != nil {
+		return fmt.Errorf("Failed to validate queue metrics configuration: %v", err)
+	}
+	if monitoredQueues != "" {
+		log.Printf("Generating queue metrics for queues matching: %v", monitoredQueues)
+	}
+
+	// Check if any channels have been configured for channel metrics
+	monitoredChannels, err = getMonitoredChannels()
+	if err != nil {
+		return fmt.Errorf("Failed to validate channel metrics configuration: %v", err)
+	}
+	if monitoredChannels != "" {
+		log.Printf("Generating channel metrics for channels matching: %v", monitoredChannels)
+	}
+
+	// Check which metrics have been selected
+	selectedMetrics, err = getMetricSelection(selectionConfigFile)
+	if err != nil {
+		return fmt.Errorf("Failed to validate metric selection configuration: %v", err)
+	}
+	if len(selectedMetrics.rules) > 0 {
+		log.Printf("Applying %v metric selection rules", len(selectedMetrics.rules))
+	}
+	if selectedMetrics.exportUnmapped {
+		log.Println("Exporting metrics which have no mapped name, using generated names")
+	}
+
 	if httpsMetricsEnabled {
 		log.Println("Starting HTTPS metrics gathering")
 	} else {
@@ -134,12 +190,24 @@ func startMetricsGathering(qmName string, log *logger.Logger) error {
 				return cert, nil
 			},
 		}
+		if clientCAs != nil {
+			requireClientCertificates(&tlsConfig, clientCAs, qmName, auditLog)
+		}
 		metricsServer.TLSConfig = &tlsConfig
 	}
 
+	switch {
+	case clientCAs != nil && bearerTokenEnabled:
+		log.Println("Metrics requests require a client certificate and a bearer token")
+	case clientCAs != nil:
+		log.Println("Metrics requests require a client certificate")
+	case bearerTokenEnabled:
+		log.Println("Metrics requests require a bearer token")
+	}
+
 	// Setup HTTP server to handle requests from Prometheus
-	http.Handle("/metrics", wrapHandler(promhttp.Handler(), auditWrapper))
-	http.HandleFunc("/", auditWrapper(
+	http.Handle("/metrics", wrapHandler(promhttp.Handler(), handlerWrapper))
+	http.HandleFunc("/", handlerWrapper(
 		func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(200)
 			// #nosec G104
@@ -0,0 +1,77 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ibm-messaging/mq-golang/v5/mqmetric"
+)
+
+// queuesConfigFile is the location of an optional file listing the queues to monitor, one pattern per line
+const queuesConfigFile = "/etc/mqm/metrics/queues"
+
+// getMonitoredQueues returns a comma-separated list of queue name patterns to generate queue metrics for.
+// The list is taken from MQ_METRICS_QUEUES if set, or otherwise from the queues configuration file.
+func getMonitoredQueues(configFile string) (string, error) {
+	var patterns []string
+	source := "MQ_METRICS_QUEUES"
+
+	if env, ok := os.LookupEnv("MQ_METRICS_QUEUES"); ok {
+		for _, pattern := range strings.Split(env, ",") {
+			if pattern = strings.TrimSpace(pattern); pattern != "" {
+				patterns = append(patterns, pattern)
+			}
+		}
+	} else {
+		// #nosec G304 - configFile is a defined constant
+		file, err := os.Open(configFile)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return "", nil
+			}
+			return "", fmt.Errorf("Failed to read %v: %v", configFile, err)
+		}
+		defer file.Close()
+		source = configFile
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			patterns = append(patterns, line)
+		}
+		if err := scanner.Err(); err != nil {
+			return "", fmt.Errorf("Failed to read %v: %v", configFile, err)
+		}
+	}
+
+	queues := strings.Join(patterns, ",")
+	if queues == "" {
+		return "", nil
+	}
+	err := mqmetric.VerifyQueuePatterns(queues)
+	if err != nil {
+		return "", fmt.Errorf("Invalid queue patterns in %v: %v", source, err)
+	}
+	return queues, nil
+}
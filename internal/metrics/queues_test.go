@@ -0,0 +1,68 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetMonitoredQueues(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      *string
+		file     string
+		expected string
+		err      bool
+	}{
+		{"NotConfigured", nil, "", "", false},
+		{"Env", strPtr("APP.*, !APP.TEMP.*,DEV.QUEUE.1"), "", "APP.*,!APP.TEMP.*,DEV.QUEUE.1", false},
+		{"EnvOverridesFile", strPtr("APP.*"), "DEV.*\n", "APP.*", false},
+		{"EnvEmpty", strPtr(""), "DEV.*\n", "", false},
+		{"EnvInvalid", strPtr("APP.*.IN"), "", "", true},
+		{"File", nil, "# Application queues\nAPP.*\n\n!APP.TEMP.*\n", "APP.*,!APP.TEMP.*", false},
+		{"FileInvalid", nil, "APP!\n", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != nil {
+				t.Setenv("MQ_METRICS_QUEUES", *tt.env)
+			} else {
+				t.Setenv("MQ_METRICS_QUEUES", "")
+				os.Unsetenv("MQ_METRICS_QUEUES")
+			}
+			configFile := filepath.Join(t.TempDir(), "queues")
+			if tt.file != "" {
+				err := os.WriteFile(configFile, []byte(tt.file), 0600)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			queues, err := getMonitoredQueues(configFile)
+			if (err != nil) != tt.err {
+				t.Fatalf("Expected error=%v; actual %v", tt.err, err)
+			}
+			if queues != tt.expected {
+				t.Errorf("Expected queues=%v; actual %v", tt.expected, queues)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
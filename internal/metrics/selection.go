@@ -0,0 +1,136 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// selectionConfigFile is the location of an optional file listing the metrics to enable or disable, one pattern per line
+const selectionConfigFile = "/etc/mqm/metrics/selection"
+
+// selectionRule enables or disables the metrics with names matching a pattern
+type selectionRule struct {
+	pattern string
+	enable  bool
+}
+
+// metricSelection decides which metrics are exported.  Rules are applied in order to the default for each
+// metric, so a later rule overrides an earlier one.
+type metricSelection struct {
+	rules []selectionRule
+	// exportUnmapped causes metrics with no name in the mapping table to be exported with a generated name
+	exportUnmapped bool
+}
+
+// selectedMetrics is the selection used when initialising metrics
+var selectedMetrics metricSelection
+
+// enabled returns true if the metric with the specified full name should be exported
+func (s metricSelection) enabled(name string, enabledByDefault bool) bool {
+	enabled := enabledByDefault
+	for _, rule := range s.rules {
+		// The pattern has already been validated, so the error can be ignored
+		if matched, _ := path.Match(rule.pattern, name); matched {
+			enabled = rule.enable
+		}
+	}
+	return enabled
+}
+
+// getMetricSelection returns the metric selection configured using MQ_METRICS_SELECTION and
+// MQ_METRICS_EXPORT_UNMAPPED.  If MQ_METRICS_SELECTION is not set, the patterns are taken from the
+// selection configuration file, if it exists.
+func getMetricSelection(configFile string) (metricSelection, error) {
+	var selection metricSelection
+	var patterns []string
+	source := "MQ_METRICS_SELECTION"
+
+	if env, ok := os.LookupEnv("MQ_METRICS_SELECTION"); ok {
+		for _, pattern := range strings.Split(env, ",") {
+			if pattern = strings.TrimSpace(pattern); pattern != "" {
+				patterns = append(patterns, pattern)
+			}
+		}
+	} else {
+		// #nosec G304 - configFile is a defined constant
+		file, err := os.Open(configFile)
+		if err != nil && !os.IsNotExist(err) {
+			return selection, fmt.Errorf("Failed to read %v: %v", configFile, err)
+		}
+		if err == nil {
+			defer file.Close()
+			source = configFile
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if line == "" || strings.HasPrefix(line, "#") {
+					continue
+				}
+				patterns = append(patterns, line)
+			}
+			if err := scanner.Err(); err != nil {
+				return selection, fmt.Errorf("Failed to read %v: %v", configFile, err)
+			}
+		}
+	}
+
+	for _, pattern := range patterns {
+		rule := selectionRule{pattern: pattern, enable: true}
+		if strings.HasPrefix(pattern, "!") {
+			rule = selectionRule{pattern: strings.TrimSpace(pattern[1:]), enable: false}
+		}
+		if _, err := path.Match(rule.pattern, ""); err != nil || rule.pattern == "" {
+			return selection, fmt.Errorf("Invalid metric pattern '%v' in %v", pattern, source)
+		}
+		selection.rules = append(selection.rules, rule)
+	}
+
+	unmapped := os.Getenv("MQ_METRICS_EXPORT_UNMAPPED")
+	selection.exportUnmapped = unmapped == "true" || unmapped == "1"
+	return selection, nil
+}
+
+// getFullMetricName returns the name of a metric as it is exported, including the namespace and prefix
+func getFullMetricName(name string, objectType bool, nhaType bool, channelType bool) string {
+	prefix, _ := getVecDetails(objectType, nhaType, channelType)
+	return namespace + "_" + prefix + "_" + name
+}
+
+// generatedMetricLookup returns the name of a metric which has no name in the mapping table.  Metrics with a
+// generated name are enabled by default.
+func generatedMetricLookup(key string, isDelta bool) metricLookup {
+	return metricLookup{generateMetricName(key, isDelta), true}
+}
+
+var invalidMetricNameChars = regexp.MustCompile("[^a-z0-9]+")
+
+// generateMetricName generates a metric name from the key of a metric which has no name in the mapping table,
+// for example "CPU/SystemSummary/RAM total bytes" becomes "cpu_systemsummary_ram_total_bytes".  The name of
+// a delta metric ends with "_total", as it is exported as a counter.
+func generateMetricName(key string, isDelta bool) string {
+	name := invalidMetricNameChars.ReplaceAllString(strings.ToLower(key), "_")
+	name = strings.Trim(name, "_")
+	if isDelta && !strings.HasSuffix(name, "_total") {
+		name += "_total"
+	}
+	return name
+}
@@ -0,0 +1,123 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetMetricSelection(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "selection")
+	err := os.WriteFile(configFile, []byte("# Comment\n!ibmmq_object_*\n\nibmmq_object_depth\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		env       string
+		setEnv    bool
+		unmapped  string
+		rules     int
+		exportAll bool
+		err       bool
+	}{
+		{"FromEnvironment", "ibmmq_qmgr_*_water_mark, !ibmmq_qmgr_fdc_files", true, "", 2, false, false},
+		{"EmptyEnvironment", "", true, "true", 0, true, false},
+		{"FromFile", "", false, "", 2, false, false},
+		{"InvalidPattern", "ibmmq_[", true, "", 0, false, true},
+		{"EmptyExclusion", "!", true, "", 0, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MQ_METRICS_SELECTION", tt.env)
+			if !tt.setEnv {
+				os.Unsetenv("MQ_METRICS_SELECTION")
+			}
+			t.Setenv("MQ_METRICS_EXPORT_UNMAPPED", tt.unmapped)
+			selection, err := getMetricSelection(configFile)
+			if (err != nil) != tt.err {
+				t.Fatalf("Expected error %v; got %v", tt.err, err)
+			}
+			if len(selection.rules) != tt.rules || selection.exportUnmapped != tt.exportAll {
+				t.Errorf("Expected %v rules and exportUnmapped=%v; got %+v", tt.rules, tt.exportAll, selection)
+			}
+		})
+	}
+}
+
+func TestGetMetricSelectionNoFile(t *testing.T) {
+	t.Setenv("MQ_METRICS_SELECTION", "")
+	os.Unsetenv("MQ_METRICS_SELECTION")
+	selection, err := getMetricSelection(filepath.Join(t.TempDir(), "selection"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selection.rules) != 0 {
+		t.Errorf("Expected no rules; got %v", selection.rules)
+	}
+}
+
+func TestMetricSelectionEnabled(t *testing.T) {
+	selection := metricSelection{rules: []selectionRule{
+		{"ibmmq_qmgr_*_water_mark", true},
+		{"ibmmq_qmgr_durable_subscriber_low_water_mark", false},
+		{"ibmmq_object_*", false},
+	}}
+	tests := []struct {
+		name     string
+		def      bool
+		expected bool
+	}{
+		{"ibmmq_qmgr_durable_subscriber_high_water_mark", false, true},
+		{"ibmmq_qmgr_durable_subscriber_low_water_mark", false, false},
+		{"ibmmq_object_mqput_mqput1_total", true, false},
+		{"ibmmq_qmgr_system_ram_size_bytes", false, false},
+		{"ibmmq_qmgr_fdc_files", true, true},
+	}
+	for _, tt := range tests {
+		if actual := selection.enabled(tt.name, tt.def); actual != tt.expected {
+			t.Errorf("Expected %v to be enabled=%v; got %v", tt.name, tt.expected, actual)
+		}
+	}
+}
+
+func TestGenerateMetricName(t *testing.T) {
+	tests := []struct {
+		key      string
+		isDelta  bool
+		expected string
+	}{
+		{"CPU/SystemSummary/RAM total bytes", false, "cpu_systemsummary_ram_total_bytes"},
+		{"STATMQI/PUT/Interval total MQPUT/MQPUT1 count", true, "statmqi_put_interval_total_mqput_mqput1_count_total"},
+		{"DISK/Log/Log - new metric (total)", true, "disk_log_log_new_metric_total"},
+	}
+	for _, tt := range tests {
+		if actual := generateMetricName(tt.key, tt.isDelta); actual != tt.expected {
+			t.Errorf("Expected %v; got %v", tt.expected, actual)
+		}
+	}
+}
+
+func TestGetFullMetricName(t *testing.T) {
+	if name := getFullMetricName("depth", true, false, false); name != "ibmmq_object_depth" {
+		t.Errorf("Unexpected name %v", name)
+	}
+	if name := getFullMetricName("fdc_files", false, false, false); name != "ibmmq_qmgr_fdc_files" {
+		t.Errorf("Unexpected name %v", name)
+	}
+}
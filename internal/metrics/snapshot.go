@@ -0,0 +1,141 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains code to provide metrics for the queue manager
+package metrics
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// A snapshot holds the values of all metrics at the time they were published by processMetrics.  A snapshot
+// is not modified after it has been published, so it can be collected by any number of concurrent scrapes.
+type snapshot struct {
+	counters map[string]*prometheus.CounterVec
+	gauges   map[string]*prometheus.GaugeVec
+}
+
+// latestSnapshot is the most recently published snapshot, or nil if no snapshot has been published yet
+var latestSnapshot atomic.Pointer[snapshot]
+
+// publishSnapshot makes a snapshot available to the exporter
+func publishSnapshot(s *snapshot) {
+	latestSnapshot.Store(s)
+}
+
+// clearGauges publishes a snapshot holding the counters of the latest snapshot, but no gauges, so that the last
+// gauge values aren't reported as current while the queue manager is unavailable.  Counters keep their totals,
+// as they must not decrease.
+func clearGauges() {
+	s := getSnapshot()
+	if s == nil {
+		return
+	}
+	publishSnapshot(&snapshot{
+		counters: s.counters,
+		gauges:   make(map[string]*prometheus.GaugeVec),
+	})
+}
+
+// getSnapshot returns the most recently published snapshot, or nil if no snapshot has been published yet
+func getSnapshot() *snapshot {
+	return latestSnapshot.Load()
+}
+
+// deltaTotals holds the running total of each delta metric, keyed by metric and then by label.  It is only
+// used by processMetrics, which adds each publication of delta values to the totals exactly once.
+type deltaTotals map[string]map[string]float64
+
+// add adds the values of a delta metric to its running totals, and returns the totals
+func (t deltaTotals) add(key string, values map[string]float64) map[string]float64 {
+	total, ok := t[key]
+	if !ok {
+		total = make(map[string]float64)
+		t[key] = total
+	}
+	for label, value := range values {
+		// Prometheus counters can't decrease, so ignore any negative change
+		if value > 0 {
+			total[label] += value
+		} else if _, ok := total[label]; !ok {
+			total[label] = 0
+		}
+	}
+	return total
+}
+
+// newSnapshot creates a snapshot of the current metric values.  Delta metrics are added to the running
+// totals, unless accumulate is false, which is used to discard values built up before the first publication.
+func newSnapshot(qmName string, metrics map[string]*metricData, totals deltaTotals, accumulate bool, log *logger.Logger) *snapshot {
+	s := &snapshot{
+		counters: make(map[string]*prometheus.CounterVec),
+		gauges:   make(map[string]*prometheus.GaugeVec),
+	}
+	for key, metric := range metrics {
+		if metric.isDelta {
+			// For delta type metrics - allocate a Prometheus Counter holding the running totals
+			var values map[string]float64
+			if accumulate {
+				values = totals.add(key, metric.values)
+			} else {
+				values = totals.add(key, nil)
+			}
+			counterVec := createCounterVec(metric.name, metric.description, metric.objectType, metric.nhaType, metric.channelType)
+			for label, value := range values {
+				counter, err := counterVec.GetMetricWithLabelValues(getLabelValues(label, qmName)...)
+				if err == nil {
+					counter.Add(value)
+				} else {
+					log.Errorf("Metrics Error: %s", err.Error())
+				}
+			}
+			s.counters[key] = counterVec
+		} else {
+			// For non-delta type metrics - allocate a Prometheus Gauge holding the current values
+			gaugeVec := createGaugeVec(metric.name, metric.description, metric.objectType, metric.nhaType, metric.channelType)
+			for label, value := range metric.values {
+				gauge, err := gaugeVec.GetMetricWithLabelValues(getLabelValues(label, qmName)...)
+				if err == nil {
+					gauge.Set(value)
+				} else {
+					log.Errorf("Metrics Error: %s", err.Error())
+				}
+			}
+			s.gauges[key] = gaugeVec
+		}
+	}
+	return s
+}
+
+// getLabelValues returns the Prometheus label values for a metric value, which is keyed by the queue manager,
+// a native HA instance, a channel, or a queue
+func getLabelValues(label string, qmName string) []string {
+	switch {
+	case label == qmgrLabelValue:
+		return []string{qmName}
+	case strings.HasPrefix(label, nhaLabelValue):
+		return []string{strings.ReplaceAll(label, nhaLabelValue, ""), qmName}
+	case strings.HasPrefix(label, channelLabelValue):
+		channelName, channelType := splitChannelLabel(label)
+		return []string{channelName, channelType, qmName}
+	default:
+		return []string{label, qmName}
+	}
+}
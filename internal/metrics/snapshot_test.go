@@ -0,0 +1,150 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestDeltaTotals(t *testing.T) {
+	totals := make(deltaTotals)
+	totals.add("key", map[string]float64{"A": 2, "B": 0})
+	totals.add("key", map[string]float64{"A": 3, "B": -1})
+	total := totals.add("key", nil)
+	expected := map[string]float64{"A": 5, "B": 0}
+	if !reflect.DeepEqual(total, expected) {
+		t.Errorf("Expected totals %v; got %v", expected, total)
+	}
+}
+
+func TestGetLabelValues(t *testing.T) {
+	tests := []struct {
+		label    string
+		expected []string
+	}{
+		{qmgrLabelValue, []string{"QM1"}},
+		{nhaLabelValue + "qm-replica-1", []string{"qm-replica-1", "QM1"}},
+		{channelLabelValue + "SDR/TO.QM2", []string{"TO.QM2", "SDR", "QM1"}},
+		{"APP.QUEUE", []string{"APP.QUEUE", "QM1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			actual := getLabelValues(tt.label, "QM1")
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expected %v; got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// collectValue scrapes the exporter, and returns the value of the only metric
+func collectValue(t *testing.T, exporter *exporter) float64 {
+	ch := make(chan prometheus.Metric, 10)
+	exporter.Collect(ch)
+	close(ch)
+	values := []float64{}
+	for m := range ch {
+		metric := dto.Metric{}
+		err := m.Write(&metric)
+		if err != nil {
+			t.Error(err)
+		}
+		values = append(values, metric.GetCounter().GetValue())
+	}
+	if len(values) != 1 {
+		t.Errorf("Expected 1 metric; got %v", len(values))
+		return 0
+	}
+	return values[0]
+}
+
+func TestCollect_Concurrent(t *testing.T) {
+	log := getTestLogger()
+	metrics := map[string]*metricData{
+		testKey1: {name: testElement1Name, description: testElement1Description, isDelta: true, values: map[string]float64{qmgrLabelValue: 4}},
+	}
+	totals := make(deltaTotals)
+	publishSnapshot(newSnapshot("QM1", metrics, totals, true, log))
+	publishSnapshot(newSnapshot("QM1", metrics, totals, true, log))
+
+	// Scrapes by several Prometheus servers see the same values, and don't affect the totals
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			exporter := newExporter("QM1", log)
+			for j := 0; j < 10; j++ {
+				if v := collectValue(t, exporter); v != 8 {
+					t.Errorf("Expected value=8; actual %v", v)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if totals[testKey1][qmgrLabelValue] != 8 {
+		t.Errorf("Expected total=8; actual %v", totals[testKey1][qmgrLabelValue])
+	}
+}
+
+func TestClearGauges(t *testing.T) {
+	log := getTestLogger()
+	metrics := map[string]*metricData{
+		testKey1: {name: testElement1Name, description: testElement1Description, isDelta: true, values: map[string]float64{qmgrLabelValue: 4}},
+		testKey2: {name: testElement2Name, description: testElement2Description, values: map[string]float64{qmgrLabelValue: 2}},
+	}
+	publishSnapshot(newSnapshot("QM1", metrics, make(deltaTotals), true, log))
+
+	// A failed collection leaves the counters, but no stale gauges
+	clearGauges()
+	s := getSnapshot()
+	if len(s.gauges) != 0 {
+		t.Errorf("Expected no gauges; got %v", len(s.gauges))
+	}
+	if v := collectValue(t, newExporter("QM1", log)); v != 4 {
+		t.Errorf("Expected counter value=4; actual %v", v)
+	}
+}
+
+func TestNewGatherer(t *testing.T) {
+	log := getTestLogger()
+	metrics := map[string]*metricData{
+		testKey1: {name: testElement1Name, description: testElement1Description, isDelta: true, values: map[string]float64{qmgrLabelValue: 4}},
+	}
+	publishSnapshot(newSnapshot("QM1", metrics, make(deltaTotals), true, log))
+
+	gatherer, err := NewGatherer("QM1", log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(families) != 1 || !strings.HasPrefix(families[0].GetName(), namespace+"_") {
+		names := []string{}
+		for _, family := range families {
+			names = append(names, family.GetName())
+		}
+		t.Errorf("Expected only the queue manager metric; got %v", names)
+	}
+}
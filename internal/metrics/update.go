@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2025
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -31,13 +31,22 @@ const (
 	qmgrLabelValue = mqmetric.QMgrMapKey
 	nhaLabelValue  = mqmetric.NativeHAKeyPrefix
 	requestTimeout = 10
+
+	// queueStatusKeyPrefix is the prefix of keys for metrics derived from queue status, rather than publications
+	queueStatusKeyPrefix = "QSTATUS/"
+	// rediscoverInterval is how often the list of monitored queues is refreshed, to pick up new queues
+	rediscoverInterval = 5 * time.Minute
+	// publishInterval is how often a snapshot of the metric values is published for scrapes to read.  This
+	// matches the default interval at which the queue manager publishes resource statistics.
+	publishInterval = 10 * time.Second
 )
 
 var (
-	startChannel    = make(chan bool)
-	stopChannel     = make(chan bool, 2)
-	requestChannel  = make(chan bool)
-	responseChannel = make(chan map[string]*metricData)
+	startChannel = make(chan bool)
+	stopChannel  = make(chan bool, 2)
+
+	// monitoredQueues is a comma-separated list of queue name patterns, for which queue metrics are generated
+	monitoredQueues = ""
 )
 
 type metricData struct {
@@ -45,25 +54,32 @@ type metricData struct {
 	description string
 	objectType  bool
 	nhaType     bool
+	channelType bool
 	values      map[string]float64
 	isDelta     bool
 }
 
-// processMetrics processes publications of metric data and handles describe/collect/stop requests
+// processMetrics processes publications of metric data, publishes snapshots of the metric values, and handles
+// stop requests
 func processMetrics(log *logger.Logger, qmName string) {
 
 	var err error
 	var firstConnect = true
 	var metrics map[string]*metricData
+	var lastDiscovery time.Time
+	// Running totals of delta metrics are kept across reconnections, so that counters never decrease
+	totals := make(deltaTotals)
 
 	for {
+		// Values built up before the first publication after connecting are discarded, as they may cover
+		// an interval which was partly counted before the connection was lost.  Activity from when the
+		// connection was lost until the first publication after reconnecting is therefore not counted.
+		firstPublication := true
+
 		// Connect to queue manager and discover available metrics
 		err = doConnect(qmName)
 		if err == nil {
-			if firstConnect {
-				firstConnect = false
-				startChannel <- true
-			}
+			lastDiscovery = time.Now()
 			// #nosec G104
 			metrics, _ = initialiseMetrics(log)
 		}
@@ -75,25 +91,40 @@ func processMetrics(log *logger.Logger, qmName string) {
 			// TODO: If we have a large number of metrics to process, then we could be blocked from responding to stop requests
 			err = mqmetric.ProcessPublications()
 
-			// Handle describe/collect/stop requests
+			// Discover any queues and channels created since they were last discovered
+			if err == nil && time.Since(lastDiscovery) > rediscoverInterval {
+				if monitoredQueues != "" {
+					err = mqmetric.RediscoverAndSubscribe(getDiscoverConfig())
+				}
+				if err == nil && monitoredChannels != "" {
+					err = rediscoverChannels()
+				}
+				lastDiscovery = time.Now()
+			}
+
+			// Publish a snapshot of the metric values, then wait for the next publication or a stop request
 			if err == nil {
+				updateAllMetrics(log, metrics)
+				publishSnapshot(newSnapshot(qmName, metrics, totals, !firstPublication, log))
+				firstPublication = false
+				if firstConnect {
+					firstConnect = false
+					startChannel <- true
+				}
 				select {
-				case collect := <-requestChannel:
-					if collect {
-						updateMetrics(metrics)
-					}
-					responseChannel <- metrics
 				case <-stopChannel:
 					log.Println("Stopping metrics gathering")
 					mqmetric.EndConnection()
 					return
-				case <-time.After(requestTimeout * time.Second):
-					log.Debugf("Metrics: No requests received within timeout period (%d seconds)", requestTimeout)
+				case <-time.After(publishInterval):
 				}
 			}
 		}
 		log.Errorf("Metrics Error: %s", err.Error())
 
+		// Stop reporting the last gauge values until they can be collected again
+		clearGauges()
+
 		// Close the connection
 		mqmetric.EndConnection()
 
@@ -108,6 +139,23 @@ func processMetrics(log *logger.Logger, qmName string) {
 	}
 }
 
+// updateAllMetrics updates the values of the metrics from publications, and from queue and channel status
+func updateAllMetrics(log *logger.Logger, metrics map[string]*metricData) {
+	updateMetrics(metrics)
+	if monitoredQueues != "" {
+		queueErr := updateQueueStatusMetrics(metrics)
+		if queueErr != nil {
+			log.Errorf("Metrics Error: Failed to collect queue status: %v", queueErr)
+		}
+	}
+	if monitoredChannels != "" {
+		channelErr := updateChannelStatusMetrics(metrics)
+		if channelErr != nil {
+			log.Errorf("Metrics Error: Failed to collect channel status: %v", channelErr)
+		}
+	}
+}
+
 // doConnect connects to the queue manager and discovers available metrics
 func doConnect(qmName string) error {
 
@@ -117,6 +165,8 @@ func doConnect(qmName string) error {
 	connConfig.UserId = ""
 	connConfig.Password = ""
 	connConfig.UsePublications = true
+	// Report channels which are not running, so that a stopped channel can be detected
+	connConfig.ShowInactiveChannels = monitoredChannels != ""
 
 	// Connect to the queue manager - open the command and dynamic reply queues
 	err := mqmetric.InitConnection(qmName, "SYSTEM.DEFAULT.MODEL.QUEUE", "", &connConfig)
@@ -124,14 +174,43 @@ func doConnect(qmName string) error {
 		return fmt.Errorf("Failed to connect to queue manager %s: %v", qmName, err)
 	}
 	// Discover available metrics for the queue manager and subscribe to them
-	err = mqmetric.DiscoverAndSubscribe(mqmetric.DiscoverConfig{})
+	err = mqmetric.DiscoverAndSubscribe(getDiscoverConfig())
 	if err != nil {
 		return fmt.Errorf("Failed to discover and subscribe to metrics: %v", err)
 	}
+	if monitoredChannels != "" {
+		err = rediscoverChannels()
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// getDiscoverConfig returns the configuration for discovering metrics, including any monitored queues
+func getDiscoverConfig() mqmetric.DiscoverConfig {
+	var discoverConfig mqmetric.DiscoverConfig
+	if monitoredQueues != "" {
+		discoverConfig.MonitoredQueues.ObjectNames = monitoredQueues
+		discoverConfig.MonitoredQueues.UseWildcard = true
+	}
+	return discoverConfig
+}
+
+// includeMetricType returns true if metrics of the specified type should be processed, and whether they are
+// object (queue) metrics.  Queue metrics are only processed if any queues are being monitored.
+func includeMetricType(metricClass *mqmetric.MonClass, metricType *mqmetric.MonType) (bool, bool) {
+	switch {
+	case metricClass.Name == "NHAREPLICA":
+		return true, false
+	case metricClass.Name == "STATQ":
+		return monitoredQueues != "", true
+	default:
+		return !strings.Contains(metricType.ObjectTopic, "%s"), false
+	}
+}
+
 // initialiseMetrics sets initial details for all available metrics
 func initialiseMetrics(log *logger.Logger) (map[string]*metricData, error) {
 
@@ -142,43 +221,49 @@ func initialiseMetrics(log *logger.Logger) (map[string]*metricData, error) {
 	for _, metricClass := range mqmetric.Metrics.Classes {
 		for _, metricType := range metricClass.Types {
 			isNHA := metricClass.Name == "NHAREPLICA"
-			if isNHA || !strings.Contains(metricType.ObjectTopic, "%s") {
+			if include, isObject := includeMetricType(metricClass, metricType); include {
 				for _, metricElement := range metricType.Elements {
 					// Get unique metric key
 					key := makeKey(metricElement)
 
-					// Get metric name from mapping
-					if metricLookup, found := metricNamesMap[key]; found {
-
-						// Check if metric is enabled
-						if metricLookup.enabled {
-
-							// Check if metric is a delta type
-							isDelta := false
-							if metricElement.Datatype == ibmmq.MQIAMO_MONITOR_DELTA {
-								isDelta = true
-							}
-
-							// Set metric details
-							metric := metricData{
-								name:        metricLookup.name,
-								description: metricElement.Description,
-								isDelta:     isDelta,
-								nhaType:     isNHA,
-							}
-
-							// Add metric
-							if _, exists := metrics[key]; !exists {
-								metrics[key] = &metric
-							} else {
-								log.Errorf("Metrics Error: Found duplicate metric key [%s]", key)
-								validMetrics = false
-							}
-						} else {
-							log.Debugf("Metrics: Skipping metric, metric is not enabled for key [%s]", key)
+					// Check if metric is a delta type
+					isDelta := false
+					if metricElement.Datatype == ibmmq.MQIAMO_MONITOR_DELTA {
+						isDelta = true
+					}
+
+					// Get metric name from mapping, or generate a name for an unexpected key if configured
+					metricLookup, found := metricNamesMap[key]
+					if !found {
+						if !selectedMetrics.exportUnmapped {
+							log.Printf("Metrics Warning: Skipping metric, unexpected key [%s]", key)
+							validMetrics = false
+							continue
 						}
+						metricLookup = generatedMetricLookup(key, isDelta)
+						log.Debugf("Metrics: Using generated name %s for unexpected key [%s]", metricLookup.name, key)
+					}
+
+					// Check if metric is enabled
+					if !selectedMetrics.enabled(getFullMetricName(metricLookup.name, isObject, isNHA, false), metricLookup.enabled) {
+						log.Debugf("Metrics: Skipping metric, metric is not enabled for key [%s]", key)
+						continue
+					}
+
+					// Set metric details
+					metric := metricData{
+						name:        metricLookup.name,
+						description: metricElement.Description,
+						objectType:  isObject,
+						isDelta:     isDelta,
+						nhaType:     isNHA,
+					}
+
+					// Add metric
+					if _, exists := metrics[key]; !exists {
+						metrics[key] = &metric
 					} else {
-						log.Printf("Metrics Warning: Skipping metric, unexpected key [%s]", key)
+						log.Errorf("Metrics Error: Found duplicate metric key [%s]", key)
 						validMetrics = false
 					}
 				}
@@ -186,19 +271,44 @@ func initialiseMetrics(log *logger.Logger) (map[string]*metricData, error) {
 		}
 	}
 
+	if monitoredQueues != "" {
+		initialiseQueueStatusMetrics(metrics)
+	}
+	if monitoredChannels != "" {
+		initialiseChannelStatusMetrics(metrics)
+	}
+
 	if !validMetrics {
 		return metrics, fmt.Errorf("Invalid metrics data")
 	}
 	return metrics, nil
 }
 
+// initialiseQueueStatusMetrics sets initial details for metrics derived from queue status
+func initialiseQueueStatusMetrics(metrics map[string]*metricData) {
+
+	mqmetric.QueueInitAttributes()
+	queueStatus := mqmetric.GetObjectStatus(mqmetric.GetConnectionKey(), mqmetric.OT_Q)
+
+	for attr, metricLookup := range generateQueueStatusMetricNamesMap() {
+		statusAttribute, ok := queueStatus.Attributes[attr]
+		if !ok || !selectedMetrics.enabled(getFullMetricName(metricLookup.name, true, false, false), metricLookup.enabled) {
+			continue
+		}
+		metrics[queueStatusKeyPrefix+attr] = &metricData{
+			name:        metricLookup.name,
+			description: statusAttribute.Description,
+			objectType:  true,
+		}
+	}
+}
+
 // updateMetrics updates values for all available metrics
 func updateMetrics(metrics map[string]*metricData) {
 
 	for _, metricClass := range mqmetric.Metrics.Classes {
-		isNHA := metricClass.Name == "NHAREPLICA"
 		for _, metricType := range metricClass.Types {
-			if isNHA || !strings.Contains(metricType.ObjectTopic, "%s") {
+			if include, _ := includeMetricType(metricClass, metricType); include {
 				for _, metricElement := range metricType.Elements {
 
 					// Unexpected metric elements (with no defined mapping) are handled in 'initialiseMetrics'
@@ -225,6 +335,30 @@ func updateMetrics(metrics map[string]*metricData) {
 	}
 }
 
+// updateQueueStatusMetrics updates values for metrics derived from the status of the monitored queues
+func updateQueueStatusMetrics(metrics map[string]*metricData) error {
+
+	err := mqmetric.CollectQueueStatus(monitoredQueues)
+	if err != nil {
+		return err
+	}
+	queueStatus := mqmetric.GetObjectStatus(mqmetric.GetConnectionKey(), mqmetric.OT_Q)
+
+	for attr, statusAttribute := range queueStatus.Attributes {
+		metric, ok := metrics[queueStatusKeyPrefix+attr]
+		if !ok {
+			continue
+		}
+		metric.values = make(map[string]float64)
+		for queueName, value := range statusAttribute.Values {
+			if value.IsInt64 {
+				metric.values[queueName] = mqmetric.QueueNormalise(statusAttribute, value.ValueInt64)
+			}
+		}
+	}
+	return nil
+}
+
 // makeKey builds a unique key for each metric
 func makeKey(metricElement *mqmetric.MonElement) string {
 	return metricElement.Parent.Parent.Name + "/" + metricElement.Parent.Name + "/" + metricElement.Description
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2025
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -84,6 +84,44 @@ func TestInitialiseMetrics_UnexpectedKey(t *testing.T) {
 	}
 }
 
+func TestInitialiseMetrics_UnexpectedKeyExported(t *testing.T) {
+
+	teardownTestCase := setupTestCase(false)
+	defer teardownTestCase()
+	selectedMetrics = metricSelection{exportUnmapped: true}
+	defer func() { selectedMetrics = metricSelection{} }()
+
+	mqmetric.Metrics.Classes[0].Types[0].Elements[0].Description = "New Metric"
+	metrics, err := initialiseMetrics(getTestLogger())
+
+	if err != nil {
+		t.Errorf("Unexpected error %s", err.Error())
+	}
+	metric, ok := metrics[testClassName+"/"+testTypeName+"/New Metric"]
+	if !ok {
+		t.Error("Expected metric not found in map")
+	} else if metric.name != "cpu_systemsummary_new_metric" {
+		t.Errorf("Expected name=%s; actual %s", "cpu_systemsummary_new_metric", metric.name)
+	}
+}
+
+func TestInitialiseMetrics_Disabled(t *testing.T) {
+
+	teardownTestCase := setupTestCase(false)
+	defer teardownTestCase()
+	selectedMetrics = metricSelection{rules: []selectionRule{{"ibmmq_qmgr_cpu_*", false}}}
+	defer func() { selectedMetrics = metricSelection{} }()
+
+	metrics, err := initialiseMetrics(getTestLogger())
+
+	if err != nil {
+		t.Errorf("Unexpected error %s", err.Error())
+	}
+	if len(metrics) != 0 {
+		t.Errorf("Expected disabled metric not to be in map, map size=%d", len(metrics))
+	}
+}
+
 func TestInitialiseMetrics_DuplicateKeys(t *testing.T) {
 
 	teardownTestCase := setupTestCase(true)
@@ -195,3 +233,34 @@ func getTestLogger() *logger.Logger {
 	log, _ := logger.NewLogger(os.Stdout, false, false, "test")
 	return log
 }
+
+func TestIncludeMetricType(t *testing.T) {
+	tests := []struct {
+		className       string
+		objectTopic     string
+		monitoredQueues string
+		include         bool
+		isObject        bool
+	}{
+		{"CPU", "$SYS/MQ/INFO/QMGR/QM1/Monitor/CPU/SystemSummary", "", true, false},
+		{"CPU", "$SYS/MQ/INFO/QMGR/QM1/Monitor/CPU/%s", "", false, false},
+		{"NHAREPLICA", "$SYS/MQ/INFO/QMGR/QM1/Monitor/NHAREPLICA/%s", "", true, false},
+		{"STATQ", "$SYS/MQ/INFO/QMGR/QM1/Monitor/STATQ/%s/GENERAL", "", false, true},
+		{"STATQ", "$SYS/MQ/INFO/QMGR/QM1/Monitor/STATQ/%s/GENERAL", "APP.*", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.className+"/"+tt.monitoredQueues, func(t *testing.T) {
+			monitoredQueues = tt.monitoredQueues
+			defer func() { monitoredQueues = "" }()
+			metricClass := &mqmetric.MonClass{Name: tt.className}
+			metricType := &mqmetric.MonType{ObjectTopic: tt.objectTopic}
+			include, isObject := includeMetricType(metricClass, metricType)
+			if include != tt.include {
+				t.Errorf("Expected include=%v; actual %v", tt.include, include)
+			}
+			if include && isObject != tt.isObject {
+				t.Errorf("Expected isObject=%v; actual %v", tt.isObject, isObject)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqevent
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ibm-messaging/mq-golang/v5/ibmmq"
+)
+
+const timestampFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// infoReasons are the event reasons which report normal operation, rather than a problem
+var infoReasons = map[int32]bool{
+	ibmmq.MQRC_CHANNEL_ACTIVATED:       true,
+	ibmmq.MQRC_CHANNEL_STARTED:         true,
+	ibmmq.MQRC_CHANNEL_STOPPED_BY_USER: true,
+	ibmmq.MQRC_COMMAND_MQSC:            true,
+	ibmmq.MQRC_COMMAND_PCF:             true,
+	ibmmq.MQRC_CONFIG_CHANGE_OBJECT:    true,
+	ibmmq.MQRC_CONFIG_CREATE_OBJECT:    true,
+	ibmmq.MQRC_CONFIG_DELETE_OBJECT:    true,
+	ibmmq.MQRC_CONFIG_REFRESH_OBJECT:   true,
+	ibmmq.MQRC_LOGGER_STATUS:           true,
+	ibmmq.MQRC_Q_DEPTH_LOW:             true,
+	ibmmq.MQRC_Q_MGR_ACTIVE:            true,
+	ibmmq.MQRC_Q_SERVICE_INTERVAL_OK:   true,
+}
+
+// parameter is a decoded PCF parameter of an event message
+type parameter struct {
+	name  string
+	value interface{}
+}
+
+// formatEvent decodes a PCF event message, and returns it as a JSON log entry of type "mq_event"
+func formatEvent(qmName string, queue string, putTime time.Time, buf []byte) (string, error) {
+	cfh, offset := ibmmq.ReadPCFHeader(buf)
+	if cfh == nil || cfh.Type != ibmmq.MQCFT_EVENT {
+		return "", fmt.Errorf("message on %v is not a PCF event message", queue)
+	}
+
+	params := make([]*ibmmq.PCFParameter, 0, cfh.ParameterCount)
+	for i := int32(0); i < cfh.ParameterCount && offset < len(buf); i++ {
+		param, bytesRead := ibmmq.ReadPCFParameter(buf[offset:])
+		if bytesRead == 0 {
+			break
+		}
+		offset += bytesRead
+		params = append(params, param)
+	}
+	decoded := decodeParameters(params)
+
+	reason := ibmmq.MQItoString("RC", int(cfh.Reason))
+	if reason == "" {
+		reason = strconv.Itoa(int(cfh.Reason))
+	}
+	eventType := ibmmq.MQItoString("CMD", int(cfh.Command))
+	if eventType == "" {
+		eventType = strconv.Itoa(int(cfh.Command))
+	}
+	if putTime.IsZero() {
+		putTime = time.Now()
+	}
+	host, _ := os.Hostname()
+
+	data := make(map[string]interface{}, len(decoded))
+	for _, p := range decoded {
+		data[p.name] = p.value
+	}
+	entry := map[string]interface{}{
+		"ibm_datetime":        putTime.Format(timestampFormat),
+		"type":                "mq_event",
+		"host":                host,
+		"ibm_serverName":      qmName,
+		"loglevel":            getLogLevel(cfh.Reason),
+		"message":             formatMessage(reason, decoded),
+		"ibm_eventQueue":      queue,
+		"ibm_eventType":       eventType,
+		"ibm_eventReason":     reason,
+		"ibm_eventReasonCode": cfh.Reason,
+		"ibm_eventData":       data,
+	}
+	out, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// getLogLevel returns the log level for an event with the specified reason
+func getLogLevel(reason int32) string {
+	if infoReasons[reason] {
+		return "INFO"
+	}
+	return "WARNING"
+}
+
+// formatMessage returns a one-line description of an event, containing its reason and the value of each of
+// its parameters, excluding any groups of parameters
+func formatMessage(reason string, params []parameter) string {
+	msg := strings.Builder{}
+	msg.WriteString("MQ event ")
+	msg.WriteString(reason)
+	first := true
+	for _, p := range params {
+		if _, isGroup := p.value.(map[string]interface{}); isGroup {
+			continue
+		}
+		if first {
+			msg.WriteString(":")
+			first = false
+		} else {
+			msg.WriteString(",")
+		}
+		msg.WriteString(fmt.Sprintf(" %s(%v)", p.name, p.value))
+	}
+	return msg.String()
+}
+
+// decodeParameters converts PCF parameters into names and values.  Integer values which correspond to
+// MQI constants are converted into the name of the constant.
+func decodeParameters(params []*ibmmq.PCFParameter) []parameter {
+	decoded := make([]parameter, 0, len(params))
+	for _, p := range params {
+		var value interface{}
+		switch p.Type {
+		case ibmmq.MQCFT_INTEGER, ibmmq.MQCFT_INTEGER64:
+			if len(p.Int64Value) > 0 {
+				value = integerValue(p.Parameter, p.Int64Value[0])
+			}
+		case ibmmq.MQCFT_INTEGER_LIST, ibmmq.MQCFT_INTEGER64_LIST:
+			values := make([]interface{}, 0, len(p.Int64Value))
+			for _, v := range p.Int64Value {
+				values = append(values, integerValue(p.Parameter, v))
+			}
+			value = values
+		case ibmmq.MQCFT_STRING, ibmmq.MQCFT_BYTE_STRING:
+			if len(p.String) > 0 {
+				value = strings.TrimSpace(p.String[0])
+			}
+		case ibmmq.MQCFT_STRING_LIST:
+			values := make([]string, 0, len(p.String))
+			for _, v := range p.String {
+				values = append(values, strings.TrimSpace(v))
+			}
+			value = values
+		case ibmmq.MQCFT_GROUP:
+			group := make(map[string]interface{})
+			for _, gp := range decodeParameters(p.GroupList) {
+				group[gp.name] = gp.value
+			}
+			value = group
+		default:
+			continue
+		}
+		decoded = append(decoded, parameter{name: parameterName(p), value: value})
+	}
+	return decoded
+}
+
+// integerValue returns the name of the MQI constant for an integer parameter value, or the value itself
+func integerValue(param int32, value int64) interface{} {
+	if ibmmq.PCFAttrToPrefix(param) != "" {
+		return ibmmq.PCFValueToString(param, value)
+	}
+	return value
+}
+
+// parameterName returns the name of the MQI constant which identifies a PCF parameter
+func parameterName(p *ibmmq.PCFParameter) string {
+	class := ""
+	switch p.Type {
+	case ibmmq.MQCFT_STRING, ibmmq.MQCFT_STRING_LIST:
+		class = "CA"
+	case ibmmq.MQCFT_BYTE_STRING:
+		class = "BACF"
+	case ibmmq.MQCFT_GROUP:
+		class = "GACF"
+	default:
+		class = "IA"
+	}
+	name := ibmmq.MQItoString(class, int(p.Parameter))
+	if name == "" {
+		name = strconv.Itoa(int(p.Parameter))
+	}
+	return name
+}
@@ -0,0 +1,178 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mqevent contains code to read MQ event messages from the queue manager's event queues
+package mqevent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+	"github.com/ibm-messaging/mq-golang/v5/ibmmq"
+)
+
+const (
+	pollInterval    = 2 * time.Second
+	retryInterval   = 10 * time.Second
+	initialBufSize  = 64 * 1024
+	maxEventBufSize = 4 * 1024 * 1024
+)
+
+// DefaultEventQueues are the event queues read if no queues are configured
+var DefaultEventQueues = []string{
+	"SYSTEM.ADMIN.QMGR.EVENT",
+	"SYSTEM.ADMIN.CHANNEL.EVENT",
+	"SYSTEM.ADMIN.PERFM.EVENT",
+	"SYSTEM.ADMIN.CONFIG.EVENT",
+	"SYSTEM.ADMIN.COMMAND.EVENT",
+}
+
+// GetEventQueues returns the event queues set in MQ_LOGGING_EVENT_QUEUES, or the default event queues
+func GetEventQueues() []string {
+	queues := make([]string, 0)
+	for _, queue := range strings.Split(os.Getenv("MQ_LOGGING_EVENT_QUEUES"), ",") {
+		if queue = strings.TrimSpace(queue); queue != "" {
+			queues = append(queues, queue)
+		}
+	}
+	if len(queues) == 0 {
+		return DefaultEventQueues
+	}
+	return queues
+}
+
+// Consume starts a goroutine which reads event messages from the specified queues, and calls the supplied
+// function with each event formatted as a JSON log entry.  Messages are removed from the queues as they are
+// read.  If the queue manager is not available, the connection is retried until the context is cancelled.
+func Consume(ctx context.Context, wg *sync.WaitGroup, qmName string, queues []string, emit func(msg string), log *logger.Logger) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			err := consumeUntilError(ctx, qmName, queues, emit, log)
+			if err != nil {
+				log.Debugf("Event queues unavailable, retrying in %v: %v", retryInterval, err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryInterval):
+			}
+		}
+	}()
+}
+
+// consumeUntilError connects to the queue manager, and reads event messages until an error occurs or
+// the context is cancelled
+func consumeUntilError(ctx context.Context, qmName string, queues []string, emit func(msg string), log *logger.Logger) error {
+	// Connect in bindings mode, in the same way as for metrics
+	cno := ibmmq.NewMQCNO()
+	cno.Options = ibmmq.MQCNO_HANDLE_SHARE_BLOCK
+	qMgr, err := ibmmq.Connx(qmName, cno)
+	if err != nil {
+		return fmt.Errorf("failed to connect to queue manager %v: %v", qmName, err)
+	}
+	defer func() {
+		// #nosec G104
+		qMgr.Disc()
+	}()
+
+	objects := make(map[string]ibmmq.MQObject, len(queues))
+	defer func() {
+		for _, obj := range objects {
+			// #nosec G104
+			obj.Close(0)
+		}
+	}()
+	for _, queue := range queues {
+		od := ibmmq.NewMQOD()
+		od.ObjectType = ibmmq.MQOT_Q
+		od.ObjectName = queue
+		obj, err := qMgr.Open(od, ibmmq.MQOO_INPUT_SHARED|ibmmq.MQOO_FAIL_IF_QUIESCING)
+		if err != nil {
+			return fmt.Errorf("failed to open event queue %v: %v", queue, err)
+		}
+		objects[queue] = obj
+	}
+	log.Printf("Reading events from queues: %v", strings.Join(queues, ", "))
+
+	buf := make([]byte, initialBufSize)
+	for {
+		for _, queue := range queues {
+			buf, err = getEvents(objects[queue], qmName, queue, buf, emit, log)
+			if err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// getEvents reads all of the messages currently available on an event queue.  The buffer is returned,
+// as it may have been enlarged to read a large message.  A message which is larger than maxEventBufSize is
+// removed from the queue and discarded, so that it doesn't stop the messages behind it from being read.
+func getEvents(obj ibmmq.MQObject, qmName string, queue string, buf []byte, emit func(msg string), log *logger.Logger) ([]byte, error) {
+	acceptTruncated := false
+	for {
+		md := ibmmq.NewMQMD()
+		gmo := ibmmq.NewMQGMO()
+		gmo.Options = ibmmq.MQGMO_NO_WAIT | ibmmq.MQGMO_NO_SYNCPOINT | ibmmq.MQGMO_FAIL_IF_QUIESCING | ibmmq.MQGMO_CONVERT
+		if acceptTruncated {
+			gmo.Options |= ibmmq.MQGMO_ACCEPT_TRUNCATED_MSG
+		}
+		datalen, err := obj.Get(md, gmo, buf)
+		if err != nil {
+			mqret, ok := err.(*ibmmq.MQReturn)
+			switch {
+			case ok && mqret.MQRC == ibmmq.MQRC_NO_MSG_AVAILABLE:
+				return buf, nil
+			case ok && mqret.MQRC == ibmmq.MQRC_TRUNCATED_MSG_FAILED && datalen <= maxEventBufSize:
+				buf = make([]byte, datalen)
+				continue
+			case ok && mqret.MQRC == ibmmq.MQRC_TRUNCATED_MSG_FAILED:
+				// Use the largest buffer, so that only a message which is too large can be truncated
+				buf = make([]byte, maxEventBufSize)
+				acceptTruncated = true
+				continue
+			case ok && mqret.MQRC == ibmmq.MQRC_TRUNCATED_MSG_ACCEPTED:
+				acceptTruncated = false
+				log.Printf("Discarded a message of %v bytes from event queue %v, as it is larger than the maximum of %v bytes", datalen, queue, maxEventBufSize)
+				continue
+			}
+			return buf, fmt.Errorf("failed to get message from event queue %v: %v", queue, err)
+		}
+		acceptTruncated = false
+		if strings.TrimSpace(md.Format) != strings.TrimSpace(ibmmq.MQFMT_EVENT) {
+			log.Debugf("Ignoring message with format '%v' on event queue %v", md.Format, queue)
+			continue
+		}
+		msg, err := formatEvent(qmName, queue, md.PutDateTime, buf[:datalen])
+		if err != nil {
+			log.Debugf("Ignoring event message: %v", err)
+			continue
+		}
+		emit(msg)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package mqevent
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ibm-messaging/mq-golang/v5/ibmmq"
+)
+
+func TestGetEventQueues(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected []string
+	}{
+		{"", DefaultEventQueues},
+		{" , ", DefaultEventQueues},
+		{"SYSTEM.ADMIN.QMGR.EVENT", []string{"SYSTEM.ADMIN.QMGR.EVENT"}},
+		{"SYSTEM.ADMIN.QMGR.EVENT, SYSTEM.ADMIN.CHANNEL.EVENT", []string{"SYSTEM.ADMIN.QMGR.EVENT", "SYSTEM.ADMIN.CHANNEL.EVENT"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("MQ_LOGGING_EVENT_QUEUES", tt.value)
+			queues := GetEventQueues()
+			if !reflect.DeepEqual(queues, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, queues)
+			}
+		})
+	}
+}
+
+func TestGetLogLevel(t *testing.T) {
+	if level := getLogLevel(ibmmq.MQRC_NOT_AUTHORIZED); level != "WARNING" {
+		t.Errorf("Expected WARNING for MQRC_NOT_AUTHORIZED, got %v", level)
+	}
+	if level := getLogLevel(ibmmq.MQRC_CHANNEL_STARTED); level != "INFO" {
+		t.Errorf("Expected INFO for MQRC_CHANNEL_STARTED, got %v", level)
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	params := []parameter{
+		{name: "MQCA_Q_MGR_NAME", value: "QM1"},
+		{name: "MQIACF_REASON_QUALIFIER", value: "MQRQ_CONN_NOT_AUTHORIZED"},
+		{name: "MQGACF_COMMAND_CONTEXT", value: map[string]interface{}{"MQCACF_USER_IDENTIFIER": "app"}},
+		{name: "MQCACF_USER_IDENTIFIER", value: "app"},
+	}
+	expected := "MQ event MQRC_NOT_AUTHORIZED: MQCA_Q_MGR_NAME(QM1), MQIACF_REASON_QUALIFIER(MQRQ_CONN_NOT_AUTHORIZED), MQCACF_USER_IDENTIFIER(app)"
+	msg := formatMessage("MQRC_NOT_AUTHORIZED", params)
+	if msg != expected {
+		t.Errorf("Expected '%v', got '%v'", expected, msg)
+	}
+	expected = "MQ event MQRC_Q_MGR_ACTIVE"
+	msg = formatMessage("MQRC_Q_MGR_ACTIVE", nil)
+	if msg != expected {
+		t.Errorf("Expected '%v', got '%v'", expected, msg)
+	}
+}
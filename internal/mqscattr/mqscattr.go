@@ -0,0 +1,63 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mqscattr parses the attributes in the output of MQ commands such as runmqsc and dspmq
+package mqscattr
+
+import "strings"
+
+// Attribute is a named value, such as "STATUS(RUNNING)"
+type Attribute struct {
+	Name  string
+	Value string
+}
+
+// Parse returns the attributes in a line of command output, such as "CONNAME(10.0.0.1(1414)) STATUS(RUNNING)",
+// in the order they appear.  A value can contain parentheses, such as an address with a port number.
+// Attributes without a value, such as "CURRENT", are ignored.
+func Parse(line string) []Attribute {
+	attributes := []Attribute{}
+	for {
+		open := strings.IndexByte(line, '(')
+		if open < 0 {
+			return attributes
+		}
+		name := strings.TrimSpace(line[:open])
+		if i := strings.LastIndexAny(name, " \t"); i >= 0 {
+			name = name[i+1:]
+		}
+		depth := 0
+		end := -1
+		for i := open; i < len(line) && end < 0; i++ {
+			switch line[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					end = i
+				}
+			}
+		}
+		if end < 0 {
+			return attributes
+		}
+		if name != "" {
+			attributes = append(attributes, Attribute{Name: name, Value: strings.TrimSpace(line[open+1 : end])})
+		}
+		line = line[end+1:]
+	}
+}
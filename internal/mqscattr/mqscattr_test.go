@@ -0,0 +1,44 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package mqscattr
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected []Attribute
+	}{
+		{"", []Attribute{}},
+		{"AMQ8417I: Display Channel Status details.", []Attribute{}},
+		{"   CHANNEL(DEV.APP.SVRCONN)                CHLTYPE(SVRCONN)", []Attribute{{"CHANNEL", "DEV.APP.SVRCONN"}, {"CHLTYPE", "SVRCONN"}}},
+		{"CONNAME(host(1414))   STATUS(RUNNING)", []Attribute{{"CONNAME", "host(1414)"}, {"STATUS", "RUNNING"}}},
+		{"QMNAME(QM1)  STATUS(RUNNING AS STANDBY)", []Attribute{{"QMNAME", "QM1"}, {"STATUS", "RUNNING AS STANDBY"}}},
+		{"CURRENT STATUS(RUNNING)", []Attribute{{"STATUS", "RUNNING"}}},
+		{"STATUS(RUNNING) CONNAME(host(1414)", []Attribute{{"STATUS", "RUNNING"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			attributes := Parse(test.line)
+			if !slices.Equal(attributes, test.expected) {
+				t.Errorf("Expected %v; got %v", test.expected, attributes)
+			}
+		})
+	}
+}
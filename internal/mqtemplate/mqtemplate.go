@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2020
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -18,6 +18,7 @@ limitations under the License.
 package mqtemplate
 
 import (
+	"io"
 	"os"
 	"path"
 	"text/template"
@@ -50,7 +51,7 @@ func ProcessTemplateFile(templateFile, destFile string, data interface{}, log *l
 	}
 
 	// #nosec G302 G304 G306 - its a read by owner/s group, and pose no harm.
-	f, err := os.OpenFile(destFile, os.O_CREATE|os.O_WRONLY, 0660)
+	f, err := os.OpenFile(destFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0660)
 	// #nosec G307 - local to this function, pose no harm.
 	defer f.Close()
 	err = t.Execute(f, data)
@@ -60,3 +61,19 @@ func ProcessTemplateFile(templateFile, destFile string, data interface{}, log *l
 	}
 	return nil
 }
+
+// ProcessTemplate takes a Go templateFile, and processes it with the
+// supplied data, writing to w
+func ProcessTemplate(templateFile string, w io.Writer, data interface{}, log *logger.Logger) error {
+	t, err := template.ParseFiles(templateFile)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	err = t.Execute(w, data)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package otlp
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultLogBufferSize is the number of log records held waiting to be exported
+	defaultLogBufferSize = 2048
+	// maxLogBatchSize is the largest number of log records sent in one request
+	maxLogBatchSize = 512
+	// logFlushInterval is the longest time a log record is held before being exported
+	logFlushInterval = 5 * time.Second
+)
+
+// OTLP severity numbers
+const (
+	severityTrace = 1
+	severityDebug = 5
+	severityInfo  = 9
+	severityInfo2 = 10
+	severityWarn  = 13
+	severityError = 17
+	severityFatal = 21
+)
+
+type logRecord struct {
+	TimeUnixNano         string     `json:"timeUnixNano"`
+	ObservedTimeUnixNano string     `json:"observedTimeUnixNano"`
+	SeverityNumber       int        `json:"severityNumber,omitempty"`
+	SeverityText         string     `json:"severityText,omitempty"`
+	Body                 anyValue   `json:"body"`
+	Attributes           []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeLogs struct {
+	Scope      scope       `json:"scope"`
+	LogRecords []logRecord `json:"logRecords"`
+}
+
+type resourceLogs struct {
+	Resource  resource    `json:"resource"`
+	ScopeLogs []scopeLogs `json:"scopeLogs"`
+}
+
+type exportLogsRequest struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+// A LogExporter sends log entries to an OpenTelemetry collector in the background.  Entries are held in
+// a bounded buffer, and are dropped if the buffer is full.
+type LogExporter struct {
+	client    *client
+	records   chan logRecord
+	done      chan struct{}
+	ctx       context.Context
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+	// mu protects closed
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewLogExporter creates a LogExporter, and starts exporting in the background
+func NewLogExporter(config Config) (*LogExporter, error) {
+	c, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &LogExporter{
+		client:  c,
+		records: make(chan logRecord, defaultLogBufferSize),
+		done:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	go e.run()
+	return e, nil
+}
+
+// Send converts a structured log entry to an OTLP log record, and queues it to be exported.  The entry
+// is not retained.
+func (e *LogExporter) Send(entry map[string]interface{}) {
+	record := toLogRecord(entry, time.Now())
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	select {
+	case e.records <- record:
+	default:
+		e.client.countDropped(1)
+	}
+}
+
+// Close exports any queued log records, waiting at most for the specified time
+func (e *LogExporter) Close(timeout time.Duration) {
+	e.closeOnce.Do(func() {
+		e.mu.Lock()
+		e.closed = true
+		close(e.records)
+		e.mu.Unlock()
+		select {
+		case <-e.done:
+		case <-time.After(timeout):
+			// Abandon any retries in progress
+			e.cancel()
+		}
+	})
+}
+
+// run exports batches of log records, when a batch is full or the flush interval has passed
+func (e *LogExporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(logFlushInterval)
+	defer ticker.Stop()
+	batch := make([]logRecord, 0, maxLogBatchSize)
+	for {
+		select {
+		case record, ok := <-e.records:
+			if !ok {
+				e.export(batch)
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) < maxLogBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+		e.export(batch)
+		batch = make([]logRecord, 0, maxLogBatchSize)
+	}
+}
+
+func (e *LogExporter) export(batch []logRecord) {
+	if len(batch) == 0 {
+		return
+	}
+	request := exportLogsRequest{
+		ResourceLogs: []resourceLogs{{
+			Resource: e.client.resource,
+			ScopeLogs: []scopeLogs{{
+				Scope:      scope{Name: scopeName},
+				LogRecords: batch,
+			}},
+		}},
+	}
+	e.client.export(e.ctx, logsPath, request, len(batch))
+}
+
+// logSeverity returns the OTLP severity number for an MQ or Liberty 'loglevel'
+func logSeverity(logLevel string) int {
+	switch strings.ToUpper(logLevel) {
+	case "FINEST", "FINER", "FINE", "ENTRY", "EXIT":
+		return severityTrace
+	case "DEBUG", "EVENT":
+		return severityDebug
+	case "INFO":
+		return severityInfo
+	case "AUDIT":
+		return severityInfo2
+	case "WARNING":
+		return severityWarn
+	case "ERROR", "SEVERE":
+		return severityError
+	case "FATAL":
+		return severityFatal
+	}
+	return 0
+}
+
+// toLogRecord converts a structured log entry, in the form used for the JSON console format, to an OTLP
+// log record.  The message is used as the body, and the other fields, apart from the time and log level,
+// are attributes.
+func toLogRecord(entry map[string]interface{}, now time.Time) logRecord {
+	logLevel, _ := entry["loglevel"].(string)
+	timestamp := now
+	if s, ok := entry["ibm_datetime"].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, strings.Replace(s, "+0000", "Z", 1)); err == nil {
+			timestamp = t
+		}
+	}
+	record := logRecord{
+		TimeUnixNano:         unixNano(timestamp),
+		ObservedTimeUnixNano: unixNano(now),
+		SeverityNumber:       logSeverity(logLevel),
+		SeverityText:         logLevel,
+		Body:                 toAnyValue(entry["message"]),
+	}
+	if _, ok := entry["message"]; !ok {
+		empty := ""
+		record.Body = anyValue{StringValue: &empty}
+	}
+	for _, k := range sortedKeys(entry) {
+		switch k {
+		case "message", "ibm_datetime", "loglevel":
+			continue
+		}
+		record.Attributes = append(record.Attributes, keyValue{Key: k, Value: toAnyValue(entry[k])})
+	}
+	return record
+}
@@ -0,0 +1,91 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package otlp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestToLogRecord(t *testing.T) {
+	entry := map[string]interface{}{
+		"ibm_datetime":     "2026-03-04T05:06:07.890Z",
+		"ibm_messageId":    "AMQ9999E",
+		"ibm_arithInsert1": float64(2),
+		"loglevel":         "ERROR",
+		"message":          "AMQ9999E: Channel program ended abnormally.",
+	}
+	now := time.Date(2026, 3, 4, 5, 6, 8, 0, time.UTC)
+	b, err := json.Marshal(toLogRecord(entry, now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"timeUnixNano":"1772600767890000000","observedTimeUnixNano":"1772600768000000000","severityNumber":17,"severityText":"ERROR","body":{"stringValue":"AMQ9999E: Channel program ended abnormally."},"attributes":[{"key":"ibm_arithInsert1","value":{"doubleValue":2}},{"key":"ibm_messageId","value":{"stringValue":"AMQ9999E"}}]}`
+	if string(b) != expected {
+		t.Errorf("Expected:\n%v\ngot:\n%v", expected, string(b))
+	}
+}
+
+func TestLogSeverity(t *testing.T) {
+	tests := map[string]int{
+		"FINEST":  1,
+		"DEBUG":   5,
+		"INFO":    9,
+		"AUDIT":   10,
+		"WARNING": 13,
+		"SEVERE":  17,
+		"fatal":   21,
+		"":        0,
+	}
+	for level, expected := range tests {
+		if s := logSeverity(level); s != expected {
+			t.Errorf("Expected severity %v for '%v'; got %v", expected, level, s)
+		}
+	}
+}
+
+func TestLogExporter(t *testing.T) {
+	collector := newTestCollector(t)
+	e, err := NewLogExporter(Config{Endpoint: collector.URL, Resource: map[string]string{"ibmmq.qmgr": "QM1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Send(map[string]interface{}{"loglevel": "INFO", "message": "first"})
+	e.Send(map[string]interface{}{"loglevel": "INFO", "message": "second"})
+	e.Close(5 * time.Second)
+	// Entries sent after closing are ignored
+	e.Send(map[string]interface{}{"loglevel": "INFO", "message": "third"})
+
+	requests := collector.received(logsPath)
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 request; got %v", len(requests))
+	}
+	var request exportLogsRequest
+	b, _ := json.Marshal(requests[0])
+	err = json.Unmarshal(b, &request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rl := request.ResourceLogs[0]
+	if len(rl.Resource.Attributes) != 1 || rl.Resource.Attributes[0].Key != "ibmmq.qmgr" || *rl.Resource.Attributes[0].Value.StringValue != "QM1" {
+		t.Errorf("Unexpected resource: %+v", rl.Resource)
+	}
+	records := rl.ScopeLogs[0].LogRecords
+	if len(records) != 2 || *records[0].Body.StringValue != "first" || *records[1].Body.StringValue != "second" {
+		t.Errorf("Unexpected log records: %+v", records)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package otlp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// aggregationTemporalityCumulative indicates that the value of a sum is the total since the start time
+const aggregationTemporalityCumulative = 2
+
+type numberDataPoint struct {
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string     `json:"timeUnixNano"`
+	AsDouble          float64    `json:"asDouble"`
+}
+
+type gauge struct {
+	DataPoints []numberDataPoint `json:"dataPoints"`
+}
+
+type sum struct {
+	DataPoints             []numberDataPoint `json:"dataPoints"`
+	AggregationTemporality int               `json:"aggregationTemporality"`
+	IsMonotonic            bool              `json:"isMonotonic"`
+}
+
+type metric struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Gauge       *gauge `json:"gauge,omitempty"`
+	Sum         *sum   `json:"sum,omitempty"`
+}
+
+type scopeMetrics struct {
+	Scope   scope    `json:"scope"`
+	Metrics []metric `json:"metrics"`
+}
+
+type resourceMetrics struct {
+	Resource     resource       `json:"resource"`
+	ScopeMetrics []scopeMetrics `json:"scopeMetrics"`
+}
+
+type exportMetricsRequest struct {
+	ResourceMetrics []resourceMetrics `json:"resourceMetrics"`
+}
+
+// A MetricExporter periodically exports the gauges and counters from a Prometheus gatherer.  Counters are
+// exported as cumulative sums, so the collector receives the correct totals regardless of how often the
+// metrics are also scraped by Prometheus.
+type MetricExporter struct {
+	client    *client
+	gatherer  prometheus.Gatherer
+	interval  time.Duration
+	startTime time.Time
+	ctx       context.Context
+	cancel    context.CancelFunc
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMetricExporter creates a MetricExporter, and starts exporting at the specified interval
+func NewMetricExporter(config Config, gatherer prometheus.Gatherer, interval time.Duration) (*MetricExporter, error) {
+	c, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid OTLP export interval: %v", interval)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &MetricExporter{
+		client:    c,
+		gatherer:  gatherer,
+		interval:  interval,
+		startTime: time.Now(),
+		ctx:       ctx,
+		cancel:    cancel,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go e.run()
+	return e, nil
+}
+
+// Close exports the metrics a final time, waiting at most for the specified time
+func (e *MetricExporter) Close(timeout time.Duration) {
+	e.closeOnce.Do(func() {
+		close(e.stop)
+		select {
+		case <-e.done:
+		case <-time.After(timeout):
+			e.cancel()
+		}
+	})
+}
+
+func (e *MetricExporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.export()
+		case <-e.stop:
+			e.export()
+			return
+		}
+	}
+}
+
+// export gathers the current metrics, and sends them to the collector
+func (e *MetricExporter) export() {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		e.client.reportFailure(fmt.Errorf("unable to gather metrics: %w", err), 0)
+		return
+	}
+	metrics, count := toMetrics(families, e.startTime, time.Now())
+	if len(metrics) == 0 {
+		// Metrics are not available yet, for example because the queue manager is not active
+		return
+	}
+	request := exportMetricsRequest{
+		ResourceMetrics: []resourceMetrics{{
+			Resource: e.client.resource,
+			ScopeMetrics: []scopeMetrics{{
+				Scope:   scope{Name: scopeName},
+				Metrics: metrics,
+			}},
+		}},
+	}
+	e.client.export(e.ctx, metricsPath, request, count)
+}
+
+// toMetrics converts Prometheus gauges and counters to OTLP metrics, and returns the number of data points.
+// Other types of metric are not used by the queue manager metrics, and are ignored.
+func toMetrics(families []*dto.MetricFamily, startTime time.Time, now time.Time) ([]metric, int) {
+	metrics := []metric{}
+	count := 0
+	for _, family := range families {
+		m := metric{Name: family.GetName(), Description: family.GetHelp()}
+		points := []numberDataPoint{}
+		for _, pm := range family.GetMetric() {
+			point := numberDataPoint{TimeUnixNano: unixNano(now)}
+			for _, label := range pm.GetLabel() {
+				point.Attributes = append(point.Attributes, stringAttribute(label.GetName(), label.GetValue()))
+			}
+			switch family.GetType() {
+			case dto.MetricType_GAUGE:
+				point.AsDouble = pm.GetGauge().GetValue()
+			case dto.MetricType_COUNTER:
+				point.StartTimeUnixNano = unixNano(startTime)
+				point.AsDouble = pm.GetCounter().GetValue()
+			default:
+				continue
+			}
+			points = append(points, point)
+		}
+		if len(points) == 0 {
+			continue
+		}
+		switch family.GetType() {
+		case dto.MetricType_GAUGE:
+			m.Gauge = &gauge{DataPoints: points}
+		case dto.MetricType_COUNTER:
+			m.Sum = &sum{DataPoints: points, AggregationTemporality: aggregationTemporalityCumulative, IsMonotonic: true}
+		}
+		metrics = append(metrics, m)
+		count += len(points)
+	}
+	return metrics, count
+}
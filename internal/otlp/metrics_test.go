@@ -0,0 +1,87 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package otlp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func newTestRegistry(t *testing.T) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: "ibmmq", Name: "object_depth", Help: "Queue depth"}, []string{"object", "qmgr"})
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Namespace: "ibmmq", Name: "qmgr_commit_total", Help: "Commit count"}, []string{"qmgr"})
+	registry.MustRegister(gauge, counter)
+	gauge.WithLabelValues("APP.QUEUE", "QM1").Set(5)
+	counter.WithLabelValues("QM1").Add(3)
+	return registry
+}
+
+func TestToMetrics(t *testing.T) {
+	families, err := newTestRegistry(t).Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start.Add(time.Minute)
+	metrics, count := toMetrics(families, start, now)
+	if count != 2 {
+		t.Errorf("Expected 2 data points; got %v", count)
+	}
+	b, err := json.Marshal(metrics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"name":"ibmmq_object_depth","description":"Queue depth","gauge":{"dataPoints":[{"attributes":[{"key":"object","value":{"stringValue":"APP.QUEUE"}},{"key":"qmgr","value":{"stringValue":"QM1"}}],"timeUnixNano":"1767225660000000000","asDouble":5}]}},` +
+		`{"name":"ibmmq_qmgr_commit_total","description":"Commit count","sum":{"dataPoints":[{"attributes":[{"key":"qmgr","value":{"stringValue":"QM1"}}],"startTimeUnixNano":"1767225600000000000","timeUnixNano":"1767225660000000000","asDouble":3}],"aggregationTemporality":2,"isMonotonic":true}}]`
+	if string(b) != expected {
+		t.Errorf("Expected:\n%v\ngot:\n%v", expected, string(b))
+	}
+}
+
+func TestMetricExporter(t *testing.T) {
+	collector := newTestCollector(t)
+	e, err := NewMetricExporter(Config{Endpoint: collector.URL}, newTestRegistry(t), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Metrics are exported when the exporter is closed
+	e.Close(5 * time.Second)
+	requests := collector.received(metricsPath)
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 request; got %v", len(requests))
+	}
+	rm := requests[0]["resourceMetrics"].([]interface{})[0].(map[string]interface{})
+	metrics := rm["scopeMetrics"].([]interface{})[0].(map[string]interface{})["metrics"].([]interface{})
+	if len(metrics) != 2 {
+		t.Errorf("Expected 2 metrics; got %v", metrics)
+	}
+}
+
+func TestMetricExporterNoMetrics(t *testing.T) {
+	collector := newTestCollector(t)
+	e, err := NewMetricExporter(Config{Endpoint: collector.URL}, prometheus.NewRegistry(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Close(5 * time.Second)
+	if len(collector.received(metricsPath)) != 0 {
+		t.Errorf("Expected no requests when there are no metrics")
+	}
+}
@@ -0,0 +1,315 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package otlp exports logs and metrics to an OpenTelemetry collector, using OTLP/HTTP with JSON encoding
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	logsPath    = "/v1/logs"
+	metricsPath = "/v1/metrics"
+
+	// scopeName is the instrumentation scope of exported logs and metrics
+	scopeName = "github.com/ibm-messaging/mq-container"
+
+	requestTimeout = 10 * time.Second
+	// maxRetryTime is the longest time spent retrying one export
+	maxRetryTime      = 30 * time.Second
+	initialRetryDelay = 1 * time.Second
+
+	// namespaceFile holds the Kubernetes namespace of the pod, if running in Kubernetes
+	namespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// Config is the configuration of an exporter
+type Config struct {
+	// Endpoint is the base URL of the collector, such as "http://collector:4318"
+	Endpoint string
+	// Resource holds the attributes of the resource which produced the logs and metrics
+	Resource map[string]string
+	// TLSConfig is used to connect to an HTTPS endpoint
+	TLSConfig *tls.Config
+	// ErrorLog is called when data can't be exported.  It must not log to a log exporter.
+	ErrorLog func(msg string)
+}
+
+// client sends OTLP requests to a collector, retrying when the collector is temporarily unavailable
+type client struct {
+	endpoint string
+	resource resource
+	http     *http.Client
+	errorLog func(msg string)
+
+	// mu protects failing and dropped, which are used to report problems without flooding the log
+	mu      sync.Mutex
+	failing bool
+	dropped int
+}
+
+// ParseEndpoint checks that an endpoint is the http or https URL of a collector
+func ParseEndpoint(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint '%v': the endpoint must be an http or https URL", endpoint)
+	}
+	return u, nil
+}
+
+func newClient(config Config) (*client, error) {
+	_, err := ParseEndpoint(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	errorLog := config.ErrorLog
+	if errorLog == nil {
+		errorLog = func(string) {}
+	}
+	return &client{
+		endpoint: strings.TrimSuffix(config.Endpoint, "/"),
+		resource: newResource(config.Resource),
+		http: &http.Client{
+			Timeout: requestTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: config.TLSConfig,
+				Proxy:           http.ProxyFromEnvironment,
+			},
+		},
+		errorLog: errorLog,
+	}, nil
+}
+
+// retryableError is returned for failures which may succeed if the request is repeated
+type retryableError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+// export sends a request to the collector, retrying with an increasing delay for up to maxRetryTime, or until
+// the context is cancelled.  The count is the number of log records or data points in the request, which
+// are reported as dropped if the export fails.
+func (c *client) export(ctx context.Context, path string, request interface{}, count int) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		c.reportFailure(fmt.Errorf("unable to encode OTLP request: %w", err), count)
+		return
+	}
+	deadline := time.Now().Add(maxRetryTime)
+	delay := initialRetryDelay
+	for {
+		err = c.post(ctx, path, body)
+		if err == nil {
+			c.reportSuccess()
+			return
+		}
+		var retryable *retryableError
+		if !errors.As(err, &retryable) {
+			break
+		}
+		wait := delay
+		if retryable.retryAfter > 0 {
+			wait = retryable.retryAfter
+		}
+		if time.Now().Add(wait).After(deadline) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			c.reportFailure(err, count)
+			return
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+	c.reportFailure(err, count)
+}
+
+// post sends one request to the collector
+func (c *client) post(ctx context.Context, path string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return &retryableError{err: err}
+	}
+	defer resp.Body.Close()
+	// #nosec G104 - the response body is only read so that the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("the collector returned HTTP status %v for %v", resp.Status, c.endpoint+path)
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return &retryableError{err: err, retryAfter: time.Duration(retryAfter) * time.Second}
+	}
+	return err
+}
+
+// reportFailure reports the first failure to export, and counts the data dropped
+func (c *client) reportFailure(err error, count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.failing {
+		c.errorLog(fmt.Sprintf("Unable to export to OpenTelemetry collector: %v", err))
+	}
+	c.failing = true
+	c.dropped += count
+}
+
+// reportSuccess reports that exports are succeeding again, and how much data was dropped
+func (c *client) reportSuccess() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.failing {
+		return
+	}
+	c.errorLog(fmt.Sprintf("Resumed exporting to OpenTelemetry collector, after dropping %v log records or data points", c.dropped))
+	c.failing = false
+	c.dropped = 0
+}
+
+// countDropped counts data which was dropped before it could be exported
+func (c *client) countDropped(count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dropped += count
+}
+
+// BuildResource returns the resource attributes for a queue manager: the service name, queue manager
+// name and host name, and the pod name and namespace if running in Kubernetes.  Attributes in the
+// standard OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME variables take precedence.
+func BuildResource(qmName string) (map[string]string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	attrs := map[string]string{
+		"service.name":        "ibm-mq",
+		"service.instance.id": hostname,
+		"host.name":           hostname,
+		"ibmmq.qmgr":          qmName,
+	}
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		attrs["k8s.pod.name"] = hostname
+		if ns, err := os.ReadFile(namespaceFile); err == nil {
+			attrs["k8s.namespace.name"] = strings.TrimSpace(string(ns))
+		}
+	}
+	for _, pair := range strings.Split(os.Getenv("OTEL_RESOURCE_ATTRIBUTES"), ",") {
+		key, value, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			continue
+		}
+		if v, err := url.QueryUnescape(strings.TrimSpace(value)); err == nil {
+			value = v
+		}
+		attrs[key] = value
+	}
+	if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+		attrs["service.name"] = name
+	}
+	return attrs, nil
+}
+
+// The following types are the subset of the OTLP JSON encoding used by the exporters
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+func newResource(attrs map[string]string) resource {
+	r := resource{Attributes: []keyValue{}}
+	for _, k := range sortedKeys(attrs) {
+		r.Attributes = append(r.Attributes, stringAttribute(k, attrs[k]))
+	}
+	return r
+}
+
+func stringAttribute(key string, value string) keyValue {
+	return keyValue{Key: key, Value: anyValue{StringValue: &value}}
+}
+
+// toAnyValue converts a value decoded from a JSON log entry to an OTLP value
+func toAnyValue(v interface{}) anyValue {
+	switch value := v.(type) {
+	case string:
+		return anyValue{StringValue: &value}
+	case bool:
+		return anyValue{BoolValue: &value}
+	case float64:
+		return anyValue{DoubleValue: &value}
+	case int:
+		f := float64(value)
+		return anyValue{DoubleValue: &f}
+	}
+	s := fmt.Sprint(v)
+	return anyValue{StringValue: &s}
+}
+
+// unixNano returns a time in the OTLP JSON encoding, which uses a string for 64-bit integers
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
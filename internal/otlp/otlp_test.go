@@ -0,0 +1,154 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+)
+
+// testCollector is a stand-in for an OpenTelemetry collector, which records the requests it receives
+type testCollector struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests map[string][]map[string]interface{}
+	// failures is the number of requests to reject before accepting requests
+	failures int
+	status   int
+}
+
+func newTestCollector(t *testing.T) *testCollector {
+	c := &testCollector{requests: map[string][]map[string]interface{}{}}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected content type: %v", r.Header.Get("Content-Type"))
+		}
+		if c.failures > 0 {
+			c.failures--
+			w.WriteHeader(c.status)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var request map[string]interface{}
+		err := json.Unmarshal(body, &request)
+		if err != nil {
+			t.Errorf("Invalid JSON request: %v", err)
+		}
+		c.requests[r.URL.Path] = append(c.requests[r.URL.Path], request)
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func (c *testCollector) received(path string) []map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.requests[path]
+}
+
+func TestNewClientInvalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"", "collector:4318", "grpc://collector:4317", "http://"} {
+		_, err := newClient(Config{Endpoint: endpoint})
+		if err == nil {
+			t.Errorf("Expected an error for endpoint '%v'", endpoint)
+		}
+	}
+}
+
+func TestExportRetry(t *testing.T) {
+	collector := newTestCollector(t)
+	collector.failures = 1
+	collector.status = http.StatusServiceUnavailable
+	errs := []string{}
+	c, err := newClient(Config{Endpoint: collector.URL + "/", ErrorLog: func(msg string) { errs = append(errs, msg) }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.export(context.Background(), logsPath, map[string]string{"a": "b"}, 1)
+	if len(collector.received(logsPath)) != 1 {
+		t.Errorf("Expected the request to be retried")
+	}
+	// A failure which succeeds when retried is not reported
+	if len(errs) != 0 {
+		t.Errorf("Expected no messages to be reported; got %v", errs)
+	}
+}
+
+func TestExportNoRetry(t *testing.T) {
+	collector := newTestCollector(t)
+	collector.failures = 1
+	collector.status = http.StatusBadRequest
+	c, err := newClient(Config{Endpoint: collector.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.export(context.Background(), logsPath, map[string]string{"a": "b"}, 3)
+	if len(collector.received(logsPath)) != 0 {
+		t.Errorf("Expected the request not to be retried")
+	}
+	if !c.failing || c.dropped != 3 {
+		t.Errorf("Expected 3 dropped records; got %v", c.dropped)
+	}
+}
+
+func TestBuildResource(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "deployment.environment=test, team=a%20b,invalid")
+	t.Setenv("OTEL_SERVICE_NAME", "")
+	attrs, err := BuildResource("QM1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostname, _ := os.Hostname()
+	expected := map[string]string{
+		"service.name":           "ibm-mq",
+		"service.instance.id":    hostname,
+		"host.name":              hostname,
+		"ibmmq.qmgr":             "QM1",
+		"deployment.environment": "test",
+		"team":                   "a b",
+	}
+	if len(attrs) != len(expected) {
+		t.Errorf("Expected %v; got %v", expected, attrs)
+	}
+	for k, v := range expected {
+		if attrs[k] != v {
+			t.Errorf("Expected %v=%v; got %v", k, v, attrs[k])
+		}
+	}
+}
+
+func TestBuildResourceServiceName(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "service.name=ignored")
+	t.Setenv("OTEL_SERVICE_NAME", "payments-mq")
+	attrs, err := BuildResource("QM1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostname, _ := os.Hostname()
+	if attrs["service.name"] != "payments-mq" || attrs["k8s.pod.name"] != hostname {
+		t.Errorf("Unexpected resource attributes: %v", attrs)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// codeHeader is the HTTP response header containing the result code, which is the same as the exit code of
+// the equivalent chkmq* command
+const codeHeader = "X-MQ-Probe-Code"
+
+// NewHandler returns an HTTP handler serving the liveness (/livez), readiness (/readyz) and startup
+// (/startupz) probes, which are equivalent to chkmqhealthy, chkmqready and chkmqstarted
+func NewHandler(source Source, nativeHA bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/livez", probeHandler(func(ctx context.Context) Result {
+		return Healthy(ctx, source)
+	}))
+	mux.Handle("/readyz", probeHandler(func(ctx context.Context) Result {
+		return Ready(ctx, source)
+	}))
+	mux.Handle("/startupz", probeHandler(func(ctx context.Context) Result {
+		return Started(ctx, source, nativeHA)
+	}))
+	return mux
+}
+
+// probeHandler returns an HTTP handler which runs a probe, and reports the result
+func probeHandler(probe func(ctx context.Context) Result) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		result := probe(req.Context())
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set(codeHeader, strconv.Itoa(result.Code))
+		w.WriteHeader(httpStatusCode(result))
+		if result.Message == "" {
+			fmt.Fprintln(w, result.Status())
+		} else {
+			fmt.Fprintf(w, "%s: %s\n", result.Status(), result.Message)
+		}
+	}
+}
+
+// httpStatusCode returns the HTTP status code used to report a result.  Kubernetes treats any code from
+// 200 to 399 as success, so every result other than success uses an error code.
+func httpStatusCode(result Result) int {
+	switch result.Code {
+	case CodeOK:
+		return http.StatusOK
+	case CodeError:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusServiceUnavailable
+	}
+}
@@ -0,0 +1,115 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		path       string
+		source     *testSource
+		statusCode int
+		code       string
+		body       string
+	}{
+		{"/livez", &testSource{dspmq: "QMNAME(QM1) STATUS(RUNNING)"}, http.StatusOK, "0", "ok: Queue manager QM1 is RUNNING\n"},
+		{"/livez", &testSource{err: errors.New("dspmq failed")}, http.StatusInternalServerError, "2", "error: dspmq failed\n"},
+		{"/readyz", &testSource{configured: true, dspmq: "QMNAME(QM1) STATUS(RUNNING)", listener: true}, http.StatusOK, "0", "ok\n"},
+		{"/readyz", &testSource{configured: true, dspmq: "QMNAME(QM1) STATUS(RUNNING AS STANDBY)"}, http.StatusServiceUnavailable, "10", "standby: Detected queue manager running in standby mode\n"},
+		{"/startupz", &testSource{dspmq: "QMNAME(QM1) STATUS(ENDED NORMALLY)"}, http.StatusServiceUnavailable, "1", "failed: Queue manager QM1 is ENDED NORMALLY\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			NewHandler(test.source, false).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+			if recorder.Code != test.statusCode {
+				t.Errorf("Expected status code %d; got %d", test.statusCode, recorder.Code)
+			}
+			if recorder.Header().Get(codeHeader) != test.code {
+				t.Errorf("Expected %s %s; got %s", codeHeader, test.code, recorder.Header().Get(codeHeader))
+			}
+			if recorder.Body.String() != test.body {
+				t.Errorf("Expected body %q; got %q", test.body, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerMethodNotAllowed(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	NewHandler(&testSource{}, false).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/livez", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status code %d; got %d", http.StatusMethodNotAllowed, recorder.Code)
+	}
+}
+
+func TestPoller(t *testing.T) {
+	source := &testSource{dspmq: "QMNAME(QM1) STATUS(RUNNING)"}
+	poller := NewPoller(source, time.Hour)
+
+	_, err := poller.QueueManagerStatus(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "not yet available") {
+		t.Errorf("Expected status to be unavailable before the first poll; got %v", err)
+	}
+
+	poller.Poll(context.Background())
+	for i := 0; i < 3; i++ {
+		result := Healthy(context.Background(), poller)
+		if result.Code != CodeOK {
+			t.Errorf("Expected code %d; got %+v", CodeOK, result)
+		}
+	}
+	if source.calls != 1 {
+		t.Errorf("Expected dspmq to be run once; got %d", source.calls)
+	}
+
+	// A status which has not been updated recently must not be reported as healthy
+	poller.interval = time.Millisecond
+	time.Sleep(10 * time.Millisecond)
+	result := Healthy(context.Background(), poller)
+	if result.Code != CodeError || !strings.Contains(result.Message, "out of date") {
+		t.Errorf("Expected out of date status to be an error; got %+v", result)
+	}
+}
+
+func TestPollerRun(t *testing.T) {
+	source := &testSource{dspmq: "QMNAME(QM1) STATUS(RUNNING)"}
+	poller := NewPoller(source, time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		poller.Run(ctx)
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	<-done
+
+	source.mu.Lock()
+	defer source.mu.Unlock()
+	if source.calls < 2 {
+		t.Errorf("Expected status to be polled repeatedly; got %d polls", source.calls)
+	}
+}
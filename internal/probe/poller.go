@@ -0,0 +1,108 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/ready"
+)
+
+const (
+	// pollTimeout is the maximum time allowed for the commands run by one poll, or by one probe
+	pollTimeout = 10 * time.Second
+	// staleIntervals is the number of poll intervals after which a cached status is no longer used
+	staleIntervals = 6
+)
+
+// cachedStatus is the status of the queue manager found by the poller
+type cachedStatus struct {
+	status  *ready.QueueManagerStatus
+	err     error
+	updated time.Time
+}
+
+// Poller is a Source which periodically runs dspmq to check the status of the queue manager, and caches the
+// result.  This means that frequent probes don't each run dspmq.  The ready file and the listener are cheap
+// to check, so they are checked each time they are needed.
+type Poller struct {
+	source   Source
+	interval time.Duration
+
+	mu     sync.RWMutex
+	cached cachedStatus
+}
+
+// NewPoller returns a Poller which caches the status from the specified source
+func NewPoller(source Source, interval time.Duration) *Poller {
+	return &Poller{
+		source:   source,
+		interval: interval,
+	}
+}
+
+// Run polls the status of the queue manager until the context is cancelled
+func (p *Poller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll updates the cached status of the queue manager
+func (p *Poller) Poll(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, pollTimeout)
+	defer cancel()
+
+	status, err := p.source.QueueManagerStatus(ctx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cached = cachedStatus{status: status, err: err, updated: time.Now()}
+}
+
+// Configured returns true if runmqserver has finished configuring the queue manager
+func (p *Poller) Configured() (bool, error) {
+	return p.source.Configured()
+}
+
+// QueueManagerStatus returns the cached status of the queue manager, or an error if it is not available or out of date
+func (p *Poller) QueueManagerStatus(ctx context.Context) (*ready.QueueManagerStatus, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.cached.updated.IsZero() {
+		return nil, errors.New("queue manager status is not yet available")
+	}
+	if time.Since(p.cached.updated) > staleIntervals*p.interval {
+		return nil, errors.New("queue manager status is out of date")
+	}
+	return p.cached.status, p.cached.err
+}
+
+// ListenerAvailable returns true if a connection can be made to the MQ listener
+func (p *Poller) ListenerAvailable() (bool, error) {
+	return p.source.ListenerAvailable()
+}
@@ -0,0 +1,241 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package probe contains the startup, liveness and readiness checks for the queue manager, which are used by
+// the chkmqstarted, chkmqhealthy and chkmqready commands, and by the HTTP probe server in runmqserver
+package probe
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/ready"
+)
+
+// listenerAddress is the address of the MQ listener, which is checked to see if the queue manager is ready
+const listenerAddress = "127.0.0.1:1414"
+
+// Result codes, which are used as the exit codes of the chkmq* commands
+const (
+	CodeOK      = 0
+	CodeFailed  = 1
+	CodeError   = 2
+	CodeStandby = 10
+	CodeReplica = 20
+)
+
+// Result is the result of a probe
+type Result struct {
+	// Code is the exit code used by the chkmq* commands
+	Code int
+	// Message describes the result, if there is anything to report
+	Message string
+}
+
+// Status returns a short description of the result code
+func (r Result) Status() string {
+	switch r.Code {
+	case CodeOK:
+		return "ok"
+	case CodeError:
+		return "error"
+	case CodeStandby:
+		return "standby"
+	case CodeReplica:
+		return "replica"
+	default:
+		return "failed"
+	}
+}
+
+// A Source provides the information about the queue manager used by the probes
+type Source interface {
+	// Configured returns true if runmqserver has finished configuring the queue manager
+	Configured() (bool, error)
+	// QueueManagerStatus returns the status of the queue manager, as shown by dspmq
+	QueueManagerStatus(ctx context.Context) (*ready.QueueManagerStatus, error)
+	// ListenerAvailable returns true if a connection can be made to the MQ listener
+	ListenerAvailable() (bool, error)
+}
+
+// commandSource runs commands to get information about the queue manager each time it is needed
+type commandSource struct {
+	name string
+}
+
+// NewCommandSource returns a Source which runs commands each time information is needed
+func NewCommandSource(name string) Source {
+	return commandSource{name: name}
+}
+
+func (c commandSource) Configured() (bool, error) {
+	return ready.Check()
+}
+
+func (c commandSource) QueueManagerStatus(ctx context.Context) (*ready.QueueManagerStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, pollTimeout)
+	defer cancel()
+	// Specify the queue manager name, just in case someone's created a second queue manager
+	return ready.GetQueueManagerStatus(ctx, c.name)
+}
+
+func (c commandSource) ListenerAvailable() (bool, error) {
+	conn, err := net.Dial("tcp", listenerAddress)
+	if err != nil {
+		return false, err
+	}
+	return true, conn.Close()
+}
+
+// describeState returns a description of the state of the queue manager, for use in a result message
+func describeState(status *ready.QueueManagerStatus) string {
+	description := fmt.Sprintf("Queue manager %v is %v", status.Name, status.State)
+	if status.NativeHA != nil {
+		description += fmt.Sprintf(" (native HA role %v, quorum %v)", status.NativeHA.Role, status.NativeHA.Quorum)
+	}
+	return description
+}
+
+// Healthy checks that the queue manager is running, in any role
+func Healthy(ctx context.Context, source Source) Result {
+	status, err := source.QueueManagerStatus(ctx)
+	if err != nil {
+		return Result{Code: CodeError, Message: err.Error()}
+	}
+	if !status.Running() {
+		return Result{Code: CodeFailed, Message: describeState(status)}
+	}
+	return Result{Code: CodeOK, Message: describeState(status)}
+}
+
+// Started checks that the queue manager has started.  For a native HA queue manager, the instance must also
+// be in-sync with its replicas, or have been ready to synchronize for longer than MQ_NATIVE_HA_IN_SYNC_TIMEOUT.
+func Started(ctx context.Context, source Source, nativeHA bool) Result {
+	status, err := source.QueueManagerStatus(ctx)
+	if err != nil {
+		return Result{Code: CodeError, Message: err.Error()}
+	}
+	if !nativeHA {
+		if !status.Running() {
+			return Result{Code: CodeFailed, Message: describeState(status)}
+		}
+		return Result{Code: CodeOK}
+	}
+
+	// For Native-HA only, check if the queue manager instance is in-sync with one or more replicas
+	// - If not in-sync within the expected time period, revert to checking on queue manager 'ready' status
+	// - This ensures we do not block indefinitely for breaking changes (i.e. protocol changes)
+	if status.NativeHAInSync() {
+		return Result{Code: CodeOK}
+	}
+
+	// Check if the Native-HA queue manager instance is ready-to-sync
+	// - A successful queue manager 'ready' status indicates that we are ready-to-sync
+	if !status.Running() {
+		return Result{Code: CodeFailed, Message: describeState(status)}
+	}
+	err = ready.SetReadyToSync()
+	if err != nil {
+		return Result{Code: CodeError, Message: err.Error()}
+	}
+
+	// Check if the time period for checking in-sync has now expired
+	// - We have already confirmed a successful queue manager 'ready' status
+	// - Therefore the expiration of the in-sync time period will result in success
+	expired, err := hasInSyncTimePeriodExpired()
+	if err != nil {
+		return Result{Code: CodeError, Message: err.Error()}
+	}
+	message := describeState(status) + ", and is not in-sync"
+	if outOfSync := status.OutOfSyncInstances(); len(outOfSync) > 0 {
+		message += ": out of sync instances " + strings.Join(outOfSync, ", ")
+	}
+	if expired {
+		return Result{Code: CodeOK, Message: message}
+	}
+	return Result{Code: CodeFailed, Message: message}
+}
+
+// hasInSyncTimePeriodExpired returns true if a Native-HA queue manager instance is not in-sync within the expected time period, otherwise false
+func hasInSyncTimePeriodExpired() (bool, error) {
+
+	// Default timeout 5 seconds
+	var timeout int64 = 5
+	var err error
+
+	// Check if a timeout override has been set
+	customTimeout := os.Getenv("MQ_NATIVE_HA_IN_SYNC_TIMEOUT")
+	if customTimeout != "" {
+		timeout, err = strconv.ParseInt(customTimeout, 10, 64)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	isReadyToSync, readyToSyncStartTime, err := ready.GetReadyToSyncStartTime()
+	if err != nil {
+		return false, err
+	}
+	if isReadyToSync && time.Now().Unix()-readyToSyncStartTime.Unix() >= timeout {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// Ready checks that runmqserver has finished configuring the queue manager, and that the queue manager is
+// ready for work.  An active queue manager is ready if its listener is available.  A standby or replica
+// instance is not ready, and is reported using a separate code.
+func Ready(ctx context.Context, source Source) Result {
+	// Check if runmqserver has indicated that it's finished configuration
+	configured, err := source.Configured()
+	if err != nil {
+		return Result{Code: CodeFailed, Message: err.Error()}
+	}
+	if !configured {
+		return Result{Code: CodeFailed, Message: "Queue manager configuration has not finished"}
+	}
+
+	// Check if the queue manager has a running listener
+	status, err := source.QueueManagerStatus(ctx)
+	if err != nil {
+		return Result{Code: CodeFailed, Message: err.Error()}
+	}
+	switch status.Status() {
+	case ready.StatusActiveQM:
+		available, err := source.ListenerAvailable()
+		if err != nil {
+			return Result{Code: CodeFailed, Message: "Listener is not available: " + err.Error()}
+		}
+		if !available {
+			return Result{Code: CodeFailed, Message: "Listener is not available"}
+		}
+		return Result{Code: CodeOK}
+	case ready.StatusRecoveryQM:
+		return Result{Code: CodeOK, Message: "Detected queue manager running as recovery leader"}
+	case ready.StatusStandbyQM:
+		return Result{Code: CodeStandby, Message: "Detected queue manager running in standby mode"}
+	case ready.StatusReplicaQM:
+		return Result{Code: CodeReplica, Message: "Detected queue manager running in replica mode"}
+	default:
+		return Result{Code: CodeFailed, Message: describeState(status)}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ibm-messaging/mq-container/internal/ready"
+)
+
+// testSource is a Source which returns fixed values
+type testSource struct {
+	mu         sync.Mutex
+	configured bool
+	dspmq      string
+	err        error
+	listener   bool
+	calls      int
+}
+
+func (s *testSource) Configured() (bool, error) {
+	return s.configured, nil
+}
+
+func (s *testSource) QueueManagerStatus(ctx context.Context) (*ready.QueueManagerStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return ready.ParseQueueManagerStatus(s.dspmq)
+}
+
+func (s *testSource) ListenerAvailable() (bool, error) {
+	if !s.listener {
+		return false, errors.New("connection refused")
+	}
+	return true, nil
+}
+
+func TestHealthy(t *testing.T) {
+	tests := []struct {
+		status string
+		err    error
+		code   int
+	}{
+		{"QMNAME(QM1) STATUS(RUNNING)", nil, CodeOK},
+		{"QMNAME(QM1) STATUS(RUNNING AS STANDBY)", nil, CodeOK},
+		{"QMNAME(QM1) STATUS(REPLICA)", nil, CodeOK},
+		{"QMNAME(QM1) STATUS(RECOVERY GROUP LEADER)", nil, CodeOK},
+		{"QMNAME(QM1) STATUS(STARTING)", nil, CodeOK},
+		{"QMNAME(QM1) STATUS(ENDED IMMEDIATELY)", nil, CodeFailed},
+		{"", errors.New("dspmq failed"), CodeError},
+	}
+	for _, test := range tests {
+		t.Run(test.status, func(t *testing.T) {
+			result := Healthy(context.Background(), &testSource{dspmq: test.status, err: test.err})
+			if result.Code != test.code {
+				t.Errorf("Expected code %d; got %+v", test.code, result)
+			}
+		})
+	}
+}
+
+func TestStarted(t *testing.T) {
+	tests := []struct {
+		name     string
+		nativeHA bool
+		dspmq    string
+		err      error
+		code     int
+	}{
+		{"Running", false, "QMNAME(QM1) STATUS(RUNNING)", nil, CodeOK},
+		{"Ended", false, "QMNAME(QM1) STATUS(ENDED NORMALLY)", nil, CodeFailed},
+		{"Error", false, "", errors.New("dspmq failed"), CodeError},
+		{"NativeHAInSync", true, "QMNAME(QM1) STATUS(RUNNING) ROLE(ACTIVE) INSTANCE(qm-0) INSYNC(YES) QUORUM(3/3)", nil, CodeOK},
+		{"NativeHAEnded", true, "QMNAME(QM1) STATUS(ENDED IMMEDIATELY) ROLE(UNKNOWN) INSTANCE(qm-0) INSYNC(NO) QUORUM(0/3)", nil, CodeFailed},
+		{"NativeHAError", true, "", errors.New("dspmq failed"), CodeError},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source := &testSource{dspmq: test.dspmq, err: test.err}
+			result := Started(context.Background(), source, test.nativeHA)
+			if result.Code != test.code {
+				t.Errorf("Expected code %d; got %+v", test.code, result)
+			}
+		})
+	}
+}
+
+func TestReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured bool
+		status     string
+		listener   bool
+		code       int
+	}{
+		{"NotConfigured", false, "QMNAME(QM1) STATUS(RUNNING)", true, CodeFailed},
+		{"Active", true, "QMNAME(QM1) STATUS(RUNNING)", true, CodeOK},
+		{"ActiveNoListener", true, "QMNAME(QM1) STATUS(RUNNING)", false, CodeFailed},
+		{"Standby", true, "QMNAME(QM1) STATUS(RUNNING AS STANDBY)", false, CodeStandby},
+		{"Replica", true, "QMNAME(QM1) STATUS(REPLICA) ROLE(REPLICA) INSTANCE(qm-1) INSYNC(YES) QUORUM(3/3)", false, CodeReplica},
+		{"RecoveryLeader", true, "QMNAME(QM1) STATUS(RECOVERY GROUP LEADER)", false, CodeOK},
+		{"Ended", true, "QMNAME(QM1) STATUS(ENDED NORMALLY)", false, CodeFailed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source := &testSource{configured: test.configured, dspmq: test.status, listener: test.listener}
+			result := Ready(context.Background(), source)
+			if result.Code != test.code {
+				t.Errorf("Expected code %d; got %+v", test.code, result)
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2024
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -21,10 +21,7 @@ import (
 	"context"
 	"os"
 	"strconv"
-	"strings"
 	"time"
-
-	"github.com/ibm-messaging/mq-container/internal/command"
 )
 
 const readyFile string = "/run/runmqserver/ready"
@@ -124,23 +121,11 @@ func GetReadyToSyncStartTime() (bool, time.Time, error) {
 
 // Status returns an enum representing the current running status of the queue manager
 func Status(ctx context.Context, name string) (QMStatus, error) {
-	out, _, err := command.RunContext(ctx, "dspmq", "-n", "-m", name)
+	status, err := GetQueueManagerStatus(ctx, name)
 	if err != nil {
 		return StatusUnknown, err
 	}
-	if strings.Contains(string(out), "(RUNNING)") {
-		return StatusActiveQM, nil
-	}
-	if strings.Contains(string(out), "(RUNNING AS STANDBY)") {
-		return StatusStandbyQM, nil
-	}
-	if strings.Contains(string(out), "(REPLICA)") {
-		return StatusStandbyQM, nil
-	}
-	if strings.Contains(string(out), "(RECOVERY GROUP LEADER)") {
-		return StatusRecoveryQM, nil
-	}
-	return StatusUnknown, nil
+	return status.Status(), nil
 }
 
 type QMStatus int
@@ -151,6 +136,8 @@ const (
 	StatusStandbyQM
 	StatusReplicaQM
 	StatusRecoveryQM
+	StatusEndedQM
+	StatusStartingQM
 )
 
 // ActiveQM returns true if the queue manager is running in active mode
@@ -162,5 +149,11 @@ func (s QMStatus) StandbyQM() bool { return s == StatusStandbyQM }
 // ReplicaQM returns true if the queue manager is running in replica mode
 func (s QMStatus) ReplicaQM() bool { return s == StatusReplicaQM }
 
-// ReplicaQM returns true if the queue manager is running in recovery mode
+// RecoveryQM returns true if the queue manager is running in recovery mode
 func (s QMStatus) RecoveryQM() bool { return s == StatusRecoveryQM }
+
+// EndedQM returns true if the queue manager has ended
+func (s QMStatus) EndedQM() bool { return s == StatusEndedQM }
+
+// StartingQM returns true if the queue manager is starting
+func (s QMStatus) StartingQM() bool { return s == StatusStartingQM }
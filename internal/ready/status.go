@@ -0,0 +1,257 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ready
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ibm-messaging/mq-container/internal/command"
+	"github.com/ibm-messaging/mq-container/internal/mqscattr"
+)
+
+// QueueManagerStatus is the status of a queue manager, as shown by "dspmq -n -o status -o nativeha -x"
+type QueueManagerStatus struct {
+	// Name is the name of the queue manager
+	Name string
+	// State is the status of the queue manager, such as "RUNNING" or "ENDED IMMEDIATELY"
+	State string
+	// Instances are the instances of a multi-instance queue manager
+	Instances []Instance
+	// NativeHA is the status of a native HA queue manager, or nil if the queue manager isn't native HA
+	NativeHA *NativeHAStatus
+}
+
+// Instance is an instance of a multi-instance queue manager
+type Instance struct {
+	// Host is the host name of the instance
+	Host string
+	// Mode is the mode of the instance, either "ACTIVE" or "STANDBY"
+	Mode string
+}
+
+// NativeHAStatus is the status of a native HA queue manager
+type NativeHAStatus struct {
+	// Role is the role of this instance, such as "ACTIVE", "REPLICA" or "UNKNOWN"
+	Role string
+	// Instance is the name of this instance
+	Instance string
+	// InSync is true if this instance is in sync with enough of the other instances to form a quorum
+	InSync bool
+	// Quorum is the number of in sync instances out of the total number of instances, such as "3/3"
+	Quorum string
+	// Instances are the instances of the queue manager, including this one
+	Instances []NativeHAInstance
+	// Group is the recovery group containing this instance, or nil if cross-region replication isn't configured
+	Group *RecoveryGroup
+	// Groups are the recovery groups known to this instance
+	Groups []RecoveryGroup
+}
+
+// NativeHAInstance is an instance of a native HA queue manager
+type NativeHAInstance struct {
+	// Name is the name of the instance
+	Name string
+	// Role is the role of the instance, such as "ACTIVE", "REPLICA" or "UNKNOWN"
+	Role string
+	// ReplicationAddress is the address used for replication to the instance
+	ReplicationAddress string
+	// ConnectionActive is true if the instance is connected to the active instance
+	ConnectionActive bool
+	// InSync is true if the instance is in sync with the active instance
+	InSync bool
+	// Backlog is the number of bytes of log data which the instance is behind the active instance
+	Backlog int64
+}
+
+// RecoveryGroup is a native HA group used for cross-region replication
+type RecoveryGroup struct {
+	// Name is the name of the group
+	Name string
+	// Role is the role of the group, either "LIVE" or "RECOVERY"
+	Role string
+	// Address is the address used for replication to the group
+	Address string
+	// Connected is true if the group is connected to this group
+	Connected bool
+	// InSync is true if the group is in sync with the live group
+	InSync bool
+	// Backlog is the number of bytes of log data which the group is behind the live group
+	Backlog int64
+}
+
+// GetQueueManagerStatus runs dspmq to get the status of the queue manager
+func GetQueueManagerStatus(ctx context.Context, name string) (*QueueManagerStatus, error) {
+	out, _, err := command.RunContext(ctx, "dspmq", "-n", "-o", "status", "-o", "nativeha", "-x", "-m", name)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", err, strings.TrimSpace(out))
+	}
+	return ParseQueueManagerStatus(out)
+}
+
+// parseAttributes returns the attributes in a line of dspmq output, such as "STATUS(RUNNING AS STANDBY)", and
+// the name of the first attribute, which identifies what the line describes
+func parseAttributes(line string) (string, map[string]string) {
+	first := ""
+	attributes := map[string]string{}
+	for _, attribute := range mqscattr.Parse(line) {
+		if first == "" {
+			first = attribute.Name
+		}
+		attributes[attribute.Name] = attribute.Value
+	}
+	return first, attributes
+}
+
+// isYes returns true if a dspmq attribute value is "YES", in any case
+func isYes(value string) bool {
+	return strings.EqualFold(value, "YES")
+}
+
+// parseBacklog returns the value of a BACKLOG attribute, which is zero if it isn't set
+func parseBacklog(value string) int64 {
+	backlog, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return backlog
+}
+
+// ParseQueueManagerStatus parses the output of "dspmq -n -o status -o nativeha -x" for a single queue manager.
+// The first line describes the queue manager, and it's followed by a line for each instance of a multi-instance
+// or native HA queue manager, and for each recovery group.
+func ParseQueueManagerStatus(out string) (*QueueManagerStatus, error) {
+	var status *QueueManagerStatus
+	for _, line := range strings.Split(out, "\n") {
+		first, attributes := parseAttributes(line)
+		switch {
+		case first == "QMNAME":
+			if status != nil {
+				return nil, fmt.Errorf("status of more than one queue manager found: %v and %v", status.Name, attributes["QMNAME"])
+			}
+			status = &QueueManagerStatus{
+				Name:  attributes["QMNAME"],
+				State: strings.ToUpper(attributes["STATUS"]),
+			}
+			if role, ok := attributes["ROLE"]; ok {
+				status.NativeHA = &NativeHAStatus{
+					Role:     strings.ToUpper(role),
+					Instance: attributes["INSTANCE"],
+					InSync:   isYes(attributes["INSYNC"]),
+					Quorum:   attributes["QUORUM"],
+				}
+				if group, ok := attributes["GRPNAME"]; ok {
+					status.NativeHA.Group = &RecoveryGroup{Name: group, Role: strings.ToUpper(attributes["GRPROLE"])}
+				}
+			}
+		case status == nil:
+			// Ignore anything before the queue manager, such as messages
+			continue
+		case first == "INSTANCE" && attributes["MODE"] != "":
+			status.Instances = append(status.Instances, Instance{
+				Host: attributes["INSTANCE"],
+				Mode: strings.ToUpper(attributes["MODE"]),
+			})
+		case first == "INSTANCE" && status.NativeHA != nil:
+			status.NativeHA.Instances = append(status.NativeHA.Instances, NativeHAInstance{
+				Name:               attributes["INSTANCE"],
+				Role:               strings.ToUpper(attributes["ROLE"]),
+				ReplicationAddress: attributes["REPLADDR"],
+				ConnectionActive:   isYes(attributes["CONNACTV"]),
+				InSync:             isYes(attributes["INSYNC"]),
+				Backlog:            parseBacklog(attributes["BACKLOG"]),
+			})
+		case first == "GRPNAME" && status.NativeHA != nil:
+			status.NativeHA.Groups = append(status.NativeHA.Groups, RecoveryGroup{
+				Name:      attributes["GRPNAME"],
+				Role:      strings.ToUpper(attributes["GRPROLE"]),
+				Address:   attributes["GRPADDR"],
+				Connected: isYes(attributes["CONNGRP"]),
+				InSync:    isYes(attributes["INSYNC"]),
+				Backlog:   parseBacklog(attributes["BACKLOG"]),
+			})
+		}
+	}
+	if status == nil {
+		return nil, errors.New("queue manager status not found in dspmq output: " + strings.TrimSpace(out))
+	}
+	return status, nil
+}
+
+// Status returns an enum representing the running status of the queue manager
+func (s *QueueManagerStatus) Status() QMStatus {
+	switch {
+	case s.State == "RUNNING":
+		return StatusActiveQM
+	case s.State == "RUNNING AS STANDBY":
+		return StatusStandbyQM
+	case s.State == "REPLICA":
+		return StatusReplicaQM
+	case s.State == "RECOVERY GROUP LEADER":
+		return StatusRecoveryQM
+	case s.State == "STARTING":
+		return StatusStartingQM
+	case strings.HasPrefix(s.State, "ENDED"):
+		return StatusEndedQM
+	default:
+		return StatusUnknown
+	}
+}
+
+// Running returns true if the queue manager has started, in any role
+func (s *QueueManagerStatus) Running() bool {
+	switch s.Status() {
+	case StatusActiveQM, StatusStandbyQM, StatusReplicaQM, StatusRecoveryQM, StatusStartingQM:
+		return true
+	default:
+		return false
+	}
+}
+
+// RunningElsewhere returns true if the queue manager is running on another instance of a multi-instance
+// queue manager, and not in this container
+func (s *QueueManagerStatus) RunningElsewhere() bool {
+	return s.State == "RUNNING ELSEWHERE"
+}
+
+// NativeHAInSync returns true if this is a native HA instance which is in sync with its replicas
+func (s *QueueManagerStatus) NativeHAInSync() bool {
+	return s.NativeHA != nil && s.NativeHA.InSync
+}
+
+// OutOfSyncInstances returns a description of each native HA instance which is not in sync, such as
+// "qm-1 (CONNACTV=NO, BACKLOG=1024)"
+func (s *QueueManagerStatus) OutOfSyncInstances() []string {
+	if s.NativeHA == nil {
+		return nil
+	}
+	descriptions := []string{}
+	for _, instance := range s.NativeHA.Instances {
+		if instance.InSync {
+			continue
+		}
+		connected := "NO"
+		if instance.ConnectionActive {
+			connected = "YES"
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%v (CONNACTV=%v, BACKLOG=%v)", instance.Name, connected, instance.Backlog))
+	}
+	return descriptions
+}
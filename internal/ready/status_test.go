@@ -0,0 +1,160 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ready
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQueueManagerStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		out      string
+		expected QueueManagerStatus
+	}{
+		{
+			name:     "SingleInstance",
+			out:      "QMNAME(QM1)                                               STATUS(RUNNING)\n",
+			expected: QueueManagerStatus{Name: "QM1", State: "RUNNING"},
+		},
+		{
+			name: "MultiInstanceStandby",
+			out: "QMNAME(QM1)                                               STATUS(RUNNING AS STANDBY)\n" +
+				"    INSTANCE(mq-a) MODE(ACTIVE)\n" +
+				"    INSTANCE(mq-b) MODE(STANDBY)\n",
+			expected: QueueManagerStatus{
+				Name:      "QM1",
+				State:     "RUNNING AS STANDBY",
+				Instances: []Instance{{Host: "mq-a", Mode: "ACTIVE"}, {Host: "mq-b", Mode: "STANDBY"}},
+			},
+		},
+		{
+			name: "NativeHAReplica",
+			out: "QMNAME(QM1)                                               STATUS(REPLICA) ROLE(REPLICA) INSTANCE(qm-1) INSYNC(YES) QUORUM(2/3)\n" +
+				" INSTANCE(qm-0) ROLE(ACTIVE) REPLADDR(10.0.0.1) CONNACTV(YES) INSYNC(YES) BACKLOG(0) CONNINST(YES) ALTDATE(2026-01-12) ALTTIME(12.03.44)\n" +
+				" INSTANCE(qm-1) ROLE(REPLICA) REPLADDR(10.0.0.2) CONNACTV(YES) INSYNC(YES) BACKLOG(0) CONNINST(YES) ALTDATE(2026-01-12) ALTTIME(12.03.44)\n" +
+				" INSTANCE(qm-2) ROLE(REPLICA) REPLADDR(10.0.0.3) CONNACTV(NO) INSYNC(NO) BACKLOG(4096) CONNINST(NO) ALTDATE(2026-01-12) ALTTIME(12.03.44)\n",
+			expected: QueueManagerStatus{
+				Name:  "QM1",
+				State: "REPLICA",
+				NativeHA: &NativeHAStatus{
+					Role:     "REPLICA",
+					Instance: "qm-1",
+					InSync:   true,
+					Quorum:   "2/3",
+					Instances: []NativeHAInstance{
+						{Name: "qm-0", Role: "ACTIVE", ReplicationAddress: "10.0.0.1", ConnectionActive: true, InSync: true},
+						{Name: "qm-1", Role: "REPLICA", ReplicationAddress: "10.0.0.2", ConnectionActive: true, InSync: true},
+						{Name: "qm-2", Role: "REPLICA", ReplicationAddress: "10.0.0.3", Backlog: 4096},
+					},
+				},
+			},
+		},
+		{
+			name: "RecoveryGroupLeader",
+			out: "QMNAME(QM1)                                               STATUS(RECOVERY GROUP LEADER) ROLE(Active) INSTANCE(qm-0) INSYNC(Yes) QUORUM(3/3) GRPNAME(BACKUP) GRPROLE(Recovery)\n" +
+				" GRPNAME(LIVE) GRPROLE(Live) GRPADDR(live.example.com(4445)) CONNGRP(Yes) INSYNC(Yes) BACKLOG(0)\n",
+			expected: QueueManagerStatus{
+				Name:  "QM1",
+				State: "RECOVERY GROUP LEADER",
+				NativeHA: &NativeHAStatus{
+					Role:     "ACTIVE",
+					Instance: "qm-0",
+					InSync:   true,
+					Quorum:   "3/3",
+					Group:    &RecoveryGroup{Name: "BACKUP", Role: "RECOVERY"},
+					Groups:   []RecoveryGroup{{Name: "LIVE", Role: "LIVE", Address: "live.example.com(4445)", Connected: true, InSync: true}},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, err := ParseQueueManagerStatus(test.out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*status, test.expected) {
+				t.Errorf("Expected %+v; got %+v", test.expected, *status)
+			}
+		})
+	}
+}
+
+func TestParseQueueManagerStatusErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"AMQ7048E: The queue manager name is either not valid or not known.\n",
+		"QMNAME(QM1) STATUS(RUNNING)\nQMNAME(QM2) STATUS(RUNNING)\n",
+	}
+	for _, out := range tests {
+		_, err := ParseQueueManagerStatus(out)
+		if err == nil {
+			t.Errorf("Expected an error parsing %q", out)
+		}
+	}
+}
+
+func TestQueueManagerStatus(t *testing.T) {
+	tests := []struct {
+		state     string
+		expected  QMStatus
+		running   bool
+		elsewhere bool
+	}{
+		{"RUNNING", StatusActiveQM, true, false},
+		{"RUNNING AS STANDBY", StatusStandbyQM, true, false},
+		{"REPLICA", StatusReplicaQM, true, false},
+		{"RECOVERY GROUP LEADER", StatusRecoveryQM, true, false},
+		{"STARTING", StatusStartingQM, true, false},
+		{"ENDED IMMEDIATELY", StatusEndedQM, false, false},
+		{"RUNNING ELSEWHERE", StatusUnknown, false, true},
+		{"QUIESCING", StatusUnknown, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.state, func(t *testing.T) {
+			status := &QueueManagerStatus{Name: "QM1", State: test.state}
+			if status.Status() != test.expected {
+				t.Errorf("Expected status %v; got %v", test.expected, status.Status())
+			}
+			if status.Running() != test.running {
+				t.Errorf("Expected running=%v; got %v", test.running, status.Running())
+			}
+			if status.RunningElsewhere() != test.elsewhere {
+				t.Errorf("Expected running elsewhere=%v; got %v", test.elsewhere, status.RunningElsewhere())
+			}
+		})
+	}
+}
+
+func TestOutOfSyncInstances(t *testing.T) {
+	status, err := ParseQueueManagerStatus("QMNAME(QM1) STATUS(RUNNING) ROLE(ACTIVE) INSTANCE(qm-0) INSYNC(NO) QUORUM(1/3)\n" +
+		" INSTANCE(qm-0) ROLE(ACTIVE) REPLADDR(10.0.0.1) CONNACTV(YES) INSYNC(YES) BACKLOG(0)\n" +
+		" INSTANCE(qm-1) ROLE(REPLICA) REPLADDR(10.0.0.2) CONNACTV(YES) INSYNC(NO) BACKLOG(1024)\n" +
+		" INSTANCE(qm-2) ROLE(UNKNOWN) REPLADDR(10.0.0.3) CONNACTV(NO) INSYNC(NO) BACKLOG(0)\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.NativeHAInSync() {
+		t.Error("Expected instance not to be in sync")
+	}
+	expected := []string{"qm-1 (CONNACTV=YES, BACKLOG=1024)", "qm-2 (CONNACTV=NO, BACKLOG=0)"}
+	if actual := status.OutOfSyncInstances(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %v; got %v", expected, actual)
+	}
+}
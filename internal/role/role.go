@@ -0,0 +1,302 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package role watches the role of the queue manager instance in this container, such as active or replica,
+// and starts and stops the parts of runmqserver which should only run while the instance is active
+package role
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/internal/ready"
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+)
+
+// Role is the role of the queue manager instance in this container
+type Role string
+
+const (
+	Unknown  Role = "unknown"
+	Active   Role = "active"
+	Standby  Role = "standby"
+	Replica  Role = "replica"
+	Recovery Role = "recovery"
+	Starting Role = "starting"
+	Ended    Role = "ended"
+)
+
+// FromStatus returns the role for a queue manager status
+func FromStatus(status ready.QMStatus) Role {
+	switch status {
+	case ready.StatusActiveQM:
+		return Active
+	case ready.StatusStandbyQM:
+		return Standby
+	case ready.StatusReplicaQM:
+		return Replica
+	case ready.StatusRecoveryQM:
+		return Recovery
+	case ready.StatusStartingQM:
+		return Starting
+	case ready.StatusEndedQM:
+		return Ended
+	}
+	return Unknown
+}
+
+// transient returns true if the role is seen briefly while the instance changes role, or if the role
+// couldn't be determined
+func (r Role) transient() bool {
+	return r == Unknown || r == Starting
+}
+
+// Transition is a change in the role of the queue manager instance
+type Transition struct {
+	From Role
+	To   Role
+}
+
+func (t Transition) String() string {
+	return string(t.From) + "->" + string(t.To)
+}
+
+// Activated returns true if the instance has become active
+func (t Transition) Activated() bool {
+	return t.To == Active && t.From != Active
+}
+
+// Deactivated returns true if the instance is no longer active
+func (t Transition) Deactivated() bool {
+	return t.From == Active && t.To != Active
+}
+
+// Subsystem is a part of runmqserver which runs only while the queue manager instance is active
+type Subsystem struct {
+	// Name is used to describe the subsystem in log messages
+	Name string
+	// Start is called when the instance becomes active
+	Start func(ctx context.Context) error
+	// Stop is called when the instance is no longer active, if Start succeeded.  It may be nil.
+	Stop func(ctx context.Context) error
+	// Background runs Start without waiting for it to return, for a subsystem which is slow to start, so
+	// that the role continues to be checked while it starts
+	Background bool
+}
+
+// subsystemState records whether a subsystem is running
+type subsystemState struct {
+	running bool
+	// starting is closed when a start in the background completes, or is nil if no start is in progress
+	starting chan struct{}
+}
+
+// StatusFunc returns the current role of the queue manager instance
+type StatusFunc func(ctx context.Context) (Role, error)
+
+// Watcher polls the role of the queue manager instance, and calls the registered subsystems and
+// listeners when it changes
+type Watcher struct {
+	status     StatusFunc
+	interval   time.Duration
+	log        *logger.Logger
+	mutex      sync.Mutex
+	role       Role
+	subsystems []Subsystem
+	states     map[string]*subsystemState
+	listeners  []func(Transition)
+	// background tracks the subsystems being started in the background
+	background sync.WaitGroup
+}
+
+// NewWatcher creates a watcher which checks the role of the queue manager instance at the given interval
+func NewWatcher(status StatusFunc, interval time.Duration, log *logger.Logger) *Watcher {
+	return &Watcher{
+		status:   status,
+		interval: interval,
+		log:      log,
+		role:     Unknown,
+		states:   map[string]*subsystemState{},
+	}
+}
+
+// Register adds a subsystem to be started when the instance becomes active, and stopped when it is no
+// longer active.  Subsystems are started in the order they were registered, and stopped in reverse order.
+func (w *Watcher) Register(subsystem Subsystem) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.subsystems = append(w.subsystems, subsystem)
+}
+
+// OnTransition adds a function to be called each time the role of the instance changes, before any
+// subsystems are started or stopped
+func (w *Watcher) OnTransition(listener func(Transition)) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.listeners = append(w.listeners, listener)
+}
+
+// Role returns the last known role of the instance
+func (w *Watcher) Role() Role {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.role
+}
+
+// Run checks the role of the instance straight away, and then at each interval until the context is
+// cancelled.  It returns once any subsystems being started in the background have started, and leaves
+// the registered subsystems running.
+func (w *Watcher) Run(ctx context.Context) {
+	defer w.background.Wait()
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		w.check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check gets the current role of the instance, and handles any change since the last check.  Transient
+// roles are ignored, so that a subsystem isn't stopped while the instance is briefly starting, or while
+// its status can't be determined.  While the instance remains active, any subsystems which failed to
+// start are retried.
+func (w *Watcher) check(ctx context.Context) {
+	role, err := w.status(ctx)
+	if err != nil {
+		w.log.Debugf("Unable to determine the role of the queue manager: %v", err)
+		return
+	}
+	if role.transient() || ctx.Err() != nil {
+		return
+	}
+	w.mutex.Lock()
+	transition := Transition{From: w.role, To: role}
+	w.role = role
+	subsystems := w.subsystems
+	listeners := w.listeners
+	w.mutex.Unlock()
+	if transition.From == transition.To {
+		if role == Active {
+			w.startSubsystems(ctx, subsystems)
+		}
+		return
+	}
+	if transition.From == Unknown {
+		w.log.Printf("Queue manager role is %v", role)
+	} else {
+		w.log.Printf("Queue manager role changed from %v to %v", transition.From, transition.To)
+	}
+	for _, listener := range listeners {
+		listener(transition)
+	}
+	switch {
+	case transition.Activated():
+		w.startSubsystems(ctx, subsystems)
+	case transition.Deactivated():
+		w.stopSubsystems(ctx, subsystems)
+	}
+}
+
+// state returns the state of the named subsystem.  The caller must hold the mutex.
+func (w *Watcher) state(name string) *subsystemState {
+	state, ok := w.states[name]
+	if !ok {
+		state = &subsystemState{}
+		w.states[name] = state
+	}
+	return state
+}
+
+// startSubsystems starts each subsystem which isn't already running or starting.  A subsystem which fails
+// to start is retried at the next check while the instance is active.  No more subsystems are started once
+// the context is cancelled.
+func (w *Watcher) startSubsystems(ctx context.Context, subsystems []Subsystem) {
+	for _, subsystem := range subsystems {
+		if ctx.Err() != nil {
+			return
+		}
+		w.mutex.Lock()
+		state := w.state(subsystem.Name)
+		if state.running || state.starting != nil {
+			w.mutex.Unlock()
+			continue
+		}
+		if !subsystem.Background {
+			w.mutex.Unlock()
+			running := w.startSubsystem(ctx, subsystem)
+			w.mutex.Lock()
+			state.running = running
+			w.mutex.Unlock()
+			continue
+		}
+		done := make(chan struct{})
+		state.starting = done
+		w.mutex.Unlock()
+		w.background.Add(1)
+		go func() {
+			defer w.background.Done()
+			defer close(done)
+			running := w.startSubsystem(ctx, subsystem)
+			w.mutex.Lock()
+			state.running = running
+			state.starting = nil
+			w.mutex.Unlock()
+		}()
+	}
+}
+
+// startSubsystem starts a subsystem, and returns true if it started successfully
+func (w *Watcher) startSubsystem(ctx context.Context, subsystem Subsystem) bool {
+	w.log.Printf("Starting %v, as the queue manager is active", subsystem.Name)
+	err := subsystem.Start(ctx)
+	if err != nil {
+		w.log.Printf("Error starting %v: %v", subsystem.Name, err)
+		return false
+	}
+	return true
+}
+
+// stopSubsystems stops each running subsystem, in the reverse order to which they were started.  A
+// subsystem which is starting in the background is stopped once it has started.
+func (w *Watcher) stopSubsystems(ctx context.Context, subsystems []Subsystem) {
+	for i := len(subsystems) - 1; i >= 0; i-- {
+		subsystem := subsystems[i]
+		w.mutex.Lock()
+		state := w.state(subsystem.Name)
+		starting := state.starting
+		w.mutex.Unlock()
+		if starting != nil {
+			<-starting
+		}
+		w.mutex.Lock()
+		running := state.running
+		state.running = false
+		w.mutex.Unlock()
+		if !running || subsystem.Stop == nil {
+			continue
+		}
+		w.log.Printf("Stopping %v, as the queue manager is no longer active", subsystem.Name)
+		err := subsystem.Stop(ctx)
+		if err != nil {
+			w.log.Printf("Error stopping %v: %v", subsystem.Name, err)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package role
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+)
+
+// errStatus is used in a sequence of roles to make the status function return an error
+const errStatus Role = "error"
+
+// newTestWatcher returns a watcher which reports each of the roles in turn, and records the
+// transitions and subsystem calls it makes
+func newTestWatcher(t *testing.T, roles []Role, failStart bool) (*Watcher, *[]string, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	log, err := logger.NewLogger(buf, true, false, t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := 0
+	status := func(ctx context.Context) (Role, error) {
+		role := roles[next]
+		next++
+		if role == errStatus {
+			return Unknown, errors.New("dspmq failed")
+		}
+		return role, nil
+	}
+	calls := []string{}
+	w := NewWatcher(status, time.Millisecond, log)
+	w.OnTransition(func(t Transition) {
+		calls = append(calls, t.String())
+	})
+	for _, name := range []string{"a", "b"} {
+		w.Register(Subsystem{
+			Name: name,
+			Start: func(ctx context.Context) error {
+				calls = append(calls, "start "+name)
+				if failStart && name == "a" {
+					return errors.New("failed")
+				}
+				return nil
+			},
+			Stop: func(ctx context.Context) error {
+				calls = append(calls, "stop "+name)
+				return nil
+			},
+		})
+	}
+	return w, &calls, buf
+}
+
+func TestWatcherCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		roles     []Role
+		failStart bool
+		expected  []string
+		role      Role
+	}{
+		{"StartActive", []Role{Active, Active}, false, []string{"unknown->active", "start a", "start b"}, Active},
+		{"StartReplica", []Role{Replica, Replica}, false, []string{"unknown->replica"}, Replica},
+		{"ReplicaToActive", []Role{Replica, Starting, Active}, false, []string{"unknown->replica", "replica->active", "start a", "start b"}, Active},
+		{"StandbyToActive", []Role{Standby, Active}, false, []string{"unknown->standby", "standby->active", "start a", "start b"}, Active},
+		{"ActiveToReplica", []Role{Active, Replica}, false, []string{"unknown->active", "start a", "start b", "active->replica", "stop b", "stop a"}, Replica},
+		{"Failover", []Role{Active, Ended, Active}, false, []string{"unknown->active", "start a", "start b", "active->ended", "stop b", "stop a", "ended->active", "start a", "start b"}, Active},
+		{"TransientIgnored", []Role{Active, Unknown, errStatus, Starting, Active}, false, []string{"unknown->active", "start a", "start b"}, Active},
+		{"RetryFailedStart", []Role{Active, Active, Replica}, true, []string{"unknown->active", "start a", "start b", "start a", "active->replica", "stop b"}, Replica},
+		{"StartFailed", []Role{Active, Replica, Active}, true, []string{"unknown->active", "start a", "start b", "active->replica", "stop b", "replica->active", "start a", "start b"}, Active},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, calls, _ := newTestWatcher(t, test.roles, test.failStart)
+			for range test.roles {
+				w.check(context.Background())
+			}
+			if !slices.Equal(*calls, test.expected) {
+				t.Errorf("Expected calls %v; got %v", test.expected, *calls)
+			}
+			if w.Role() != test.role {
+				t.Errorf("Expected role %v; got %v", test.role, w.Role())
+			}
+		})
+	}
+}
+
+func TestWatcherBackgroundStart(t *testing.T) {
+	roles := []Role{Active, Active, Replica}
+	w, calls, _ := newTestWatcher(t, roles, false)
+	release := make(chan struct{})
+	starts := 0
+	stopped := false
+	w.Register(Subsystem{
+		Name: "slow",
+		Start: func(ctx context.Context) error {
+			starts++
+			<-release
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			stopped = true
+			return nil
+		},
+		Background: true,
+	})
+
+	// The role is checked again while the subsystem is starting, without starting it twice
+	w.check(context.Background())
+	w.check(context.Background())
+	expected := []string{"unknown->active", "start a", "start b"}
+	if !slices.Equal(*calls, expected) {
+		t.Errorf("Expected calls %v; got %v", expected, *calls)
+	}
+
+	// Stopping waits for the start to complete
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+	w.check(context.Background())
+	if starts != 1 {
+		t.Errorf("Expected 1 start; got %v", starts)
+	}
+	if !stopped {
+		t.Error("Expected the subsystem to be stopped once it had started")
+	}
+}
+
+func TestWatcherLogsTransitions(t *testing.T) {
+	w, _, buf := newTestWatcher(t, []Role{Replica, Active}, false)
+	w.check(context.Background())
+	w.check(context.Background())
+	for _, expected := range []string{"Queue manager role is replica", "Queue manager role changed from replica to active", "Starting a, as the queue manager is active"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected log to contain %q; got %v", expected, buf.String())
+		}
+	}
+}
+
+func TestWatcherRun(t *testing.T) {
+	w, calls, _ := newTestWatcher(t, []Role{Replica, Replica, Active, Active, Active, Active, Active, Active}, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	w.OnTransition(func(t Transition) {
+		if t.Activated() {
+			cancel()
+		}
+	})
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the watcher to stop when the context was cancelled")
+	}
+	if slices.Contains(*calls, "start a") {
+		t.Errorf("Expected subsystems not to be started once the context was cancelled; got %v", *calls)
+	}
+}
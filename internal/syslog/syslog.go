@@ -0,0 +1,410 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package syslog forwards structured log entries to a syslog server, using the RFC 5424 format
+package syslog
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultBufferSize is the number of messages held while the syslog server is unavailable
+	defaultBufferSize = 1000
+	dialTimeout       = 10 * time.Second
+	writeTimeout      = 10 * time.Second
+	// defaultRetryInterval is the time between attempts to reconnect to the syslog server
+	defaultRetryInterval = 5 * time.Second
+	// dropReportInterval is the minimum time between reports of messages dropped because the queue was full
+	dropReportInterval = time.Minute
+	// timestampFormat is the RFC 5424 timestamp format, which allows at most microsecond precision
+	timestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// Syslog severities
+const (
+	severityCritical = 2
+	severityError    = 3
+	severityWarning  = 4
+	severityNotice   = 5
+	severityInfo     = 6
+	severityDebug    = 7
+)
+
+// facilities maps the supported facility names to their codes
+var facilities = map[string]int{
+	"user":   1,
+	"daemon": 3,
+	"local0": 16,
+	"local1": 17,
+	"local2": 18,
+	"local3": 19,
+	"local4": 20,
+	"local5": 21,
+	"local6": 22,
+	"local7": 23,
+}
+
+// Config is the configuration of a syslog Writer
+type Config struct {
+	// Network is one of "udp", "tcp", "tls" or "unix"
+	Network string
+	// Address is the host and port of the syslog server, or the path of a unix socket
+	Address string
+	// Facility is the syslog facility code
+	Facility int
+	// Hostname is used as the HOSTNAME of each message
+	Hostname string
+	// AppName is used as the APP-NAME of each message
+	AppName string
+	// TLSConfig is used to connect to the syslog server when the network is "tls"
+	TLSConfig *tls.Config
+	// BufferSize is the number of messages which are held while waiting to be sent
+	BufferSize int
+	// RetryInterval is the time between attempts to reconnect to the syslog server
+	RetryInterval time.Duration
+	// ErrorLog is called when messages can't be sent.  It must not log to the Writer.
+	ErrorLog func(msg string)
+}
+
+// A Writer sends log entries to a syslog server in the background, using a bounded queue.  While the server
+// is unavailable, messages are held in the queue until it can be reached again.  Messages are dropped if the
+// queue is full.
+type Writer struct {
+	config   Config
+	messages chan []byte
+	done     chan struct{}
+	// stop is closed if the queued messages can't be sent before the Writer is closed
+	stop   chan struct{}
+	conn   net.Conn
+	stream bool
+	// framed is true if stream messages are prefixed with their length, rather than terminated by a new-line
+	framed         bool
+	closeOnce      sync.Once
+	dropped        int
+	failing        bool
+	lastDropReport time.Time
+	// mu protects closed and dropped
+	mu     sync.Mutex
+	closed bool
+}
+
+// ParseAddress parses a syslog server address of the form "udp://host:port", "tcp://host:port",
+// "tls://host:port" or "unix:///path".  If the port is omitted, the standard port for the network is used.
+func ParseAddress(address string) (string, string, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid syslog address '%v': %w", address, err)
+	}
+	switch u.Scheme {
+	case "unix":
+		if u.Path == "" {
+			return "", "", fmt.Errorf("invalid syslog address '%v': missing socket path", address)
+		}
+		return u.Scheme, u.Path, nil
+	case "udp", "tcp", "tls":
+		if u.Hostname() == "" {
+			return "", "", fmt.Errorf("invalid syslog address '%v': missing host", address)
+		}
+		port := u.Port()
+		if port == "" {
+			port = "514"
+			if u.Scheme == "tls" {
+				port = "6514"
+			}
+		}
+		return u.Scheme, net.JoinHostPort(u.Hostname(), port), nil
+	}
+	return "", "", fmt.Errorf("invalid syslog address '%v': the scheme must be one of 'udp', 'tcp', 'tls' or 'unix'", address)
+}
+
+// Facility returns the code for the named syslog facility
+func Facility(name string) (int, error) {
+	if name == "" {
+		return facilities["user"], nil
+	}
+	f, ok := facilities[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unsupported syslog facility '%v'", name)
+	}
+	return f, nil
+}
+
+// Severity returns the syslog severity for an MQ or Liberty 'loglevel'
+func Severity(logLevel string) int {
+	switch strings.ToUpper(logLevel) {
+	case "FATAL":
+		return severityCritical
+	case "ERROR", "SEVERE":
+		return severityError
+	case "WARNING":
+		return severityWarning
+	case "AUDIT":
+		return severityNotice
+	case "DEBUG", "FINE", "FINER", "FINEST", "ENTRY", "EXIT", "EVENT":
+		return severityDebug
+	}
+	return severityInfo
+}
+
+// New creates a Writer, which connects to the syslog server when the first message is sent
+func New(config Config) (*Writer, error) {
+	switch config.Network {
+	case "udp", "tcp", "tls", "unix":
+	default:
+		return nil, fmt.Errorf("unsupported syslog network '%v'", config.Network)
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = defaultBufferSize
+	}
+	if config.RetryInterval <= 0 {
+		config.RetryInterval = defaultRetryInterval
+	}
+	if config.ErrorLog == nil {
+		config.ErrorLog = func(string) {}
+	}
+	w := &Writer{
+		config:   config,
+		messages: make(chan []byte, config.BufferSize),
+		done:     make(chan struct{}),
+		stop:     make(chan struct{}),
+	}
+	go w.run()
+	return w, nil
+}
+
+// Send formats a log entry as a syslog message, and queues it to be sent.  The entry is not retained.
+func (w *Writer) Send(entry map[string]interface{}) {
+	msg, err := w.format(entry, time.Now())
+	if err != nil {
+		w.config.ErrorLog(fmt.Sprintf("Unable to format log entry for syslog: %v", err))
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+	select {
+	case w.messages <- msg:
+	default:
+		w.dropped++
+	}
+}
+
+// Close sends any queued messages, waiting at most for the specified time, and closes the connection.
+// Messages which can't be sent in time are dropped.
+func (w *Writer) Close(timeout time.Duration) {
+	w.closeOnce.Do(func() {
+		w.mu.Lock()
+		w.closed = true
+		close(w.messages)
+		w.mu.Unlock()
+		select {
+		case <-w.done:
+		case <-time.After(timeout):
+			close(w.stop)
+		}
+	})
+}
+
+// format returns an RFC 5424 message for a log entry, with the JSON entry as the MSG
+func (w *Writer) format(entry map[string]interface{}, now time.Time) ([]byte, error) {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	logLevel, _ := entry["loglevel"].(string)
+	timestamp := now
+	if s, ok := entry["ibm_datetime"].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, strings.Replace(s, "+0000", "Z", 1)); err == nil {
+			timestamp = t
+		}
+	}
+	procID, _ := entry["ibm_processId"].(string)
+	msgID, _ := entry["ibm_messageId"].(string)
+	pri := w.config.Facility*8 + Severity(logLevel)
+	header := fmt.Sprintf("<%d>1 %s %s %s %s %s -",
+		pri,
+		timestamp.Format(timestampFormat),
+		headerField(w.config.Hostname, 255),
+		headerField(w.config.AppName, 48),
+		headerField(procID, 128),
+		headerField(msgID, 32))
+	return append([]byte(header+" "), body...), nil
+}
+
+// headerField returns a value which is valid for an RFC 5424 header field, which only allows printable
+// ASCII characters, or "-" if the value is empty
+func headerField(value string, maxLen int) string {
+	if value == "" {
+		return "-"
+	}
+	b := []byte(value)
+	for i, c := range b {
+		if c < 33 || c > 126 {
+			b[i] = '_'
+		}
+	}
+	if len(b) > maxLen {
+		b = b[:maxLen]
+	}
+	return string(b)
+}
+
+// run sends queued messages until the Writer is closed.  While the syslog server is unavailable, no more
+// messages are taken from the queue, so that they are held until the server can be reached again.
+func (w *Writer) run() {
+	defer close(w.done)
+	defer func() {
+		if w.conn != nil {
+			w.conn.Close()
+		}
+	}()
+	for msg := range w.messages {
+		for !w.send(msg) {
+			select {
+			case <-w.stop:
+				// The remaining messages can't be sent before the Writer is closed
+				dropped := 1
+				for range w.messages {
+					dropped++
+				}
+				w.mu.Lock()
+				w.dropped += dropped
+				w.mu.Unlock()
+				w.reportDropped()
+				return
+			case <-time.After(w.config.RetryInterval):
+			}
+		}
+		if time.Since(w.lastDropReport) >= dropReportInterval {
+			w.reportDropped()
+		}
+	}
+	w.reportDropped()
+}
+
+// send writes a message to the syslog server, reconnecting once if the connection has failed.  It returns
+// false if the message couldn't be sent.
+func (w *Writer) send(msg []byte) bool {
+	for attempt := 0; attempt < 2; attempt++ {
+		if w.conn == nil {
+			err := w.connect()
+			if err != nil {
+				w.setFailing(err)
+				return false
+			}
+		}
+		err := w.write(msg)
+		if err == nil {
+			w.setRecovered()
+			return true
+		}
+		w.conn.Close()
+		w.conn = nil
+		w.setFailing(err)
+	}
+	return false
+}
+
+func (w *Writer) connect() error {
+	var err error
+	switch w.config.Network {
+	case "tls":
+		dialer := &net.Dialer{Timeout: dialTimeout}
+		w.conn, err = tls.DialWithDialer(dialer, "tcp", w.config.Address, w.config.TLSConfig)
+		w.stream, w.framed = true, true
+	case "tcp":
+		w.conn, err = net.DialTimeout("tcp", w.config.Address, dialTimeout)
+		w.stream, w.framed = true, true
+	case "udp":
+		w.conn, err = net.DialTimeout("udp", w.config.Address, dialTimeout)
+		w.stream, w.framed = false, false
+	case "unix":
+		// Local syslog daemons usually use a datagram socket, but some use a stream socket
+		w.conn, err = net.DialTimeout("unixgram", w.config.Address, dialTimeout)
+		w.stream, w.framed = false, false
+		if err != nil {
+			w.conn, err = net.DialTimeout("unix", w.config.Address, dialTimeout)
+			w.stream = true
+		}
+	}
+	if err != nil {
+		w.conn = nil
+	}
+	return err
+}
+
+// write sends one message.  Messages on a TCP or TLS connection use octet-counting framing, as
+// described in RFC 6587 and RFC 5425.
+func (w *Writer) write(msg []byte) error {
+	err := w.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err != nil {
+		return err
+	}
+	switch {
+	case w.framed:
+		_, err = w.conn.Write(append([]byte(strconv.Itoa(len(msg))+" "), msg...))
+	case w.stream:
+		_, err = w.conn.Write(append(msg, '\n'))
+	default:
+		_, err = w.conn.Write(msg)
+	}
+	return err
+}
+
+// setFailing reports the first failure to send to the syslog server
+func (w *Writer) setFailing(err error) {
+	if !w.failing {
+		w.config.ErrorLog(fmt.Sprintf("Unable to send log messages to syslog server %v: %v", w.config.Address, err))
+	}
+	w.failing = true
+}
+
+// setRecovered reports that messages are being sent again, and how many were dropped
+func (w *Writer) setRecovered() {
+	if !w.failing {
+		return
+	}
+	w.failing = false
+	w.mu.Lock()
+	dropped := w.dropped
+	w.dropped = 0
+	w.mu.Unlock()
+	w.config.ErrorLog(fmt.Sprintf("Resumed sending log messages to syslog server %v, after dropping %v messages", w.config.Address, dropped))
+}
+
+// reportDropped reports how many messages have been dropped since the last report, if there are any
+func (w *Writer) reportDropped() {
+	w.mu.Lock()
+	dropped := w.dropped
+	w.dropped = 0
+	w.mu.Unlock()
+	if dropped == 0 {
+		return
+	}
+	w.lastDropReport = time.Now()
+	w.config.ErrorLog(fmt.Sprintf("Dropped %v log messages which could not be sent to syslog server %v", dropped, w.config.Address))
+}
@@ -0,0 +1,306 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package syslog
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		address         string
+		expectedNetwork string
+		expectedAddress string
+		expectErr       bool
+	}{
+		{"udp://syslog.example.com", "udp", "syslog.example.com:514", false},
+		{"tcp://10.0.0.1:601", "tcp", "10.0.0.1:601", false},
+		{"tls://syslog.example.com", "tls", "syslog.example.com:6514", false},
+		{"tls://[::1]:6514", "tls", "[::1]:6514", false},
+		{"unix:///dev/log", "unix", "/dev/log", false},
+		{"unix://", "", "", true},
+		{"http://syslog.example.com", "", "", true},
+		{"syslog.example.com:514", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			network, address, err := ParseAddress(tt.address)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected an error; got %v %v", network, address)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if network != tt.expectedNetwork || address != tt.expectedAddress {
+				t.Errorf("Expected %v %v; got %v %v", tt.expectedNetwork, tt.expectedAddress, network, address)
+			}
+		})
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	tests := map[string]int{
+		"FATAL":   2,
+		"ERROR":   3,
+		"SEVERE":  3,
+		"WARNING": 4,
+		"AUDIT":   5,
+		"INFO":    6,
+		"":        6,
+		"debug":   7,
+		"FINEST":  7,
+	}
+	for level, expected := range tests {
+		if s := Severity(level); s != expected {
+			t.Errorf("Expected severity %v for '%v'; got %v", expected, level, s)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	w := &Writer{config: Config{Facility: 16, Hostname: "host1", AppName: "QM 1"}}
+	entry := map[string]interface{}{
+		"ibm_datetime":  "2026-03-04T05:06:07.890Z",
+		"ibm_messageId": "AMQ9999E",
+		"ibm_processId": "123",
+		"loglevel":      "ERROR",
+		"message":       "AMQ9999E: Channel program ended abnormally.",
+	}
+	msg, err := w.format(entry, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<131>1 2026-03-04T05:06:07.890000Z host1 QM_1 123 AMQ9999E - {"ibm_datetime":"2026-03-04T05:06:07.890Z","ibm_messageId":"AMQ9999E","ibm_processId":"123","loglevel":"ERROR","message":"AMQ9999E: Channel program ended abnormally."}`
+	if string(msg) != expected {
+		t.Errorf("Expected:\n%v\ngot:\n%v", expected, string(msg))
+	}
+}
+
+func TestFormatNilFields(t *testing.T) {
+	w := &Writer{config: Config{Facility: 1, AppName: "QM1"}}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	msg, err := w.format(map[string]interface{}{"message": "hello"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<14>1 2026-01-02T03:04:05.000000Z - QM1 - - - {"message":"hello"}`
+	if string(msg) != expected {
+		t.Errorf("Expected:\n%v\ngot:\n%v", expected, string(msg))
+	}
+}
+
+func TestWriterTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	received := make(chan string, 2)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			// Read the octet count, then the message
+			length, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(length))
+			if err != nil {
+				return
+			}
+			buf := make([]byte, n)
+			_, err = io.ReadFull(r, buf)
+			if err != nil {
+				return
+			}
+			received <- string(buf)
+		}
+	}()
+	w, err := New(Config{Network: "tcp", Address: l.Addr().String(), Facility: 1, AppName: "QM1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Send(map[string]interface{}{"loglevel": "INFO", "message": "first"})
+	w.Send(map[string]interface{}{"loglevel": "WARNING", "message": "second"})
+	w.Close(5 * time.Second)
+	for _, expected := range []string{`<14>1 `, `<12>1 `} {
+		select {
+		case msg := <-received:
+			if !strings.HasPrefix(msg, expected) {
+				t.Errorf("Expected message starting with %v; got %v", expected, msg)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for message")
+		}
+	}
+}
+
+func TestWriterUnixgram(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	w, err := New(Config{Network: "unix", Address: path, Facility: 1, AppName: "QM1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Send(map[string]interface{}{"loglevel": "ERROR", "message": "broken"})
+	w.Close(5 * time.Second)
+	buf := make([]byte, 4096)
+	err = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(buf[:n]), "<11>1 ") || !strings.HasSuffix(string(buf[:n]), `{"loglevel":"ERROR","message":"broken"}`) {
+		t.Errorf("Unexpected message: %v", string(buf[:n]))
+	}
+}
+
+func TestWriterUnavailable(t *testing.T) {
+	errs := []string{}
+	w, err := New(Config{Network: "unix", Address: filepath.Join(t.TempDir(), "missing"), Facility: 1, RetryInterval: 10 * time.Millisecond, ErrorLog: func(msg string) {
+		errs = append(errs, msg)
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Send(map[string]interface{}{"message": "first"})
+	w.Send(map[string]interface{}{"message": "second"})
+	w.Close(100 * time.Millisecond)
+	<-w.done
+	// Only the first failure is reported, followed by the messages which were dropped when the Writer was closed
+	if len(errs) != 2 || !strings.HasPrefix(errs[0], "Unable to send") || !strings.HasPrefix(errs[1], "Dropped 2 log messages") {
+		t.Errorf("Expected a failure and 2 dropped messages to be reported; got %v", errs)
+	}
+}
+
+func TestWriterReconnect(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	listen := func() *net.UnixConn {
+		conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conn
+	}
+	receive := func(conn *net.UnixConn, expected string) {
+		t.Helper()
+		buf := make([]byte, 4096)
+		err := conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasSuffix(string(buf[:n]), expected) {
+			t.Errorf("Expected message ending with %v; got %v", expected, string(buf[:n]))
+		}
+	}
+	conn := listen()
+	errs := make(chan string, 10)
+	w, err := New(Config{Network: "unix", Address: path, Facility: 1, RetryInterval: 50 * time.Millisecond, ErrorLog: func(msg string) {
+		errs <- msg
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close(5 * time.Second)
+	w.Send(map[string]interface{}{"message": "before"})
+	receive(conn, `{"message":"before"}`)
+
+	// Stop the listener, and send messages while it is unavailable
+	conn.Close()
+	err = os.Remove(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []string{"first", "second", "third"} {
+		w.Send(map[string]interface{}{"message": m})
+	}
+	select {
+	case msg := <-errs:
+		if !strings.HasPrefix(msg, "Unable to send") {
+			t.Fatalf("Expected a failure to be reported; got %v", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the failure to be reported")
+	}
+
+	// Restart the listener, and check the held messages are sent in order
+	conn = listen()
+	defer conn.Close()
+	for _, m := range []string{"first", "second", "third"} {
+		receive(conn, `{"message":"`+m+`"}`)
+	}
+	select {
+	case msg := <-errs:
+		if !strings.HasPrefix(msg, "Resumed sending log messages") || !strings.HasSuffix(msg, "after dropping 0 messages") {
+			t.Errorf("Expected recovery to be reported with no dropped messages; got %v", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the recovery to be reported")
+	}
+}
+
+func TestWriterReportsDropped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	errs := []string{}
+	w, err := New(Config{Network: "unix", Address: path, Facility: 1, ErrorLog: func(msg string) {
+		errs = append(errs, msg)
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Messages dropped because the queue was full are reported, even though the connection never fails
+	w.mu.Lock()
+	w.dropped = 3
+	w.mu.Unlock()
+	w.Send(map[string]interface{}{"message": "sent"})
+	w.Close(5 * time.Second)
+	<-w.done
+	if len(errs) != 1 || !strings.HasPrefix(errs[0], "Dropped 3 log messages") {
+		t.Errorf("Expected 3 dropped messages to be reported; got %v", errs)
+	}
+}
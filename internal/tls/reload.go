@@ -0,0 +1,195 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tls
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/ibm-messaging/mq-container/internal/pathutils"
+	"github.com/ibm-messaging/mq-container/internal/sensitive"
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+)
+
+// keystoreDirStaging is the location where the default CMS Keystore & PKCS#12 Truststore are rebuilt during a reload
+const keystoreDirStaging = "/run/runmqserver/tls-reload/"
+
+const (
+	reloadPollInterval = 1 * time.Minute
+	reloadDebounceTime = 2 * time.Second
+)
+
+// ReloadDefaultTLSKeystores rebuilds the default CMS Keystore & PKCS#12 Truststore from the keys and
+// certificates currently supplied.  The keystores are built in a staging directory, and only replace
+// the keystores in use once they have been built successfully.  The supplied password is reused, so
+// that existing configuration which refers to the keystores remains valid.
+func ReloadDefaultTLSKeystores(ctx context.Context, password *sensitive.Sensitive, log *logger.Logger) (string, KeyStoreData, KeyStoreData, error) {
+	err := os.RemoveAll(keystoreDirStaging)
+	if err != nil {
+		return "", KeyStoreData{}, KeyStoreData{}, fmt.Errorf("Failed to remove staging Keystore directory: %v", err)
+	}
+	certLabels, keyStore, trustStore, err := configureTLSKeystores(ctx, keystoreDirStaging, []string{keyDirDefault}, []string{trustDirDefault}, true, false, password, log)
+	if err != nil {
+		return "", keyStore, trustStore, err
+	}
+	err = replaceKeystoreFiles(keystoreDirStaging, keystoreDirDefault)
+	if err != nil {
+		return "", keyStore, trustStore, err
+	}
+	if keyStore.Keystore != nil {
+		keyStore.Keystore.Filename = pathutils.CleanPath(keystoreDirDefault, cmsKeystoreName)
+	}
+	if trustStore.Keystore != nil {
+		trustStore.Keystore.Filename = pathutils.CleanPath(keystoreDirDefault, p12TruststoreName)
+	}
+	certLabel := ""
+	if len(certLabels) > 0 {
+		certLabel = certLabels[0]
+	}
+	return certLabel, keyStore, trustStore, nil
+}
+
+// replaceKeystoreFiles moves all files from the source directory into the destination directory,
+// replacing any existing files with the same name, and then removes the source directory
+func replaceKeystoreFiles(srcDir, destDir string) error {
+	files, err := os.ReadDir(srcDir)
+	if err != nil {
+		return fmt.Errorf("Failed to read staging Keystore directory: %v", err)
+	}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		err = os.Rename(pathutils.CleanPath(srcDir, file.Name()), pathutils.CleanPath(destDir, file.Name()))
+		if err != nil {
+			return fmt.Errorf("Failed to replace Keystore file %s: %v", file.Name(), err)
+		}
+	}
+	return os.RemoveAll(srcDir)
+}
+
+// WatchDefaultTLSDirectories calls the supplied function whenever the keys or certificates supplied in
+// the default directories change.  Changes are detected using filesystem events, and a periodic poll to
+// catch any missed events.  Detections are debounced, to avoid reloading a partially updated set of files.
+func WatchDefaultTLSDirectories(ctx context.Context, onChange func(), log *logger.Logger) error {
+	return watchDirectories(ctx, []string{keyDirDefault, trustDirDefault}, reloadPollInterval, reloadDebounceTime, onChange, log)
+}
+
+func watchDirectories(ctx context.Context, dirs []string, pollInterval, debounceTime time.Duration, onChange func(), log *logger.Logger) error {
+	digest, err := directoryDigest(dirs...)
+	if err != nil {
+		return err
+	}
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to set up fsnotify: %w", err)
+	}
+	addWatches(fsWatcher, dirs)
+
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		defer fsWatcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-fsWatcher.Events:
+			case err := <-fsWatcher.Errors:
+				log.Debugf("Error watching TLS directories: %v", err)
+				continue
+			case <-ticker.C:
+			}
+			time.Sleep(debounceTime)
+			// Discard any events which arrived while waiting
+		drain:
+			for {
+				select {
+				case <-fsWatcher.Events:
+				default:
+					break drain
+				}
+			}
+			// New key or trust directories may have been added
+			addWatches(fsWatcher, dirs)
+			newDigest, err := directoryDigest(dirs...)
+			if err != nil {
+				log.Printf("Error reading TLS keys and certificates: %v", err)
+				continue
+			}
+			if newDigest != digest {
+				digest = newDigest
+				onChange()
+			}
+		}
+	}()
+	return nil
+}
+
+// addWatches watches each of the supplied directories, and each of their sub-directories
+func addWatches(fsWatcher *fsnotify.Watcher, dirs []string) {
+	for _, dir := range dirs {
+		// Errors are ignored, as a directory may not exist until keys or certificates are supplied
+		_ = fsWatcher.Add(dir)
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			_ = fsWatcher.Add(pathutils.CleanPath(dir, entry.Name()))
+		}
+	}
+}
+
+// directoryDigest returns a digest of the key (*.key) and certificate (*.crt) files in the sub-directories
+// of each of the supplied directories, using the same layout as when the keystores are configured
+func directoryDigest(dirs ...string) (string, error) {
+	h := sha256.New()
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", err
+		}
+		for _, entry := range entries {
+			// Skip the hidden directories used by Kubernetes to update mounted volumes atomically
+			if strings.HasPrefix(entry.Name(), "..") {
+				continue
+			}
+			files, _ := os.ReadDir(pathutils.CleanPath(dir, entry.Name()))
+			for _, file := range files {
+				if !strings.HasSuffix(file.Name(), ".key") && !strings.HasSuffix(file.Name(), ".crt") {
+					continue
+				}
+				filePath := pathutils.CleanPath(dir, entry.Name(), file.Name())
+				// #nosec G304 - filename variable is derived from contents of a defined constant directory
+				content, err := os.ReadFile(filePath)
+				if err != nil {
+					return "", fmt.Errorf("Failed to read file %s: %v", filePath, err)
+				}
+				h.Write([]byte(filePath))
+				h.Write(content)
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,107 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tls
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	err := os.MkdirAll(filepath.Dir(path), 0770)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(path, []byte(content), 0660)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDirectoryDigest(t *testing.T) {
+	keyDir := t.TempDir()
+	trustDir := filepath.Join(t.TempDir(), "missing")
+
+	empty, err := directoryDigest(keyDir, trustDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeTestFile(t, filepath.Join(keyDir, "default", "tls.key"), "key")
+	writeTestFile(t, filepath.Join(keyDir, "default", "tls.crt"), "crt")
+	initial, err := directoryDigest(keyDir, trustDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if initial == empty {
+		t.Errorf("Expected digest to change when keys were added")
+	}
+
+	// Files which are not keys or certificates, and hidden directories, are ignored
+	writeTestFile(t, filepath.Join(keyDir, "default", "README"), "ignored")
+	writeTestFile(t, filepath.Join(keyDir, "..2026_01_01", "tls.crt"), "ignored")
+	ignored, err := directoryDigest(keyDir, trustDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ignored != initial {
+		t.Errorf("Expected digest not to change when unrelated files were added")
+	}
+
+	writeTestFile(t, filepath.Join(keyDir, "default", "tls.crt"), "renewed")
+	renewed, err := directoryDigest(keyDir, trustDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renewed == initial {
+		t.Errorf("Expected digest to change when a certificate was updated")
+	}
+}
+
+func TestWatchDirectories(t *testing.T) {
+	log, _ := logger.NewLogger(os.Stdout, false, false, "test")
+	keyDir := t.TempDir()
+	writeTestFile(t, filepath.Join(keyDir, "default", "tls.crt"), "crt")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changed := make(chan bool, 10)
+	err := watchDirectories(ctx, []string{keyDir}, 100*time.Millisecond, 10*time.Millisecond, func() {
+		changed <- true
+	}, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-changed:
+		t.Fatal("Expected no change to be reported before files were updated")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	writeTestFile(t, filepath.Join(keyDir, "default", "tls.crt"), "renewed")
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected change to be reported after a certificate was updated")
+	}
+}
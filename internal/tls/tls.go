@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2019, 2024
+© Copyright IBM Corporation 2019, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -17,9 +17,11 @@ package tls
 
 import (
 	"bufio"
+	"context"
 	"crypto"
 	"crypto/rand"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -45,11 +47,16 @@ const cmsKeystoreName = "key.kdb"
 // p12TruststoreName is the name of the PKCS#12 Truststore
 const p12TruststoreName = "trust.p12"
 
-// keystoreDirDefault is the location for the default CMS Keystore & PKCS#12 Truststore
-const keystoreDirDefault = "/run/runmqserver/tls/"
+// tlsMQSCTemplate is the template for the MQSC which configures TLS for the queue manager
+const tlsMQSCTemplate = "/etc/mqm/15-tls.mqsc.tpl"
 
-// keystoreDirHA is the location for the HA CMS Keystore
-const keystoreDirHA = "/run/runmqserver/ha/tls/"
+var (
+	// keystoreDirDefault is the location for the default CMS Keystore & PKCS#12 Truststore
+	keystoreDirDefault = "/run/runmqserver/tls/"
+
+	// keystoreDirHA is the location for the HA CMS Keystore
+	keystoreDirHA = "/run/runmqserver/ha/tls/"
+)
 
 // keyDirDefault is the location of the default keys to import
 const keyDirDefault = "/etc/mqm/pki/keys"
@@ -86,7 +93,7 @@ type TLSStore struct {
 	Truststore KeyStoreData
 }
 
-func configureTLSKeystores(keystoreDir string, keyDirs, trustDirs []string, p12TruststoreRequired bool, nativeTLSHA bool, log *logger.Logger) ([]string, KeyStoreData, KeyStoreData, error) {
+func configureTLSKeystores(ctx context.Context, keystoreDir string, keyDirs, trustDirs []string, p12TruststoreRequired bool, nativeTLSHA bool, password *sensitive.Sensitive, log *logger.Logger) ([]string, KeyStoreData, KeyStoreData, error) {
 	var keyLabel string
 
 	cmsKeystoreRequired := false
@@ -99,7 +106,7 @@ func configureTLSKeystores(keystoreDir string, keyDirs, trustDirs []string, p12T
 		}
 	}
 	// Create the CMS Keystore & PKCS#12 Truststore (if required)
-	tlsStore, err := generateAllKeystores(keystoreDir, cmsKeystoreRequired, p12TruststoreRequired, nativeTLSHA)
+	tlsStore, err := generateAllKeystores(ctx, keystoreDir, cmsKeystoreRequired, p12TruststoreRequired, nativeTLSHA, password)
 	if err != nil {
 		return nil, tlsStore.Keystore, tlsStore.Truststore, err
 	}
@@ -108,7 +115,7 @@ func configureTLSKeystores(keystoreDir string, keyDirs, trustDirs []string, p12T
 	if tlsStore.Keystore.Keystore != nil {
 		for idx, keyDir := range keyDirs {
 			// Process all keys - add them to the CMS KeyStore
-			keyLabel, err = processKeys(&tlsStore, keystoreDir, keyDir, log)
+			keyLabel, err = processKeys(ctx, &tlsStore, keystoreDir, keyDir, log)
 			if err != nil {
 				return nil, tlsStore.Keystore, tlsStore.Truststore, err
 			}
@@ -118,7 +125,7 @@ func configureTLSKeystores(keystoreDir string, keyDirs, trustDirs []string, p12T
 
 	for _, trustDir := range trustDirs {
 		// Process all trust certificates - add them to the CMS KeyStore & PKCS#12 Truststore (if required)
-		err = processTrustCertificates(&tlsStore, trustDir)
+		err = processTrustCertificates(ctx, &tlsStore, trustDir)
 		if err != nil {
 			return nil, tlsStore.Keystore, tlsStore.Truststore, err
 		}
@@ -128,8 +135,12 @@ func configureTLSKeystores(keystoreDir string, keyDirs, trustDirs []string, p12T
 }
 
 // ConfigureDefaultTLSKeystores configures the CMS Keystore & PKCS#12 Truststore
-func ConfigureDefaultTLSKeystores(log *logger.Logger) (string, KeyStoreData, KeyStoreData, error) {
-	certLabels, keyStore, trustStore, err := configureTLSKeystores(keystoreDirDefault, []string{keyDirDefault}, []string{trustDirDefault}, true, false, log)
+func ConfigureDefaultTLSKeystores(ctx context.Context, log *logger.Logger) (string, KeyStoreData, KeyStoreData, error) {
+	return configureDefaultTLSKeystores(ctx, keystoreDirDefault, log)
+}
+
+func configureDefaultTLSKeystores(ctx context.Context, keystoreDir string, log *logger.Logger) (string, KeyStoreData, KeyStoreData, error) {
+	certLabels, keyStore, trustStore, err := configureTLSKeystores(ctx, keystoreDir, []string{keyDirDefault}, []string{trustDirDefault}, true, false, nil, log)
 	if err != nil {
 		return "", keyStore, trustStore, err
 	}
@@ -141,11 +152,15 @@ func ConfigureDefaultTLSKeystores(log *logger.Logger) (string, KeyStoreData, Key
 }
 
 // ConfigureHATLSKeystore configures the CMS Keystore & PKCS#12 Truststore
-func ConfigureHATLSKeystore(log *logger.Logger) (string, string, KeyStoreData, KeyStoreData, error) {
+func ConfigureHATLSKeystore(ctx context.Context, log *logger.Logger) (string, string, KeyStoreData, KeyStoreData, error) {
+	return configureHATLSKeystore(ctx, keystoreDirHA, log)
+}
+
+func configureHATLSKeystore(ctx context.Context, keystoreDir string, log *logger.Logger) (string, string, KeyStoreData, KeyStoreData, error) {
 	// *.crt files mounted to the HA TLS dir keyDirHA will be processed as trusted in the CMS keystore
 	keyDirs := []string{keyDirHA, keyDirGroupHA}
 	trustDirs := []string{trustDirGroupHA}
-	haCertLabels, haKeystore, haTruststore, err := configureTLSKeystores(keystoreDirHA, keyDirs, trustDirs, false, true, log)
+	haCertLabels, haKeystore, haTruststore, err := configureTLSKeystores(ctx, keystoreDir, keyDirs, trustDirs, false, true, nil, log)
 	if err != nil {
 		return "", "", haKeystore, haTruststore, err
 	}
@@ -156,18 +171,68 @@ func ConfigureHATLSKeystore(log *logger.Logger) (string, string, KeyStoreData, K
 	return haCertLabels[0], haCertLabels[1], haKeystore, haTruststore, err
 }
 
+// GetHATLSCertificateLabels returns the certificate labels which ConfigureHATLSKeystore would use for the
+// native HA and group keys.  The keystore is built in a temporary directory, which is removed afterwards,
+// so that the keystore in use by a running queue manager is left unchanged.
+func GetHATLSCertificateLabels(ctx context.Context, log *logger.Logger) (string, string, error) {
+	keystoreDir, err := os.MkdirTemp("", "ha-tls")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.RemoveAll(keystoreDir)
+	haCertLabel, haGroupCertLabel, _, _, err := configureHATLSKeystore(ctx, keystoreDir, log)
+	return haCertLabel, haGroupCertLabel, err
+}
+
 // ConfigureTLS configures TLS for the queue manager
-func ConfigureTLS(keyLabel string, cmsKeystore KeyStoreData, devMode bool, log *logger.Logger) error {
+func ConfigureTLS(ctx context.Context, keyLabel string, cmsKeystore KeyStoreData, devMode bool, log *logger.Logger) error {
 
 	const mqscLink string = "/run/15-tls.mqsc"
-	const mqscTemplate string = "/etc/mqm/15-tls.mqsc.tpl"
+
+	err := mqtemplate.ProcessTemplateFile(tlsMQSCTemplate, mqscLink, getTLSTemplateData(ctx, keyLabel, cmsKeystore), log)
+	if err != nil {
+		return err
+	}
+
+	if devMode && keyLabel != "" {
+		err = configureTLSDev(log)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RenderDefaultTLS writes the MQSC which ConfigureTLS would generate for the queue manager to w.  The keystores
+// are built in a temporary directory, which is removed afterwards, so that the keystores in use by a running
+// queue manager are left unchanged.  The MQSC refers to the keystore in its usual location.
+func RenderDefaultTLS(ctx context.Context, w io.Writer, log *logger.Logger) error {
+	keystoreDir, err := os.MkdirTemp("", "tls")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(keystoreDir)
+	keyLabel, cmsKeystore, _, err := configureDefaultTLSKeystores(ctx, keystoreDir, log)
+	if err != nil {
+		return err
+	}
+	data := getTLSTemplateData(ctx, keyLabel, cmsKeystore)
+	if data["SSLKeyR"] != "" {
+		data["SSLKeyR"] = strings.TrimSuffix(pathutils.CleanPath(keystoreDirDefault, cmsKeystoreName), ".kdb")
+	}
+	return mqtemplate.ProcessTemplate(tlsMQSCTemplate, w, data, log)
+}
+
+// getTLSTemplateData returns the values used to process the TLS MQSC template
+func getTLSTemplateData(ctx context.Context, keyLabel string, cmsKeystore KeyStoreData) map[string]string {
 	sslKeyRing := ""
 	var fipsEnabled = "NO"
 
 	// Don't set SSLKEYR if no keys or crts are not supplied
 	// Key label will be blank if no private keys were added during processing keys and certs.
 	if cmsKeystore.Keystore != nil && len(keyLabel) > 0 {
-		certList, _ := cmsKeystore.Keystore.ListAllCertificates()
+		certList, _ := cmsKeystore.Keystore.ListAllCertificates(ctx)
 		if len(certList) > 0 {
 			sslKeyRing = strings.TrimSuffix(cmsKeystore.Keystore.Filename, ".kdb")
 		}
@@ -176,23 +241,11 @@ func ConfigureTLS(keyLabel string, cmsKeystore KeyStoreData, devMode bool, log *
 			fipsEnabled = "YES"
 		}
 	}
-	err := mqtemplate.ProcessTemplateFile(mqscTemplate, mqscLink, map[string]string{
+	return map[string]string{
 		"SSLKeyR":          sslKeyRing,
 		"CertificateLabel": keyLabel,
 		"SSLFips":          fipsEnabled,
-	}, log)
-	if err != nil {
-		return err
 	}
-
-	if devMode && keyLabel != "" {
-		err = configureTLSDev(log)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
 }
 
 // configureTLSDev configures TLS for the developer defaults
@@ -211,8 +264,9 @@ func configureTLSDev(log *logger.Logger) error {
 	return nil
 }
 
-// generateAllKeystores creates the CMS Keystore & PKCS#12 Truststore (if required)
-func generateAllKeystores(keystoreDir string, createCMSKeystore bool, p12TruststoreRequired bool, nativeTLSHA bool) (TLSStore, error) {
+// generateAllKeystores creates the CMS Keystore & PKCS#12 Truststore (if required).
+// A random password is generated for the keystores, unless one is supplied.
+func generateAllKeystores(ctx context.Context, keystoreDir string, createCMSKeystore bool, p12TruststoreRequired bool, nativeTLSHA bool, password *sensitive.Sensitive) (TLSStore, error) {
 
 	var cmsKeystore, p12Truststore KeyStoreData
 
@@ -220,7 +274,10 @@ func generateAllKeystores(keystoreDir string, createCMSKeystore bool, p12Trustst
 	p12Truststore.keyLabelLookup = map[comparablePrivateKey]privateKeyInfo{}
 
 	// Generate a pasword for use with both the CMS Keystore & PKCS#12 Truststore
-	pw := generateRandomPassword()
+	pw := password
+	if pw == nil {
+		pw = generateRandomPassword()
+	}
 	cmsKeystore.Password = pw
 	p12Truststore.Password = pw
 
@@ -234,7 +291,7 @@ func generateAllKeystores(keystoreDir string, createCMSKeystore bool, p12Trustst
 	// Create the CMS Keystore if we have been provided keys and certificates
 	if createCMSKeystore {
 		cmsKeystore.Keystore = keystore.NewCMSKeyStore(pathutils.CleanPath(keystoreDir, cmsKeystoreName), cmsKeystore.Password)
-		err = cmsKeystore.Keystore.Create()
+		err = cmsKeystore.Keystore.Create(ctx)
 		if err != nil {
 			return TLSStore{cmsKeystore, p12Truststore}, fmt.Errorf("Failed to create CMS Keystore: %v", err)
 		}
@@ -243,7 +300,7 @@ func generateAllKeystores(keystoreDir string, createCMSKeystore bool, p12Trustst
 	// Create the PKCS#12 Truststore (if required)
 	if p12TruststoreRequired {
 		p12Truststore.Keystore = keystore.NewPKCS12KeyStore(pathutils.CleanPath(keystoreDir, p12TruststoreName), p12Truststore.Password)
-		err = p12Truststore.Keystore.Create()
+		err = p12Truststore.Keystore.Create(ctx)
 		if err != nil {
 			return TLSStore{cmsKeystore, p12Truststore}, fmt.Errorf("Failed to create PKCS#12 Truststore: %v", err)
 		}
@@ -253,7 +310,7 @@ func generateAllKeystores(keystoreDir string, createCMSKeystore bool, p12Trustst
 }
 
 // processKeys processes all keys - adding them to the CMS KeyStore
-func processKeys(tlsStore *TLSStore, keystoreDir string, keyDir string, log *logger.Logger) (string, error) {
+func processKeys(ctx context.Context, tlsStore *TLSStore, keystoreDir string, keyDir string, log *logger.Logger) (string, error) {
 
 	// Key label - will be set to the label of the first set of keys
 	keyLabel := ""
@@ -323,13 +380,13 @@ func processKeys(tlsStore *TLSStore, keystoreDir string, keyDir string, log *log
 			}
 
 			// Import the new PKCS#12 Keystore into the CMS Keystore
-			err = tlsStore.Keystore.Keystore.Import(keystorePath, tlsStore.Keystore.Password)
+			err = tlsStore.Keystore.Keystore.Import(ctx, keystorePath, tlsStore.Keystore.Password)
 			if err != nil {
 				return "", fmt.Errorf("Failed to import keys from %s into CMS Keystore: %v", keystorePath, err)
 			}
 
 			// Relabel the certificate in the CMS Keystore
-			err = relabelCertificate(keySet.Name(), &tlsStore.Keystore)
+			err = relabelCertificate(ctx, keySet.Name(), &tlsStore.Keystore)
 			if err != nil {
 				return "", err
 			}
@@ -345,7 +402,7 @@ func processKeys(tlsStore *TLSStore, keystoreDir string, keyDir string, log *log
 }
 
 // processTrustCertificates processes all trust certificates - adding them to the CMS KeyStore & PKCS#12 Truststore (if required)
-func processTrustCertificates(tlsStore *TLSStore, trustDir string) error {
+func processTrustCertificates(ctx context.Context, tlsStore *TLSStore, trustDir string) error {
 
 	// Process all trust certiifcates
 	trustList, err := os.ReadDir(trustDir)
@@ -392,7 +449,7 @@ func processTrustCertificates(tlsStore *TLSStore, trustDir string) error {
 
 	// Add all trust certificates to PKCS#12 Truststore (if required)
 	if tlsStore.Truststore.Keystore != nil && len(tlsStore.Truststore.TrustedCerts) > 0 {
-		err = addCertificatesToTruststore(&tlsStore.Truststore)
+		err = addCertificatesToTruststore(ctx, &tlsStore.Truststore)
 		if err != nil {
 			return err
 		}
@@ -400,7 +457,7 @@ func processTrustCertificates(tlsStore *TLSStore, trustDir string) error {
 
 	// Add all trust certificates to CMS Keystore
 	if len(tlsStore.Keystore.TrustedCerts) > 0 {
-		err = addCertificatesToCMSKeystore(&tlsStore.Keystore)
+		err = addCertificatesToCMSKeystore(ctx, &tlsStore.Keystore)
 		if err != nil {
 			return err
 		}
@@ -574,9 +631,9 @@ func processCertificates(keyDir string, keySetName, keyPrefix string, keys []os.
 }
 
 // relabelCertificate sets a new label for a certificate in the CMS Keystore
-func relabelCertificate(newLabel string, cmsKeystore *KeyStoreData) error {
+func relabelCertificate(ctx context.Context, newLabel string, cmsKeystore *KeyStoreData) error {
 
-	allLabels, err := cmsKeystore.Keystore.GetCertificateLabels()
+	allLabels, err := cmsKeystore.Keystore.GetCertificateLabels(ctx)
 	if err != nil {
 		return fmt.Errorf("Failed to get list of all certificate labels from CMS Keystore: %v", err)
 	}
@@ -590,7 +647,7 @@ func relabelCertificate(newLabel string, cmsKeystore *KeyStoreData) error {
 			}
 		}
 		if !found {
-			err = cmsKeystore.Keystore.RenameCertificate(strings.Trim(label, "\""), newLabel)
+			err = cmsKeystore.Keystore.RenameCertificate(ctx, strings.Trim(label, "\""), newLabel)
 			if err != nil {
 				return err
 			}
@@ -608,7 +665,7 @@ func relabelCertificate(newLabel string, cmsKeystore *KeyStoreData) error {
 }
 
 // addCertificatesToTruststore adds trust certificates to the PKCS#12 Truststore
-func addCertificatesToTruststore(p12Truststore *KeyStoreData) error {
+func addCertificatesToTruststore(ctx context.Context, p12Truststore *KeyStoreData) error {
 
 	temporaryPemFile := pathutils.CleanPath("/tmp", "trust.pem")
 	_, err := os.Stat(temporaryPemFile)
@@ -624,13 +681,13 @@ func addCertificatesToTruststore(p12Truststore *KeyStoreData) error {
 		return err
 	}
 
-	err = p12Truststore.Keystore.AddNoLabel(temporaryPemFile)
+	err = p12Truststore.Keystore.AddNoLabel(ctx, temporaryPemFile)
 	if err != nil {
 		return fmt.Errorf("Failed to add certificates to PKCS#12 Truststore: %v", err)
 	}
 
 	// Relabel all certiifcates
-	allCertificates, err := p12Truststore.Keystore.ListAllCertificates()
+	allCertificates, err := p12Truststore.Keystore.ListAllCertificates(ctx)
 	if err != nil || len(allCertificates) <= 0 {
 		return fmt.Errorf("Failed to get any certificates from PKCS#12 Truststore: %v", err)
 	}
@@ -640,7 +697,7 @@ func addCertificatesToTruststore(p12Truststore *KeyStoreData) error {
 		certificate = strings.TrimSpace(certificate)
 		newLabel := fmt.Sprintf("Trust%d", i)
 
-		err = p12Truststore.Keystore.RenameCertificate(certificate, newLabel)
+		err = p12Truststore.Keystore.RenameCertificate(ctx, certificate, newLabel)
 		if err != nil || len(allCertificates) <= 0 {
 			return fmt.Errorf("Failed to rename certificate %s to %s in PKCS#12 Truststore: %v", certificate, newLabel, err)
 		}
@@ -650,7 +707,7 @@ func addCertificatesToTruststore(p12Truststore *KeyStoreData) error {
 }
 
 // addCertificatesToCMSKeystore adds trust certificates to the CMS keystore
-func addCertificatesToCMSKeystore(cmsKeystore *KeyStoreData) error {
+func addCertificatesToCMSKeystore(ctx context.Context, cmsKeystore *KeyStoreData) error {
 
 	temporaryPemFile := pathutils.CleanPath("/tmp", "cmsTrust.pem")
 	_, err := os.Stat(temporaryPemFile)
@@ -666,7 +723,7 @@ func addCertificatesToCMSKeystore(cmsKeystore *KeyStoreData) error {
 		return err
 	}
 
-	err = cmsKeystore.Keystore.AddNoLabel(temporaryPemFile)
+	err = cmsKeystore.Keystore.AddNoLabel(ctx, temporaryPemFile)
 	if err != nil {
 		return fmt.Errorf("Failed to add certificates to CMS keystore: %v", err)
 	}
@@ -1,6 +1,14 @@
 package tls
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ibm-messaging/mq-container/pkg/logger"
+)
 
 func TestGeneratePassword(t *testing.T) {
 	previousPasswords := map[string]bool{}
@@ -21,3 +29,48 @@ func TestGeneratePassword(t *testing.T) {
 		previousPasswords[newPass.String()] = true
 	}
 }
+
+func TestRenderLeavesLiveKeystoresUnchanged(t *testing.T) {
+	log, _ := logger.NewLogger(os.Stdout, false, false, "test")
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+
+	liveDir := filepath.Join(t.TempDir(), "tls")
+	liveFiles := map[string]string{
+		cmsKeystoreName:   "live keystore",
+		"key.sth":         "live stash",
+		p12TruststoreName: "live truststore",
+	}
+	for name, content := range liveFiles {
+		writeTestFile(t, filepath.Join(liveDir, name), content)
+	}
+	liveHADir := filepath.Join(t.TempDir(), "ha", "tls")
+
+	previousDir, previousHADir := keystoreDirDefault, keystoreDirHA
+	keystoreDirDefault, keystoreDirHA = liveDir, liveHADir
+	t.Cleanup(func() { keystoreDirDefault, keystoreDirHA = previousDir, previousHADir })
+
+	// The keystore tools may not be installed, so errors are expected, but must not affect the live files
+	_ = RenderDefaultTLS(context.Background(), &bytes.Buffer{}, log)
+	_, _, _ = GetHATLSCertificateLabels(context.Background(), log)
+
+	for name, content := range liveFiles {
+		buf, err := os.ReadFile(filepath.Join(liveDir, name))
+		if err != nil {
+			t.Fatalf("Expected %v to be left in place: %v", name, err)
+		}
+		if string(buf) != content {
+			t.Errorf("Expected %v to be unchanged; got '%v'", name, string(buf))
+		}
+	}
+	if _, err := os.Stat(liveHADir); !os.IsNotExist(err) {
+		t.Errorf("Expected HA keystore directory not to be created; got %v", err)
+	}
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected temporary keystore directories to be removed; found %v", entries)
+	}
+}
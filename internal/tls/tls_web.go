@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2019, 2023
+© Copyright IBM Corporation 2019, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -16,6 +16,7 @@ limitations under the License.
 package tls
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -61,7 +62,7 @@ func ConfigureWebTLS(keyLabel, webKeystore string, p12Truststore KeyStoreData, l
 }
 
 // ConfigureWebKeyStore configures the Web Keystore
-func ConfigureWebKeystore(p12Truststore KeyStoreData, keyLabel string) (string, error) {
+func ConfigureWebKeystore(ctx context.Context, p12Truststore KeyStoreData, keyLabel string) (string, error) {
 
 	webKeystore := webKeystoreDefault
 	if keyLabel != "" {
@@ -77,13 +78,13 @@ func ConfigureWebKeystore(p12Truststore KeyStoreData, keyLabel string) (string,
 
 		// Create the Web Keystore
 		newWebKeystore := keystore.NewPKCS12KeyStore(webKeystoreFile, p12Truststore.Password)
-		err := newWebKeystore.Create()
+		err := newWebKeystore.Create(ctx)
 		if err != nil {
 			return "", fmt.Errorf("failed to create Web Keystore %s: %v", webKeystoreFile, err)
 		}
 
 		// Generate a new self-signed certificate in the Web Keystore
-		err = newWebKeystore.CreateSelfSignedCertificate("default", fmt.Sprintf("CN=%s", genHostName), genHostName)
+		err = newWebKeystore.CreateSelfSignedCertificate(ctx, "default", fmt.Sprintf("CN=%s", genHostName), genHostName)
 		if err != nil {
 			return "", fmt.Errorf("failed to generate certificate in Web Keystore %s with DN of 'CN=%s': %v", webKeystoreFile, genHostName, err)
 		}
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2021
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -35,9 +35,16 @@ const debugLevel string = "DEBUG"
 const infoLevel string = "INFO"
 const errorLevel string = "ERROR"
 
+// A Sink receives a copy of each structured log entry, for example to forward it to a remote server.
+// The entry must not be modified or retained after Send returns.
+type Sink interface {
+	Send(entry map[string]interface{})
+}
+
 // A Logger is used to log messages to stdout
 type Logger struct {
 	writer      *syncwriter.SyncWriter
+	sinks       []Sink
 	debug       bool
 	json        bool
 	processName string
@@ -73,6 +80,12 @@ func NewLogger(writer io.Writer, debug bool, json bool, serverName string) (*Log
 	}, nil
 }
 
+// AddSink adds a sink which receives a copy of each entry logged.  This must be called before
+// the logger is used by more than one goroutine.
+func (l *Logger) AddSink(sink Sink) {
+	l.sinks = append(l.sinks, sink)
+}
+
 func (l *Logger) format(entry map[string]interface{}) (string, error) {
 	if l.json {
 		b, err := json.Marshal(entry)
@@ -99,6 +112,9 @@ func (l *Logger) log(level string, msg string) {
 		"ibm_userName":    l.userName,
 		"type":            "mq_containerlog",
 	}
+	for _, sink := range l.sinks {
+		sink.Send(entry)
+	}
 	s, err := l.format(entry)
 	if err != nil {
 		syncwriter.For(os.Stderr).Println(err)
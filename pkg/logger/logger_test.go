@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2019
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -53,3 +53,29 @@ func TestSimpleLogger(t *testing.T) {
 		t.Errorf("Expected log output to contain %v; got %v", s, buf.String())
 	}
 }
+
+type testSink struct {
+	entries []map[string]interface{}
+}
+
+func (s *testSink) Send(entry map[string]interface{}) {
+	s.entries = append(s.entries, entry)
+}
+
+func TestLoggerSink(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l, err := NewLogger(buf, false, false, t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &testSink{}
+	l.AddSink(sink)
+	l.Error("Failed")
+	l.Debug("Not logged")
+	if len(sink.entries) != 1 {
+		t.Fatalf("Expected 1 entry to be sent to the sink; got %v", len(sink.entries))
+	}
+	if sink.entries[0]["message"] != "Failed" || sink.entries[0]["loglevel"] != "ERROR" || sink.entries[0]["ibm_serverName"] != t.Name() {
+		t.Errorf("Unexpected entry sent to the sink: %v", sink.entries[0])
+	}
+}
@@ -0,0 +1,311 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqini
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultIndent is used for new attributes, when a stanza has no existing attributes to copy
+const defaultIndent = "   "
+
+// line is a single line in an INI file.  Comments, blank lines and any other
+// text which isn't an attribute are kept in raw, and have an empty key.
+type line struct {
+	raw   string
+	key   string
+	value string
+}
+
+// Stanza is a named group of attributes in an INI file, such as "Log" or "ServiceComponent"
+type Stanza struct {
+	Name  string
+	lines []line
+}
+
+// File is an MQ INI file, such as qm.ini or mqs.ini.  The order of stanzas and
+// attributes is preserved, as are comments and blank lines, so that a file can
+// be edited and written back with only the changed lines being altered.
+type File struct {
+	// preamble holds any lines before the first stanza
+	preamble []line
+	stanzas  []*Stanza
+	// trailingNewline records whether the original file ended with a newline
+	trailingNewline bool
+}
+
+// Attribute is a key/value pair in a stanza
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// isComment returns true if the trimmed line is a comment
+func isComment(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";")
+}
+
+// parseLine parses a single line, returning the stanza name if the line starts a new stanza
+func parseLine(raw string) (line, string) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" || isComment(trimmed) {
+		return line{raw: raw}, ""
+	}
+	key, value, found := strings.Cut(trimmed, "=")
+	if !found {
+		if strings.HasSuffix(trimmed, ":") {
+			return line{raw: raw}, strings.TrimSpace(strings.TrimSuffix(trimmed, ":"))
+		}
+		return line{raw: raw}, ""
+	}
+	return line{raw: raw, key: strings.TrimSpace(key), value: strings.TrimSpace(value)}, ""
+}
+
+// Parse parses the contents of an INI file
+func Parse(data []byte) *File {
+	f := File{}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if text == "" {
+		return &f
+	}
+	f.trailingNewline = strings.HasSuffix(text, "\n")
+	text = strings.TrimSuffix(text, "\n")
+	var current *Stanza
+	for _, raw := range strings.Split(text, "\n") {
+		l, name := parseLine(raw)
+		switch {
+		case name != "":
+			current = &Stanza{Name: name, lines: []line{l}}
+			f.stanzas = append(f.stanzas, current)
+		case current != nil:
+			current.lines = append(current.lines, l)
+		default:
+			f.preamble = append(f.preamble, l)
+		}
+	}
+	return &f
+}
+
+// ReadFile reads and parses an INI file
+func ReadFile(path string) (*File, error) {
+	// #nosec G304 - the caller is responsible for the path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data), nil
+}
+
+// Bytes returns the contents of the INI file
+func (f *File) Bytes() []byte {
+	var buf bytes.Buffer
+	first := true
+	writeLines := func(lines []line) {
+		for _, l := range lines {
+			if !first {
+				buf.WriteString("\n")
+			}
+			buf.WriteString(l.raw)
+			first = false
+		}
+	}
+	writeLines(f.preamble)
+	for _, s := range f.stanzas {
+		writeLines(s.lines)
+	}
+	if f.trailingNewline && !first {
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+// WriteFile atomically replaces the file at the specified path with the contents of the INI file,
+// by writing to a temporary file in the same directory and renaming it
+func (f *File) WriteFile(path string, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(f.Bytes())
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(tmp.Name(), perm)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// AllStanzas returns all the stanzas in the file, in file order
+func (f *File) AllStanzas() []*Stanza {
+	return append([]*Stanza{}, f.stanzas...)
+}
+
+// Stanzas returns all the stanzas with the specified name, in file order.  Stanza names are
+// matched without regard to case.
+func (f *File) Stanzas(name string) []*Stanza {
+	stanzas := []*Stanza{}
+	for _, s := range f.stanzas {
+		if strings.EqualFold(s.Name, name) {
+			stanzas = append(stanzas, s)
+		}
+	}
+	return stanzas
+}
+
+// Stanza returns the first stanza with the specified name, or nil if there is no such stanza
+func (f *File) Stanza(name string) *Stanza {
+	for _, s := range f.stanzas {
+		if strings.EqualFold(s.Name, name) {
+			return s
+		}
+	}
+	return nil
+}
+
+// AddStanza adds a new, empty stanza at the end of the file
+func (f *File) AddStanza(name string) *Stanza {
+	if len(f.stanzas) == 0 && len(f.preamble) == 0 {
+		f.trailingNewline = true
+	}
+	s := &Stanza{Name: name, lines: []line{{raw: name + ":"}}}
+	f.stanzas = append(f.stanzas, s)
+	return s
+}
+
+// RemoveStanzas removes all the stanzas with the specified name for which the match function
+// returns true, and returns the number removed.  If match is nil, all the stanzas with the
+// specified name are removed.
+func (f *File) RemoveStanzas(name string, match func(*Stanza) bool) int {
+	kept := make([]*Stanza, 0, len(f.stanzas))
+	for _, s := range f.stanzas {
+		if strings.EqualFold(s.Name, name) && (match == nil || match(s)) {
+			continue
+		}
+		kept = append(kept, s)
+	}
+	removed := len(f.stanzas) - len(kept)
+	f.stanzas = kept
+	return removed
+}
+
+// Get returns the value of an attribute in the first stanza with the specified name
+func (f *File) Get(stanza string, key string) (string, bool) {
+	s := f.Stanza(stanza)
+	if s == nil {
+		return "", false
+	}
+	return s.Get(key)
+}
+
+// Set sets the value of an attribute in the first stanza with the specified name, adding
+// the stanza at the end of the file if it doesn't already exist.  It returns true if the
+// file was changed.
+func (f *File) Set(stanza string, key string, value string) bool {
+	s := f.Stanza(stanza)
+	if s == nil {
+		s = f.AddStanza(stanza)
+	}
+	return s.Set(key, value)
+}
+
+// Attributes returns the attributes in the stanza, in file order
+func (s *Stanza) Attributes() []Attribute {
+	attributes := []Attribute{}
+	for _, l := range s.lines {
+		if l.key != "" {
+			attributes = append(attributes, Attribute{Key: l.key, Value: l.value})
+		}
+	}
+	return attributes
+}
+
+// Get returns the value of the first attribute with the specified key.  Keys are matched
+// without regard to case.
+func (s *Stanza) Get(key string) (string, bool) {
+	for _, l := range s.lines {
+		if l.key != "" && strings.EqualFold(l.key, key) {
+			return l.value, true
+		}
+	}
+	return "", false
+}
+
+// Set sets the value of the first attribute with the specified key, or adds the attribute
+// after the last existing attribute in the stanza.  It returns true if the stanza was changed.
+func (s *Stanza) Set(key string, value string) bool {
+	last := 0
+	indent := defaultIndent
+	for i, l := range s.lines {
+		if l.key == "" {
+			continue
+		}
+		if strings.EqualFold(l.key, key) {
+			if l.value == value {
+				return false
+			}
+			lineIndent := l.raw[:len(l.raw)-len(strings.TrimLeft(l.raw, " \t"))]
+			s.lines[i] = line{raw: lineIndent + l.key + "=" + value, key: l.key, value: value}
+			return true
+		}
+		last = i
+		indent = l.raw[:len(l.raw)-len(strings.TrimLeft(l.raw, " \t"))]
+	}
+	l := line{raw: indent + key + "=" + value, key: key, value: value}
+	s.lines = append(s.lines[:last+1], append([]line{l}, s.lines[last+1:]...)...)
+	return true
+}
+
+// SetMatching sets the value of every attribute whose key is matched by the function, including
+// attributes which are repeated in the stanza, and returns the number changed
+func (s *Stanza) SetMatching(match func(key string) bool, value string) int {
+	changed := 0
+	for i, l := range s.lines {
+		if l.key == "" || l.value == value || !match(l.key) {
+			continue
+		}
+		lineIndent := l.raw[:len(l.raw)-len(strings.TrimLeft(l.raw, " \t"))]
+		s.lines[i] = line{raw: lineIndent + l.key + "=" + value, key: l.key, value: value}
+		changed++
+	}
+	return changed
+}
+
+// Remove removes all the attributes with the specified key, and returns true if any were removed
+func (s *Stanza) Remove(key string) bool {
+	kept := make([]line, 0, len(s.lines))
+	for _, l := range s.lines {
+		if l.key != "" && strings.EqualFold(l.key, key) {
+			continue
+		}
+		kept = append(kept, l)
+	}
+	removed := len(kept) != len(s.lines)
+	s.lines = kept
+	return removed
+}
@@ -0,0 +1,196 @@
+/*
+© Copyright IBM Corporation 2026
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mqini
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testQMIni = `#*******************************************************************#
+#* Module Name: qm.ini                                             *#
+#*******************************************************************#
+ExitPath:
+   ExitsDefaultPath=/mnt/mqm/data/exits
+   ExitsDefaultPath64=/mnt/mqm/data/exits64
+#*                                                                 *#
+Log:
+   LogPrimaryFiles=3
+   LogSecondaryFiles=2
+   LogFilePages=4096
+   LogType=CIRCULAR
+   LogBufferPages=0
+   LogPath=/mnt/mqm-log/log/QM1/
+
+ServiceComponent:
+   Service=AuthorizationService
+   Name=MQSeries.UNIX.auth.service
+   Module=amqzfu
+   ComponentDataSize=0
+ServiceComponent:
+   Service=AuthorizationService
+   Name=Dev.HtpAuth.Service
+   Module=/opt/mqm/lib64/mqsimpleauth.so
+   ComponentDataSize=0
+`
+
+func TestParseRoundTrip(t *testing.T) {
+	inputs := []string{testQMIni, "", "Log:\n   LogFilePages=1235", "Log:\r\n   LogFilePages=1235\r\n"}
+	for _, input := range inputs {
+		expected := input
+		if input == "Log:\r\n   LogFilePages=1235\r\n" {
+			expected = "Log:\n   LogFilePages=1235\n"
+		}
+		actual := string(Parse([]byte(input)).Bytes())
+		if actual != expected {
+			t.Errorf("Expected:\n%q\nGot:\n%q", expected, actual)
+		}
+	}
+}
+
+func TestGet(t *testing.T) {
+	f := Parse([]byte(testQMIni))
+	tests := []struct {
+		stanza string
+		key    string
+		value  string
+		found  bool
+	}{
+		{"Log", "LogFilePages", "4096", true},
+		{"log", "logtype", "CIRCULAR", true},
+		{"Log", "LogPath", "/mnt/mqm-log/log/QM1/", true},
+		{"ServiceComponent", "Name", "MQSeries.UNIX.auth.service", true},
+		{"ExitPath", "LogFilePages", "", false},
+		{"TuningParameters", "Foo", "", false},
+	}
+	for _, table := range tests {
+		value, found := f.Get(table.stanza, table.key)
+		if value != table.value || found != table.found {
+			t.Errorf("Get(%v, %v) - expected %v and %v; got %v and %v", table.stanza, table.key, table.value, table.found, value, found)
+		}
+	}
+	stanzas := f.Stanzas("ServiceComponent")
+	if len(stanzas) != 2 {
+		t.Fatalf("Expected 2 ServiceComponent stanzas; got %v", len(stanzas))
+	}
+	if name, _ := stanzas[1].Get("Name"); name != "Dev.HtpAuth.Service" {
+		t.Errorf("Expected second ServiceComponent to be Dev.HtpAuth.Service; got %v", name)
+	}
+	if len(stanzas[1].Attributes()) != 4 {
+		t.Errorf("Expected 4 attributes; got %v", stanzas[1].Attributes())
+	}
+}
+
+func TestSet(t *testing.T) {
+	f := Parse([]byte("Log:\n      LogPrimaryFiles=3\n# comment\n\nTuningParameters:\n   Foo=Bar"))
+	if f.Set("Log", "LogPrimaryFiles", "3") {
+		t.Error("Expected no change when setting the existing value")
+	}
+	f.Set("Log", "LogPrimaryFiles", "10")
+	f.Set("Log", "LogSecondaryFiles", "5")
+	f.Set("Channels", "MaxChannels", "200")
+	expected := "Log:\n      LogPrimaryFiles=10\n      LogSecondaryFiles=5\n# comment\n\nTuningParameters:\n   Foo=Bar\nChannels:\n   MaxChannels=200"
+	actual := string(f.Bytes())
+	if actual != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, actual)
+	}
+
+	f = Parse([]byte{})
+	f.Set("Log", "LogPrimaryFiles", "10")
+	expected = "Log:\n   LogPrimaryFiles=10\n"
+	actual = string(f.Bytes())
+	if actual != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, actual)
+	}
+}
+
+func TestSetMatching(t *testing.T) {
+	f := Parse([]byte("Service:\n   Name=AuthorizationService\n   ServiceComponent=A\n    ServiceComponent=B\n   Other=C"))
+	changed := f.Stanza("Service").SetMatching(func(key string) bool {
+		return key == "ServiceComponent"
+	}, "X")
+	if changed != 2 {
+		t.Errorf("Expected 2 attributes to be changed; got %v", changed)
+	}
+	expected := "Service:\n   Name=AuthorizationService\n   ServiceComponent=X\n    ServiceComponent=X\n   Other=C"
+	actual := string(f.Bytes())
+	if actual != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, actual)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	f := Parse([]byte(testQMIni))
+	removed := f.RemoveStanzas("ServiceComponent", func(s *Stanza) bool {
+		name, _ := s.Get("Name")
+		return name == "Dev.HtpAuth.Service"
+	})
+	if removed != 1 || len(f.Stanzas("ServiceComponent")) != 1 {
+		t.Errorf("Expected one ServiceComponent stanza to be removed; removed %v", removed)
+	}
+	if f.RemoveStanzas("ServiceComponent", nil) != 1 || f.Stanza("ServiceComponent") != nil {
+		t.Error("Expected all ServiceComponent stanzas to be removed")
+	}
+	if !f.Stanza("Log").Remove("LogPath") {
+		t.Error("Expected LogPath to be removed")
+	}
+	if _, found := f.Get("Log", "LogPath"); found {
+		t.Error("Expected LogPath to be removed")
+	}
+	if f.Stanza("Log").Remove("LogPath") {
+		t.Error("Expected no change when removing a missing attribute")
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "qm.ini")
+	err := os.WriteFile(path, []byte(testQMIni), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Set("Log", "LogBufferPages", "512")
+	err = f.WriteFile(path, 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err = ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := f.Get("Log", "LogBufferPages"); v != "512" {
+		t.Errorf("Expected LogBufferPages=512; got %v", v)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0640 {
+		t.Errorf("Expected file mode 0640; got %v", fi.Mode().Perm())
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected temporary file to be removed; found %v entries", len(entries))
+	}
+}
@@ -1,5 +1,5 @@
 /*
-© Copyright IBM Corporation 2018, 2023
+© Copyright IBM Corporation 2018, 2026
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -18,10 +18,9 @@ limitations under the License.
 package mqini
 
 import (
-	"bufio"
+	"context"
 	"errors"
 	"os"
-	"strings"
 
 	"github.com/ibm-messaging/mq-container/internal/command"
 	"github.com/ibm-messaging/mq-container/internal/pathutils"
@@ -38,37 +37,34 @@ type QueueManager struct {
 
 // getQueueManagerFromStanza parses a queue manager stanza
 func getQueueManagerFromStanza(stanza string) (*QueueManager, error) {
-	scanner := bufio.NewScanner(strings.NewReader(stanza))
 	qm := QueueManager{}
-	for scanner.Scan() {
-		l := scanner.Text()
-		l = strings.TrimSpace(l)
-		t := strings.Split(l, "=")
-		switch t[0] {
-		case "Name":
-			qm.Name = t[1]
-		case "Prefix":
-			qm.Prefix = t[1]
-		case "Directory":
-			qm.Directory = t[1]
-		case "DataPath":
-			qm.DataPath = t[1]
-		case "InstallationName":
-			qm.InstallationName = t[1]
-		}
+	s := Parse([]byte(stanza)).Stanza("QueueManager")
+	if s == nil {
+		return nil, errors.New("no QueueManager stanza found")
 	}
-	return &qm, scanner.Err()
+	qm.Name, _ = s.Get("Name")
+	qm.Prefix, _ = s.Get("Prefix")
+	qm.Directory, _ = s.Get("Directory")
+	qm.DataPath, _ = s.Get("DataPath")
+	qm.InstallationName, _ = s.Get("InstallationName")
+	return &qm, nil
 }
 
 // GetQueueManager returns queue manager configuration information
 func GetQueueManager(name string) (*QueueManager, error) {
+	return GetQueueManagerContext(context.Background(), name)
+}
+
+// GetQueueManagerContext returns queue manager configuration information, stopping dspmqinf if the
+// context is done before it completes
+func GetQueueManagerContext(ctx context.Context, name string) (*QueueManager, error) {
 	_, err := os.Stat("/var/mqm/mqs.ini")
 	if err != nil {
 		// Don't run dspmqinf, which will generate an FDC if mqs.ini isn't there yet
 		return nil, errors.New("dspmqinf should not be run before crtmqdir")
 	}
 	// dspmqinf essentially returns a subset of mqs.ini, but it's simpler to parse
-	out, _, err := command.Run("dspmqinf", "-o", "stanza", name)
+	out, _, err := command.RunContext(ctx, "dspmqinf", "-o", "stanza", name)
 	if err != nil {
 		return nil, err
 	}